@@ -0,0 +1,92 @@
+// Command wallet-reconcile checks wallet balances against their operation
+// history and ledger accounts. It reads the same config as wallet-service
+// (CONFIG_PATH and DSN_POSTGRES), prints every mismatch as a JSON line to
+// stdout and exits with status 2 when any were found, 1 on failure.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"wallet-service/internal/config"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/lib/logger/sl"
+	"wallet-service/internal/services/reconcile"
+	"wallet-service/internal/storage/postgres"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+	"wallet-service/pkg/pgx-driver/transaction"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+func run() int {
+	mode := flag.String("mode", "incremental", "full or incremental")
+	resume := flag.Bool("resume", false, "continue the run in progress instead of starting a new one")
+	batchSize := flag.Int("batch-size", 0, "wallets per page (default reconcile.batch_size)")
+	flag.Parse()
+
+	cfg := config.MustLoad()
+
+	// stdout carries the report.
+	log := sl.InitLogger(cfg.Env, os.Stderr)
+
+	if *batchSize <= 0 {
+		*batchSize = cfg.Reconcile.BatchSize
+	}
+
+	storage, err := pgxdriver.New(cfg.Storage.Postgres.DSN, log, pgxdriver.MaxPoolSize(2))
+	if err != nil {
+		log.Error("failed to connect to postgres", sl.Err(err))
+		return 1
+	}
+	defer storage.Close()
+
+	txManager, err := transaction.NewManager(storage, log)
+	if err != nil {
+		log.Error("failed to create transaction manager", sl.Err(err))
+		return 1
+	}
+
+	reconciler := reconcile.New(txManager, log, postgres.NewReconciliationRepository(log, storage), *batchSize)
+
+	// An interrupted run stays in progress and continues with -resume.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	enc := json.NewEncoder(os.Stdout)
+	report := func(mismatch *models.WalletReconciliation) {
+		if err := enc.Encode(mismatch); err != nil {
+			log.Error("failed to write mismatch", sl.Err(err))
+		}
+	}
+
+	var result *models.ReconciliationRun
+	if *resume {
+		result, err = reconciler.Resume(ctx, report)
+	} else {
+		result, err = reconciler.Run(ctx, models.ReconciliationMode(strings.ToUpper(*mode)), report)
+	}
+	if err != nil {
+		log.Error("reconciliation failed", sl.Err(err))
+		return 1
+	}
+
+	log.Info("reconciliation finished",
+		slog.String("run_id", result.ID.String()),
+		slog.Int64("wallets_checked", result.WalletsChecked),
+		slog.Int64("mismatches", result.Mismatches),
+	)
+
+	if result.Mismatches > 0 {
+		return 2
+	}
+
+	return 0
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"wallet-service/internal/config"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/services/auth"
+	"wallet-service/internal/storage/postgres"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+)
+
+var errAPIKeyUsage = errors.New("usage: wallet-service apikey create NAME SCOPE...")
+
+// runAPIKey runs `wallet-service apikey create NAME SCOPE...`, which issues
+// keys without going through the API, e.g. the first admin key. The key and
+// its secret are printed to stdout as JSON; the secret is not shown again.
+func runAPIKey(cfg *config.Config, log *slog.Logger, args []string) error {
+	if len(args) < 3 || args[0] != "create" {
+		return errAPIKeyUsage
+	}
+
+	storage, err := pgxdriver.New(cfg.Storage.Postgres.DSN, log, pgxdriver.MaxPoolSize(1), pgxdriver.MinConns(0))
+	if err != nil {
+		return err
+	}
+	defer storage.Close()
+
+	scopes := make([]models.Scope, 0, len(args)-2)
+	for _, scope := range args[2:] {
+		scopes = append(scopes, models.Scope(scope))
+	}
+
+	authService := auth.New(log, postgres.NewAPIKeyRepository(log, storage))
+
+	key, secret, err := authService.CreateKey(context.Background(), args[1], scopes)
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(struct {
+		APIKey *models.APIKey `json:"api_key"`
+		Secret string         `json:"secret"`
+	}{key, secret})
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/grpc-server/interceptor"
+	walletgrpc "wallet-service/internal/grpc-server/wallet"
+	walletv1 "wallet-service/pkg/api/wallet/v1"
+
+	"google.golang.org/grpc"
+)
+
+// walletRPCScopes is the scope each WalletService method requires, matching
+// the scopes of the HTTP routes doing the same.
+var walletRPCScopes = map[string]models.Scope{
+	walletv1.WalletService_GetWallet_FullMethodName:      models.ScopeWalletsRead,
+	walletv1.WalletService_ListOperations_FullMethodName: models.ScopeWalletsRead,
+	walletv1.WalletService_CreateWallet_FullMethodName:   models.ScopeWalletsWrite,
+	walletv1.WalletService_Deposit_FullMethodName:        models.ScopeWalletsWrite,
+	walletv1.WalletService_Withdraw_FullMethodName:       models.ScopeWalletsWrite,
+}
+
+// newGRPCServer builds the gRPC server; a nil authenticator leaves it open,
+// like the HTTP API with auth disabled.
+func newGRPCServer(
+	log *slog.Logger,
+	walletService walletgrpc.WalletService,
+	authenticator interceptor.Authenticator,
+) *grpc.Server {
+
+	interceptors := []grpc.UnaryServerInterceptor{
+		interceptor.NewRequestID(),
+		interceptor.NewLogger(log),
+	}
+	if authenticator != nil {
+		interceptors = append(interceptors, interceptor.NewAuth(log, authenticator, walletRPCScopes))
+	}
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(interceptors...))
+
+	walletv1.RegisterWalletServiceServer(srv, walletgrpc.New(log, walletService))
+
+	return srv
+}
+
+// stopGRPCServer lets in-flight calls finish, and cuts them off once ctx
+// expires.
+func stopGRPCServer(ctx context.Context, srv *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		srv.Stop()
+	}
+}
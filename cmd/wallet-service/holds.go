@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"wallet-service/internal/lib/logger/sl"
+)
+
+type holdExpirer interface {
+	ExpireHolds(ctx context.Context, batchSize int) (int, error)
+}
+
+// runHoldExpiry periodically releases expired holds until ctx is canceled.
+// A full batch is followed immediately by another one to drain a backlog.
+func runHoldExpiry(ctx context.Context, log *slog.Logger, he holdExpirer, interval time.Duration, batchSize int) {
+	log = log.With(slog.String("component", "worker/hold-expiry"))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("hold expiry stopped")
+			return
+		case <-ticker.C:
+		}
+
+		for {
+			expired, err := he.ExpireHolds(ctx, batchSize)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Error("failed to expire holds", sl.Err(err))
+				}
+				break
+			}
+
+			if expired > 0 {
+				log.Info("expired holds released", slog.Int("count", expired))
+			}
+
+			if expired < batchSize {
+				break
+			}
+		}
+	}
+}
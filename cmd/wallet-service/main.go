@@ -4,18 +4,66 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
+	_ "time/tzdata" // daily balances accept any IANA time zone; the runtime image has no zoneinfo
 	"wallet-service/internal/config"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/grpc-server/interceptor"
+	apikeylist "wallet-service/internal/http-server/handlers/apikey/list"
+	apikeyrevoke "wallet-service/internal/http-server/handlers/apikey/revoke"
+	apikeysave "wallet-service/internal/http-server/handlers/apikey/save"
+	"wallet-service/internal/http-server/handlers/fx/quote"
+	"wallet-service/internal/http-server/handlers/health/live"
+	"wallet-service/internal/http-server/handlers/health/ready"
+	"wallet-service/internal/http-server/handlers/hold/authorize"
+	"wallet-service/internal/http-server/handlers/hold/capture"
+	"wallet-service/internal/http-server/handlers/hold/void"
+	"wallet-service/internal/http-server/handlers/operation/reverse"
+	"wallet-service/internal/http-server/handlers/wallet/balance"
+	"wallet-service/internal/http-server/handlers/wallet/batch"
+	"wallet-service/internal/http-server/handlers/wallet/buckets"
+	"wallet-service/internal/http-server/handlers/wallet/dailybalance"
+	"wallet-service/internal/http-server/handlers/wallet/exchange"
 	"wallet-service/internal/http-server/handlers/wallet/get"
+	"wallet-service/internal/http-server/handlers/wallet/history"
+	"wallet-service/internal/http-server/handlers/wallet/limits"
 	"wallet-service/internal/http-server/handlers/wallet/operation"
 	"wallet-service/internal/http-server/handlers/wallet/save"
+	"wallet-service/internal/http-server/handlers/wallet/statement"
+	"wallet-service/internal/http-server/handlers/wallet/status"
+	walletstream "wallet-service/internal/http-server/handlers/wallet/stream"
+	"wallet-service/internal/http-server/handlers/wallet/transfer"
+	"wallet-service/internal/http-server/handlers/webhook/deliveries"
+	webhookget "wallet-service/internal/http-server/handlers/webhook/get"
+	webhooklist "wallet-service/internal/http-server/handlers/webhook/list"
+	"wallet-service/internal/http-server/handlers/webhook/redeliver"
+	webhookremove "wallet-service/internal/http-server/handlers/webhook/remove"
+	webhooksave "wallet-service/internal/http-server/handlers/webhook/save"
+	webhookupdate "wallet-service/internal/http-server/handlers/webhook/update"
+	authmiddleware "wallet-service/internal/http-server/middleware/auth"
 	"wallet-service/internal/http-server/middleware/logger"
+	metricsmiddleware "wallet-service/internal/http-server/middleware/metrics"
+	tracingmiddleware "wallet-service/internal/http-server/middleware/tracing"
+	"wallet-service/internal/lib/fx"
 	"wallet-service/internal/lib/logger/sl"
+	"wallet-service/internal/lib/metrics"
+	"wallet-service/internal/lib/publisher"
+	"wallet-service/internal/lib/tracing"
+	"wallet-service/internal/services/auth"
+	"wallet-service/internal/services/health"
+	"wallet-service/internal/services/outbox"
+	"wallet-service/internal/services/reconcile"
+	"wallet-service/internal/services/stream"
 	"wallet-service/internal/services/wallet"
+	"wallet-service/internal/services/webhook"
+	"wallet-service/internal/storage/migrator"
 	"wallet-service/internal/storage/postgres"
 	pgxdriver "wallet-service/pkg/pgx-driver"
 	"wallet-service/pkg/pgx-driver/transaction"
@@ -27,10 +75,45 @@ import (
 func main() {
 	cfg := config.MustLoad()
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		// stdout carries the status.
+		log := sl.InitLogger(cfg.Env, os.Stderr)
+		if err := runMigrate(cfg, log, os.Args[2:]); err != nil {
+			log.Error("migrate failed", sl.Err(err))
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "apikey" {
+		log := sl.InitLogger(cfg.Env, os.Stderr)
+		if err := runAPIKey(cfg, log, os.Args[2:]); err != nil {
+			log.Error("apikey failed", sl.Err(err))
+			os.Exit(1)
+		}
+		return
+	}
+
 	log := sl.InitLogger(cfg.Env, os.Stdout)
 
 	log.Debug("CONFIG", cfg)
 
+	if err := prepareSchema(cfg, log); err != nil {
+		panic(err)
+	}
+
+	tCfg := cfg.Tracing
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+		Exporter:    tCfg.Exporter,
+		Endpoint:    tCfg.Endpoint,
+		Insecure:    tCfg.Insecure,
+		SampleRatio: tCfg.SampleRatio,
+		ServiceName: tCfg.ServiceName,
+	})
+	if err != nil {
+		panic(err)
+	}
+
 	pCfg := cfg.Storage.Postgres
 
 	storage, err := pgxdriver.New(
@@ -44,39 +127,209 @@ func main() {
 		panic(err)
 	}
 
-	txManger, err := transaction.NewManager(storage, log)
+	serviceMetrics := metrics.New()
+	serviceMetrics.RegisterPool(storage)
+
+	txManger, err := transaction.NewManager(storage, log, transaction.OnRetry(serviceMetrics.TransactionRetried))
 	if err != nil {
 		panic(err)
 	}
 
 	walletRepository := postgres.NewWalletRepository(log, storage)
 	operationRepository := postgres.NewOperationRepository(log, storage)
+	idempotencyRepository := postgres.NewIdempotencyRepository(log, storage)
+	holdRepository := postgres.NewHoldRepository(log, storage)
+	ledgerRepository := postgres.NewLedgerRepository(log, storage)
+	quoteRepository := postgres.NewQuoteRepository(log, storage)
+	limitRepository := postgres.NewLimitRepository(log, storage)
+	outboxRepository := postgres.NewOutboxRepository(log, storage)
+	webhookRepository := postgres.NewWebhookRepository(log, storage)
+	reconciliationRepository := postgres.NewReconciliationRepository(log, storage)
+	schemaRepository := postgres.NewSchemaRepository(log, storage)
+	apiKeyRepository := postgres.NewAPIKeyRepository(log, storage)
+
+	fxRates, err := loadFXRates(cfg.FX.RatesFile)
+	if err != nil {
+		panic(err)
+	}
 
 	walletService := wallet.New(
 		txManger,
 		log,
-		walletRepository,
-		walletRepository,
-		walletRepository,
-		operationRepository)
+		wallet.Wallets(walletRepository),
+		wallet.Operations(operationRepository),
+		wallet.Idempotency(idempotencyRepository),
+		wallet.Holds(holdRepository),
+		wallet.Ledger(ledgerRepository),
+		wallet.FXRates(fxRates),
+		wallet.Quotes(quoteRepository),
+		wallet.QuoteTTL(cfg.FX.QuoteTTL),
+		wallet.Limits(limitRepository),
+		wallet.DefaultLimits(models.Limits{
+			MaxSingleWithdrawal: cfg.Limits.MaxSingleWithdrawal,
+			DailyWithdrawal:     cfg.Limits.DailyWithdrawal,
+			MonthlyWithdrawal:   cfg.Limits.MonthlyWithdrawal,
+			MaxBalance:          cfg.Limits.MaxBalance,
+			MaxOperations:       cfg.Limits.MaxOperations,
+			OperationWindow:     cfg.Limits.OperationWindow,
+		}),
+		wallet.Outbox(outboxRepository),
+		wallet.Metrics(serviceMetrics))
+
+	// Plain deposits through the HTTP API may be coalesced per wallet.
+	var operationService operation.WalletService = walletService
+	var depositCoalescer *wallet.DepositCoalescer
+	if cfg.Coalescing.Enabled {
+		depositCoalescer = wallet.NewDepositCoalescer(walletService, log, cfg.Coalescing.Window, cfg.Coalescing.MaxBatch)
+		operationService = depositCoalescer
+	}
+
+	eventPublisher, err := newEventPublisher(cfg.Outbox.Publisher, cfg.Outbox.FilePath)
+	if err != nil {
+		panic(err)
+	}
+
+	webhookService := webhook.New(
+		txManger,
+		log,
+		webhookRepository,
+		webhookRepository,
+		&http.Client{Timeout: cfg.Webhooks.Timeout},
+		cfg.Webhooks.MaxAttempts,
+		cfg.Webhooks.BaseBackoff,
+		cfg.Webhooks.MaxBackoff)
+
+	outboxRelay := outbox.New(
+		txManger,
+		log,
+		outboxRepository,
+		publisher.NewMultiPublisher(eventPublisher, webhookService),
+		cfg.Outbox.MaxAttempts,
+		cfg.Outbox.BaseBackoff,
+		cfg.Outbox.MaxBackoff)
+
+	streamHub := stream.NewHub(log)
+
+	authService := auth.New(log, apiKeyRepository)
 
 	router := chi.NewRouter()
 
+	healthChecker := health.New(log, storage, schemaRepository, storage, migrator.LatestVersion())
+
 	// middleware
 	router.Use(middleware.RequestID)
+	router.Use(tracingmiddleware.NewTracingMiddleware(log))
 	router.Use(middleware.Logger)
 	router.Use(logger.NewLoggerMiddleware(log))
+	router.Use(metricsmiddleware.NewMetricsMiddleware(log, serviceMetrics))
 	router.Use(middleware.Recoverer)
 	router.Use(middleware.URLFormat)
 
+	router.Handle("/metrics", serviceMetrics.Handler())
+	router.Get("/healthz", live.New(log))
+	router.Get("/readyz", ready.New(log, healthChecker))
+
 	router.Route("/api/v1", func(r chi.Router) {
-		r.Post("/wallets", save.New(log, walletService))
-		r.Post("/wallets/operation", operation.New(log, walletService))
+		if cfg.Auth.Enabled {
+			r.Use(authmiddleware.NewAuthMiddleware(log, authService))
+		} else {
+			log.Warn("api key authentication is disabled, the http and grpc apis are open to every client")
+		}
+
+		r.Group(func(r chi.Router) {
+			r.Use(requireScope(cfg.Auth.Enabled, models.ScopeWalletsWrite))
 
-		r.Get("/wallets/{WALLET_UUID}", get.New(log, walletService))
+			r.Post("/wallets", save.New(log, walletService))
+			r.Post("/wallets/operation", operation.New(log, operationService))
+			r.Post("/wallets/operations/batch", batch.New(log, walletService, cfg.Batch.Timeout))
+			r.Post("/wallets/transfers", transfer.New(log, walletService))
+			r.Post("/wallets/exchanges", exchange.New(log, walletService))
 
+			r.Post("/wallets/{WALLET_UUID}/holds", authorize.New(log, walletService))
+			r.Post("/holds/{HOLD_UUID}/capture", capture.New(log, walletService))
+			r.Post("/holds/{HOLD_UUID}/void", void.New(log, walletService))
+
+			r.Post("/fx/quotes", quote.New(log, walletService))
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(requireScope(cfg.Auth.Enabled, models.ScopeWalletsRead))
+
+			r.Get("/wallets/{WALLET_UUID}", get.New(log, walletService))
+			r.Get("/wallets/{WALLET_UUID}/operations", history.New(log, walletService))
+			r.Get("/wallets/{WALLET_UUID}/balance", balance.New(log, walletService))
+			r.Get("/wallets/{WALLET_UUID}/balance/daily", dailybalance.New(log, walletService))
+			r.Get("/wallets/{WALLET_UUID}/statement", statement.New(log, walletService, cfg.Statement.Timeout))
+			r.Get("/wallets/{WALLET_UUID}/stream", walletstream.New(log, walletService, streamHub, cfg.Stream.Heartbeat))
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(requireScope(cfg.Auth.Enabled, models.ScopeAdmin))
+
+			r.Post("/wallets/{WALLET_UUID}/status", status.New(log, walletService))
+			r.Put("/wallets/{WALLET_UUID}/limits", limits.New(log, walletService))
+			r.Put("/wallets/{WALLET_UUID}/buckets", buckets.New(log, walletService))
+
+			r.Post("/operations/{OPERATION_UUID}/reverse", reverse.New(log, walletService))
+
+			r.Post("/webhooks", webhooksave.New(log, webhookService))
+			r.Get("/webhooks", webhooklist.New(log, webhookService))
+			r.Get("/webhooks/{WEBHOOK_UUID}", webhookget.New(log, webhookService))
+			r.Patch("/webhooks/{WEBHOOK_UUID}", webhookupdate.New(log, webhookService))
+			r.Delete("/webhooks/{WEBHOOK_UUID}", webhookremove.New(log, webhookService))
+			r.Get("/webhooks/{WEBHOOK_UUID}/deliveries", deliveries.New(log, webhookService))
+			r.Post("/webhooks/{WEBHOOK_UUID}/deliveries/{DELIVERY_UUID}/redeliver", redeliver.New(log, webhookService))
+
+			r.Post("/api-keys", apikeysave.New(log, authService))
+			r.Get("/api-keys", apikeylist.New(log, authService))
+			r.Post("/api-keys/{API_KEY_UUID}/revoke", apikeyrevoke.New(log, authService))
+		})
 	})
 
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		runHoldExpiry(workersCtx, log, walletService, cfg.Holds.ExpiryInterval, cfg.Holds.ExpiryBatchSize)
+	}()
+
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		runOutboxRelay(workersCtx, log, outboxRelay, cfg.Outbox.PollInterval, cfg.Outbox.BatchSize, outboxRetention{
+			Published: cfg.Outbox.Retention,
+			Dead:      cfg.Outbox.DeadRetention,
+			Interval:  cfg.Outbox.PurgeInterval,
+		})
+	}()
+
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		runWebhookDelivery(workersCtx, log, webhookService, cfg.Webhooks.PollInterval, cfg.Webhooks.BatchSize)
+	}()
+
+	if cfg.Reconcile.Enabled {
+		reconciler := reconcile.New(txManger, log, reconciliationRepository, cfg.Reconcile.BatchSize)
+		mode := models.ReconciliationMode(strings.ToUpper(cfg.Reconcile.Mode))
+
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			runReconciliation(workersCtx, log, reconciler, cfg.Reconcile.Interval, mode)
+		}()
+	}
+
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		if err := streamHub.Run(workersCtx, storage, postgres.OperationsChannel); err != nil {
+			log.Error("operation listener stopped", sl.Err(err))
+		}
+	}()
+
 	srv := &http.Server{
 		Addr:         cfg.HTTPServer.Address,
 		Handler:      router,
@@ -85,6 +338,25 @@ func main() {
 		IdleTimeout:  cfg.HTTPServer.IdleTimeout,
 	}
 
+	// Open event streams never finish on their own.
+	srv.RegisterOnShutdown(streamHub.Close)
+
+	// Collected deposits are applied before their requests are answered.
+	if depositCoalescer != nil {
+		srv.RegisterOnShutdown(depositCoalescer.Close)
+	}
+
+	var grpcAuthenticator interceptor.Authenticator
+	if cfg.Auth.Enabled {
+		grpcAuthenticator = authService
+	}
+	grpcServer := newGRPCServer(log, walletService, grpcAuthenticator)
+
+	grpcListener, err := net.Listen("tcp", cfg.GRPCServer.Address)
+	if err != nil {
+		panic(err)
+	}
+
 	shutdownError := make(chan error)
 
 	go func() {
@@ -98,12 +370,28 @@ func main() {
 			"signal": s.String(),
 		})
 
+		healthChecker.Drain()
+		log.Info("not ready, draining before shutdown", slog.String("delay", cfg.HTTPServer.DrainDelay.String()))
+		time.Sleep(cfg.HTTPServer.DrainDelay)
+
+		stopWorkers()
+
 		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 		defer cancel()
 
+		stopGRPCServer(ctx, grpcServer)
+
 		shutdownError <- srv.Shutdown(ctx)
 	}()
 
+	go func() {
+		log.Info("starting grpc server", slog.String("port", cfg.GRPCServer.Address))
+
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			panic(err)
+		}
+	}()
+
 	log.Info("starting server wallet-service",
 		slog.String("env", cfg.Env),
 		slog.String("port", cfg.HTTPServer.Address),
@@ -122,10 +410,40 @@ func main() {
 		return
 	}
 
+	workers.Wait()
+
+	if err := eventPublisher.Close(); err != nil {
+		log.Error("failed to close event publisher", sl.Err(err))
+	}
+
 	storage.Close()
 
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(ctx); err != nil {
+		log.Error("failed to flush traces", sl.Err(err))
+	}
+
 	log.Info("stopped server", map[string]string{
 		"addr": srv.Addr,
 	})
 
 }
+
+// requireScope enforces scope on a route group while authentication is on.
+func requireScope(enabled bool, scope models.Scope) func(http.Handler) http.Handler {
+	if !enabled {
+		return func(next http.Handler) http.Handler { return next }
+	}
+
+	return authmiddleware.RequireScope(scope)
+}
+
+// loadFXRates reads the static rate table; without a file no pair is quoted.
+func loadFXRates(path string) (*fx.StaticProvider, error) {
+	if path == "" {
+		return fx.NewStaticProvider(nil)
+	}
+
+	return fx.LoadFile(path)
+}
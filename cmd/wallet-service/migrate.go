@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"wallet-service/internal/config"
+	"wallet-service/internal/lib/logger/sl"
+	"wallet-service/internal/storage/migrator"
+)
+
+const (
+	migrationsCheck   = "check"
+	migrationsMigrate = "migrate"
+	migrationsOff     = "off"
+)
+
+var (
+	errMigrateUsage = errors.New("usage: wallet-service migrate up | down [N] | status | force VERSION")
+
+	errSchemaBehind = errors.New("database schema is behind the binary")
+)
+
+// runMigrate runs `wallet-service migrate <command>`. The status is printed
+// to stdout as JSON.
+func runMigrate(cfg *config.Config, log *slog.Logger, args []string) error {
+	if len(args) == 0 {
+		return errMigrateUsage
+	}
+
+	mg, err := migrator.New(cfg.Storage.Postgres.DSN, log, cfg.Migrations.LockTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := mg.Close(); err != nil {
+			log.Warn("failed to close migrator", sl.Err(err))
+		}
+	}()
+
+	switch command := args[0]; {
+	case command == "up" && len(args) == 1:
+		err = mg.Up()
+	case command == "down" && len(args) <= 2:
+		steps := 1
+		if len(args) == 2 {
+			if steps, err = strconv.Atoi(args[1]); err != nil {
+				return errMigrateUsage
+			}
+		}
+		err = mg.Down(steps)
+	case command == "force" && len(args) == 2:
+		version, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			return errMigrateUsage
+		}
+		err = mg.Force(version)
+	case command == "status" && len(args) == 1:
+	default:
+		return errMigrateUsage
+	}
+	if err != nil {
+		return err
+	}
+
+	status, err := mg.Status()
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(os.Stdout).Encode(status)
+}
+
+// prepareSchema checks the schema before the service starts serving and,
+// with on_startup "migrate", brings it up to date first.
+func prepareSchema(cfg *config.Config, log *slog.Logger) error {
+	mode := cfg.Migrations.OnStartup
+	switch mode {
+	case migrationsOff:
+		return nil
+	case migrationsCheck, migrationsMigrate:
+	default:
+		return fmt.Errorf("unknown migrations.on_startup %q", mode)
+	}
+
+	mg, err := migrator.New(cfg.Storage.Postgres.DSN, log, cfg.Migrations.LockTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := mg.Close(); err != nil {
+			log.Warn("failed to close migrator", sl.Err(err))
+		}
+	}()
+
+	if mode == migrationsMigrate {
+		if err := mg.Up(); err != nil {
+			return err
+		}
+	}
+
+	status, err := mg.Status()
+	if err != nil {
+		return err
+	}
+
+	if status.Behind() {
+		return fmt.Errorf("%w: version %d (dirty: %t), expected %d; run wallet-service migrate up",
+			errSchemaBehind, status.Version, status.Dirty, status.Latest)
+	}
+	if status.Version > status.Latest {
+		log.Warn("database schema is ahead of the binary",
+			slog.Uint64("version", uint64(status.Version)),
+			slog.Uint64("expected", uint64(status.Latest)),
+		)
+		return nil
+	}
+
+	log.Info("database schema is up to date", slog.Uint64("version", uint64(status.Version)))
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+	"wallet-service/internal/lib/logger/sl"
+	"wallet-service/internal/lib/publisher"
+)
+
+type outboxRelayer interface {
+	RelayBatch(ctx context.Context, batchSize int) (int, error)
+	PurgeEvents(ctx context.Context, retention, deadRetention time.Duration, batchSize int) (int64, error)
+}
+
+// outboxRetention says how long delivered and dead-lettered events are kept
+// and how often the relay deletes older ones.
+type outboxRetention struct {
+	Published time.Duration
+	Dead      time.Duration
+	Interval  time.Duration
+}
+
+// runOutboxRelay periodically publishes pending outbox events until ctx is
+// canceled. A full batch is followed immediately by another one to drain a backlog.
+// Every retention.Interval it also deletes the events past their retention.
+func runOutboxRelay(
+	ctx context.Context,
+	log *slog.Logger,
+	relay outboxRelayer,
+	interval time.Duration,
+	batchSize int,
+	retention outboxRetention,
+) {
+	log = log.With(slog.String("component", "worker/outbox-relay"))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// A zero interval turns the purge off.
+	var purge <-chan time.Time
+	if retention.Interval > 0 {
+		purgeTicker := time.NewTicker(retention.Interval)
+		defer purgeTicker.Stop()
+		purge = purgeTicker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("outbox relay stopped")
+			return
+		case <-purge:
+			purged, err := relay.PurgeEvents(ctx, retention.Published, retention.Dead, batchSize)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Error("failed to purge outbox events", sl.Err(err))
+				}
+			} else if purged > 0 {
+				log.Info("purged outbox events", slog.Int64("count", purged))
+			}
+			continue
+		case <-ticker.C:
+		}
+
+		for {
+			claimed, err := relay.RelayBatch(ctx, batchSize)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Error("failed to relay outbox events", sl.Err(err))
+				}
+				break
+			}
+
+			if claimed < batchSize {
+				break
+			}
+		}
+	}
+}
+
+// newEventPublisher builds the publisher named in the config: "stdout" or
+// "file", which appends to filePath.
+func newEventPublisher(kind, filePath string) (*publisher.WriterPublisher, error) {
+	switch kind {
+	case "", "stdout":
+		return publisher.NewWriterPublisher(os.Stdout), nil
+	case "file":
+		return publisher.NewFilePublisher(filePath)
+	default:
+		return nil, fmt.Errorf("unknown outbox publisher %q", kind)
+	}
+}
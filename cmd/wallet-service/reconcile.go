@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/lib/logger/sl"
+	"wallet-service/internal/services"
+	"wallet-service/internal/services/reconcile"
+)
+
+type walletReconciler interface {
+	Run(ctx context.Context, mode models.ReconciliationMode, report reconcile.ReportFunc) (*models.ReconciliationRun, error)
+	Resume(ctx context.Context, report reconcile.ReportFunc) (*models.ReconciliationRun, error)
+}
+
+// runReconciliation reconciles wallet balances every interval until ctx is
+// canceled. A run left unfinished, by this or another process, is resumed
+// before a new one is started.
+func runReconciliation(
+	ctx context.Context,
+	log *slog.Logger,
+	wr walletReconciler,
+	interval time.Duration,
+	mode models.ReconciliationMode,
+) {
+
+	log = log.With(slog.String("component", "worker/reconcile"))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("reconciliation stopped")
+			return
+		case <-ticker.C:
+		}
+
+		_, err := wr.Resume(ctx, nil)
+		if errors.Is(err, services.ErrNoReconciliationRunning) {
+			_, err = wr.Run(ctx, mode, nil)
+		}
+
+		switch {
+		case err == nil, ctx.Err() != nil:
+		case errors.Is(err, services.ErrReconciliationRunning):
+			log.Debug("reconciliation started by another process")
+		default:
+			log.Error("failed to reconcile wallets", sl.Err(err))
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"wallet-service/internal/lib/logger/sl"
+)
+
+type webhookDeliverer interface {
+	DeliverBatch(ctx context.Context, batchSize int) (int, error)
+}
+
+// runWebhookDelivery periodically sends due webhook deliveries until ctx is
+// canceled. A full batch is followed immediately by another one to drain a backlog.
+func runWebhookDelivery(ctx context.Context, log *slog.Logger, wd webhookDeliverer, interval time.Duration, batchSize int) {
+	log = log.With(slog.String("component", "worker/webhook-delivery"))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("webhook delivery stopped")
+			return
+		case <-ticker.C:
+		}
+
+		for {
+			claimed, err := wd.DeliverBatch(ctx, batchSize)
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Error("failed to deliver webhooks", sl.Err(err))
+				}
+				break
+			}
+
+			if claimed < batchSize {
+				break
+			}
+		}
+	}
+}
@@ -14,7 +14,11 @@ type Config struct {
 		Address     string        `yaml:"address"`
 		Timeout     time.Duration `yaml:"timeout" env-default:"4s"`
 		IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
+		DrainDelay  time.Duration `yaml:"drain_delay" env-default:"5s"`
 	} `yaml:"http_server"`
+	GRPCServer struct {
+		Address string `yaml:"address" env-default:":9090"`
+	} `yaml:"grpc_server"`
 	Storage struct {
 		Postgres struct {
 			DSN            string
@@ -26,6 +30,78 @@ type Config struct {
 			MaxRetryDelay  time.Duration `yaml:"max_retry_delay"`
 		} `yaml:"postgres"`
 	} `yaml:"storage"`
+	Holds struct {
+		ExpiryInterval  time.Duration `yaml:"expiry_interval" env-default:"30s"`
+		ExpiryBatchSize int           `yaml:"expiry_batch_size" env-default:"100"`
+	} `yaml:"holds"`
+	FX struct {
+		RatesFile string        `yaml:"rates_file"`
+		QuoteTTL  time.Duration `yaml:"quote_ttl" env-default:"30s"`
+	} `yaml:"fx"`
+	Limits struct {
+		MaxSingleWithdrawal int64         `yaml:"max_single_withdrawal" env-default:"0"`
+		DailyWithdrawal     int64         `yaml:"daily_withdrawal" env-default:"0"`
+		MonthlyWithdrawal   int64         `yaml:"monthly_withdrawal" env-default:"0"`
+		MaxBalance          int64         `yaml:"max_balance" env-default:"0"`
+		MaxOperations       int64         `yaml:"max_operations" env-default:"0"`
+		OperationWindow     time.Duration `yaml:"operation_window" env-default:"1h"`
+	} `yaml:"limits"`
+	Outbox struct {
+		Publisher    string        `yaml:"publisher" env-default:"stdout"`
+		FilePath     string        `yaml:"file_path"`
+		PollInterval time.Duration `yaml:"poll_interval" env-default:"1s"`
+		BatchSize    int           `yaml:"batch_size" env-default:"100"`
+		MaxAttempts  int           `yaml:"max_attempts" env-default:"10"`
+		BaseBackoff  time.Duration `yaml:"base_backoff" env-default:"1s"`
+		MaxBackoff   time.Duration `yaml:"max_backoff" env-default:"5m"`
+		// Published and dead events older than these are deleted every
+		// PurgeInterval; zero keeps them.
+		Retention     time.Duration `yaml:"retention" env-default:"168h"`
+		DeadRetention time.Duration `yaml:"dead_retention" env-default:"720h"`
+		PurgeInterval time.Duration `yaml:"purge_interval" env-default:"1h"`
+	} `yaml:"outbox"`
+	Webhooks struct {
+		PollInterval time.Duration `yaml:"poll_interval" env-default:"1s"`
+		BatchSize    int           `yaml:"batch_size" env-default:"20"`
+		Timeout      time.Duration `yaml:"timeout" env-default:"10s"`
+		MaxAttempts  int           `yaml:"max_attempts" env-default:"8"`
+		BaseBackoff  time.Duration `yaml:"base_backoff" env-default:"10s"`
+		MaxBackoff   time.Duration `yaml:"max_backoff" env-default:"1h"`
+	} `yaml:"webhooks"`
+	Batch struct {
+		Timeout time.Duration `yaml:"timeout" env-default:"60s"`
+	} `yaml:"batch"`
+	Stream struct {
+		Heartbeat time.Duration `yaml:"heartbeat" env-default:"15s"`
+	} `yaml:"stream"`
+	Statement struct {
+		Timeout time.Duration `yaml:"timeout" env-default:"5m"`
+	} `yaml:"statement"`
+	Coalescing struct {
+		Enabled  bool          `yaml:"enabled" env-default:"false"`
+		Window   time.Duration `yaml:"window" env-default:"2ms"`
+		MaxBatch int           `yaml:"max_batch" env-default:"100"`
+	} `yaml:"coalescing"`
+	Reconcile struct {
+		Enabled   bool          `yaml:"enabled" env-default:"false"`
+		Interval  time.Duration `yaml:"interval" env-default:"1h"`
+		Mode      string        `yaml:"mode" env-default:"incremental"`
+		BatchSize int           `yaml:"batch_size" env-default:"1000"`
+	} `yaml:"reconcile"`
+	Migrations struct {
+		OnStartup   string        `yaml:"on_startup" env-default:"check"`
+		LockTimeout time.Duration `yaml:"lock_timeout" env-default:"5m"`
+	} `yaml:"migrations"`
+	Tracing struct {
+		Exporter    string  `yaml:"exporter" env-default:"none"`
+		Endpoint    string  `yaml:"endpoint" env-default:"localhost:4317"`
+		Insecure    bool    `yaml:"insecure" env-default:"true"`
+		SampleRatio float64 `yaml:"sample_ratio" env-default:"1"`
+		ServiceName string  `yaml:"service_name" env-default:"wallet-service"`
+	} `yaml:"tracing"`
+	Auth struct {
+		Enabled bool `yaml:"enabled" env-default:"true"`
+	} `yaml:"auth"`
 }
 
 func MustLoad() *Config {
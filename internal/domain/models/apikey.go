@@ -0,0 +1,53 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Scope string
+
+const (
+	ScopeWalletsRead  Scope = "wallets:read"
+	ScopeWalletsWrite Scope = "wallets:write"
+	ScopeAdmin        Scope = "admin"
+)
+
+// Scopes lists the scopes an API key can be granted.
+func Scopes() []Scope {
+	return []Scope{ScopeWalletsRead, ScopeWalletsWrite, ScopeAdmin}
+}
+
+// APIKey describes a key without its secret, which is only known to the
+// client it was issued to.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	Name       string     `json:"name" db:"name"`
+	Prefix     string     `json:"prefix" db:"prefix"`
+	Scopes     []Scope    `json:"scopes" db:"scopes"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// Principal is the client a request was authenticated as.
+type Principal struct {
+	KeyID  uuid.UUID `json:"key_id"`
+	Name   string    `json:"name"`
+	Scopes []Scope   `json:"scopes"`
+}
+
+// HasScope reports whether the principal may act under scope. admin grants
+// every scope and wallets:write grants wallets:read.
+func (p *Principal) HasScope(scope Scope) bool {
+	switch {
+	case slices.Contains(p.Scopes, ScopeAdmin), slices.Contains(p.Scopes, scope):
+		return true
+	case scope == ScopeWalletsRead:
+		return slices.Contains(p.Scopes, ScopeWalletsWrite)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BalancePoint is the balance of a wallet at a moment in time.
+type BalancePoint struct {
+	At      time.Time `json:"at"`
+	Balance int64     `json:"balance"`
+}
+
+// WalletBalance is the balance a wallet had at At.
+type WalletBalance struct {
+	WalletID uuid.UUID `json:"wallet_id"`
+	Currency string    `json:"currency"`
+	At       time.Time `json:"at"`
+	Balance  int64     `json:"balance"`
+}
+
+// DailyBalance is the balance of a wallet at the end of Date, or at At for
+// the current day.
+type DailyBalance struct {
+	Date    string    `json:"date"`
+	At      time.Time `json:"at"`
+	Balance int64     `json:"balance"`
+}
+
+// BalanceSeries is the end-of-day balance of a wallet over a range of days
+// of TimeZone. Days before the wallet was opened are left out.
+type BalanceSeries struct {
+	WalletID uuid.UUID      `json:"wallet_id"`
+	Currency string         `json:"currency"`
+	TimeZone string         `json:"time_zone"`
+	Days     []DailyBalance `json:"days"`
+}
+
+// Statement is the header of an account statement: the balance of the wallet
+// when the period [From, To) starts. Its lines are the operations of the
+// period, each carrying the running balance in BalanceAfter.
+type Statement struct {
+	WalletID       uuid.UUID `json:"wallet_id"`
+	Currency       string    `json:"currency"`
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	OpeningBalance int64     `json:"opening_balance"`
+}
+
+// StatementWriter renders a statement as it is read: the header first, then
+// each operation, then the closing balance.
+type StatementWriter interface {
+	Opening(statement *Statement) error
+	Line(operation *Operation) error
+	Closing(balance int64) error
+}
@@ -0,0 +1,17 @@
+package models
+
+type BatchMode string
+
+const (
+	// BatchAtomic applies every item of a batch or none of them.
+	BatchAtomic BatchMode = "atomic"
+	// BatchBestEffort applies each item on its own and reports per-item results.
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+// BatchItemResult is the outcome of one item of an operations batch: the
+// wallet as left by the item, or the reason the item was not applied.
+type BatchItemResult struct {
+	Wallet *Wallet
+	Err    error
+}
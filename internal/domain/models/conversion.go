@@ -0,0 +1,10 @@
+package models
+
+import "github.com/google/uuid"
+
+// Conversion is supplied by the caller of a cross-currency transfer: it names
+// the FX quote whose rate converts the debited amount. The credited amount is
+// computed from that rate, never taken from the caller.
+type Conversion struct {
+	QuoteID uuid.UUID `json:"quote_id"`
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// DefaultCurrency is assigned to wallets created without an explicit currency
+// and to every wallet that existed before currencies were introduced.
+const DefaultCurrency = "USD"
+
+// Currency is an ISO 4217 currency. Amounts are always stored as integers in
+// minor units; Exponent is the number of minor-unit digits (2 for cents).
+type Currency struct {
+	Code     string `json:"code"`
+	Exponent int    `json:"exponent"`
+}
+
+var currencies = map[string]Currency{
+	"AUD": {Code: "AUD", Exponent: 2},
+	"BHD": {Code: "BHD", Exponent: 3},
+	"BYN": {Code: "BYN", Exponent: 2},
+	"CAD": {Code: "CAD", Exponent: 2},
+	"CHF": {Code: "CHF", Exponent: 2},
+	"CNY": {Code: "CNY", Exponent: 2},
+	"EUR": {Code: "EUR", Exponent: 2},
+	"GBP": {Code: "GBP", Exponent: 2},
+	"INR": {Code: "INR", Exponent: 2},
+	"JPY": {Code: "JPY", Exponent: 0},
+	"KRW": {Code: "KRW", Exponent: 0},
+	"KWD": {Code: "KWD", Exponent: 3},
+	"KZT": {Code: "KZT", Exponent: 2},
+	"NOK": {Code: "NOK", Exponent: 2},
+	"RUB": {Code: "RUB", Exponent: 2},
+	"SEK": {Code: "SEK", Exponent: 2},
+	"TRY": {Code: "TRY", Exponent: 2},
+	"UAH": {Code: "UAH", Exponent: 2},
+	"USD": {Code: "USD", Exponent: 2},
+}
+
+var ErrInvalidAmountFormat = errors.New("invalid amount format")
+
+func LookupCurrency(code string) (Currency, bool) {
+	c, ok := currencies[code]
+	return c, ok
+}
+
+// Currencies returns every supported currency.
+func Currencies() []Currency {
+	list := make([]Currency, 0, len(currencies))
+	for _, c := range currencies {
+		list = append(list, c)
+	}
+
+	return list
+}
+
+// Format renders an amount of minor units as a decimal string, e.g. 1234 USD -> "12.34".
+func (c Currency) Format(minor int64) string {
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+
+	digits := strconv.FormatInt(minor, 10)
+	if c.Exponent == 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= c.Exponent {
+		digits = strings.Repeat("0", c.Exponent-len(digits)+1) + digits
+	}
+
+	point := len(digits) - c.Exponent
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// ParseAmount converts a decimal string into minor units. It rejects more
+// fractional digits than the currency has.
+func (c Currency) ParseAmount(s string) (int64, error) {
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" || len(frac) > c.Exponent {
+		return 0, ErrInvalidAmountFormat
+	}
+
+	frac += strings.Repeat("0", c.Exponent-len(frac))
+
+	minor, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidAmountFormat
+	}
+
+	return minor, nil
+}
+
+var ledgerNamespace = uuid.MustParse("6f1c7d4e-2b8a-4c1e-9d3f-5a7b9c0e1f2a")
+
+// ExternalAccountFor returns the external ledger account of a currency.
+// The default currency keeps the historical ExternalAccountID.
+func ExternalAccountFor(currency string) uuid.UUID {
+	if currency == DefaultCurrency {
+		return ExternalAccountID
+	}
+
+	return uuid.NewSHA1(ledgerNamespace, []byte("EXTERNAL/"+currency))
+}
+
+// FXAccountFor returns the ledger account through which conversions from or
+// into a currency are booked, so every journal entry balances per currency.
+func FXAccountFor(currency string) uuid.UUID {
+	return uuid.NewSHA1(ledgerNamespace, []byte("FX/"+currency))
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FXQuote locks an exchange rate for a short time. Rate is the price of one
+// major unit of FromCurrency in major units of ToCurrency. A quote can be
+// used for a single exchange.
+type FXQuote struct {
+	ID           uuid.UUID  `json:"id" db:"id"`
+	FromCurrency string     `json:"from_currency" db:"from_currency"`
+	ToCurrency   string     `json:"to_currency" db:"to_currency"`
+	Rate         string     `json:"rate" db:"rate"`
+	ExpiresAt    time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt       *time.Time `json:"used_at,omitempty" db:"used_at"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Exchange is the result of converting money between two wallets in
+// different currencies at a quoted rate.
+type Exchange struct {
+	ID             uuid.UUID `json:"id"`
+	QuoteID        uuid.UUID `json:"quote_id"`
+	Rate           string    `json:"rate"`
+	From           *Wallet   `json:"from"`
+	To             *Wallet   `json:"to"`
+	DebitedAmount  int64     `json:"debited_amount"`
+	CreditedAmount int64     `json:"credited_amount"`
+}
@@ -0,0 +1,27 @@
+package models
+
+// HealthCheck is the result of one readiness check.
+type HealthCheck struct {
+	Name  string `json:"name"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// PoolUsage is the saturation of the database connection pool.
+type PoolUsage struct {
+	AcquiredConns int32   `json:"acquired_conns"`
+	IdleConns     int32   `json:"idle_conns"`
+	TotalConns    int32   `json:"total_conns"`
+	MaxConns      int32   `json:"max_conns"`
+	Saturation    float64 `json:"saturation"`
+}
+
+// Readiness tells whether the instance should receive traffic. Draining is
+// set once graceful shutdown has begun.
+type Readiness struct {
+	Ready         bool          `json:"ready"`
+	Draining      bool          `json:"draining"`
+	SchemaVersion uint          `json:"schema_version"`
+	Checks        []HealthCheck `json:"checks"`
+	Pool          *PoolUsage    `json:"pool,omitempty"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HoldStatus string
+
+const (
+	HoldActive   HoldStatus = "ACTIVE"
+	HoldCaptured HoldStatus = "CAPTURED"
+	HoldVoided   HoldStatus = "VOIDED"
+	HoldExpired  HoldStatus = "EXPIRED"
+)
+
+// Hold reserves part of a wallet's balance until it is captured, voided or expires.
+type Hold struct {
+	ID             uuid.UUID  `json:"id" db:"id"`
+	WalletID       uuid.UUID  `json:"wallet_id" db:"wallet_id"`
+	Amount         int64      `json:"amount" db:"amount"`
+	CapturedAmount int64      `json:"captured_amount" db:"captured_amount"`
+	Status         HoldStatus `json:"status" db:"status"`
+	ExpiresAt      time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// HoldResult pairs a hold with the state of its wallet after the hold changed.
+type HoldResult struct {
+	Hold   *Hold   `json:"hold"`
+	Wallet *Wallet `json:"wallet"`
+}
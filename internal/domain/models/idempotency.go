@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyRecord stores the outcome of an operation executed under a
+// client-supplied Idempotency-Key, so a replay can return it unchanged.
+type IdempotencyRecord struct {
+	Key             string    `json:"key" db:"key"`
+	WalletID        uuid.UUID `json:"wallet_id" db:"wallet_id"`
+	RequestHash     string    `json:"request_hash" db:"request_hash"`
+	OperationID     uuid.UUID `json:"operation_id" db:"operation_id"`
+	Balance         int64     `json:"balance" db:"balance"`
+	HeldBalance     int64     `json:"held_balance" db:"held_balance"`
+	Currency        string    `json:"currency" db:"currency"`
+	WalletUpdatedAt time.Time `json:"wallet_updated_at" db:"wallet_updated_at"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExternalAccountID is the ledger account representing money outside the
+// service in DefaultCurrency: deposits are credited from it and withdrawals
+// debited to it. See ExternalAccountFor for other currencies.
+var ExternalAccountID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+type LedgerAccountKind string
+
+const (
+	AccountWallet   LedgerAccountKind = "WALLET"
+	AccountExternal LedgerAccountKind = "EXTERNAL"
+	AccountFX       LedgerAccountKind = "FX"
+)
+
+type JournalEntryType string
+
+const (
+	EntryOpeningBalance JournalEntryType = "OPENING_BALANCE"
+	EntryDeposit        JournalEntryType = "DEPOSIT"
+	EntryWithdraw       JournalEntryType = "WITHDRAW"
+	EntryTransfer       JournalEntryType = "TRANSFER"
+	EntryCapture        JournalEntryType = "CAPTURE"
+	EntryExchange       JournalEntryType = "EXCHANGE"
+	EntryReversal       JournalEntryType = "REVERSAL"
+)
+
+// JournalEntry is one balanced double-entry record: the amounts of its
+// postings always sum to zero, per currency. ReferenceID points at the operation (or
+// transfer, or wallet for opening balances) that produced it.
+type JournalEntry struct {
+	ID          uuid.UUID        `json:"id" db:"id"`
+	Type        JournalEntryType `json:"type" db:"type"`
+	ReferenceID uuid.UUID        `json:"reference_id" db:"reference_id"`
+	Postings    []Posting        `json:"postings"`
+	CreatedAt   time.Time        `json:"created_at" db:"created_at"`
+}
+
+// Posting moves Amount into (positive) or out of (negative) an account.
+// Wallet accounts share their id with the wallet.
+type Posting struct {
+	AccountID uuid.UUID `json:"account_id" db:"account_id"`
+	Amount    int64     `json:"amount" db:"amount"`
+}
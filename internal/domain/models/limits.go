@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// Limit names reported when a limit is exceeded.
+const (
+	LimitMaxSingleWithdrawal = "max_single_withdrawal"
+	LimitDailyWithdrawal     = "daily_withdrawal"
+	LimitMonthlyWithdrawal   = "monthly_withdrawal"
+	LimitMaxBalance          = "max_balance"
+	LimitMaxOperations       = "max_operations"
+)
+
+// Limits caps the activity of a wallet. Amounts are in minor units of the
+// wallet currency; zero means unlimited.
+type Limits struct {
+	MaxSingleWithdrawal int64         `json:"max_single_withdrawal"`
+	DailyWithdrawal     int64         `json:"daily_withdrawal"`
+	MonthlyWithdrawal   int64         `json:"monthly_withdrawal"`
+	MaxBalance          int64         `json:"max_balance"`
+	MaxOperations       int64         `json:"max_operations"`
+	OperationWindow     time.Duration `json:"-"`
+}
+
+// LimitOverrides are the per-wallet replacements of the global limits; a nil
+// field falls back to the default.
+type LimitOverrides struct {
+	MaxSingleWithdrawal    *int64 `json:"max_single_withdrawal"`
+	DailyWithdrawal        *int64 `json:"daily_withdrawal"`
+	MonthlyWithdrawal      *int64 `json:"monthly_withdrawal"`
+	MaxBalance             *int64 `json:"max_balance"`
+	MaxOperations          *int64 `json:"max_operations"`
+	OperationWindowSeconds *int64 `json:"operation_window_seconds"`
+}
+
+// Resolve returns the limits in effect when o is applied on top of defaults.
+func (o *LimitOverrides) Resolve(defaults Limits) Limits {
+	if o == nil {
+		return defaults
+	}
+
+	limits := defaults
+	for _, field := range []struct {
+		override *int64
+		target   *int64
+	}{
+		{o.MaxSingleWithdrawal, &limits.MaxSingleWithdrawal},
+		{o.DailyWithdrawal, &limits.DailyWithdrawal},
+		{o.MonthlyWithdrawal, &limits.MonthlyWithdrawal},
+		{o.MaxBalance, &limits.MaxBalance},
+		{o.MaxOperations, &limits.MaxOperations},
+	} {
+		if field.override != nil {
+			*field.target = *field.override
+		}
+	}
+
+	if o.OperationWindowSeconds != nil {
+		limits.OperationWindow = time.Duration(*o.OperationWindowSeconds) * time.Second
+	}
+
+	return limits
+}
+
+// LimitUsage is what a wallet has already consumed of its limits.
+type LimitUsage struct {
+	WithdrawnToday     int64
+	WithdrawnThisMonth int64
+	OperationsInWindow int64
+}
@@ -9,14 +9,58 @@ import (
 type OperationType string
 
 const (
-	Deposit  OperationType = "DEPOSIT"
-	Withdraw OperationType = "WITHDRAW"
+	Deposit     OperationType = "DEPOSIT"
+	Withdraw    OperationType = "WITHDRAW"
+	TransferOut OperationType = "TRANSFER_OUT"
+	TransferIn  OperationType = "TRANSFER_IN"
+	Capture     OperationType = "CAPTURE"
+	ExchangeOut OperationType = "EXCHANGE_OUT"
+	ExchangeIn  OperationType = "EXCHANGE_IN"
+	Reversal    OperationType = "REVERSAL"
 )
 
 type Operation struct {
-	ID        uuid.UUID     `json:"id" db:"id"`
-	WalletID  uuid.UUID     `json:"wallet_id" db:"wallet_id"`
-	Type      OperationType `json:"type" db:"type"`
-	Amount    int64         `json:"amount" db:"amount"`
-	CreatedAt time.Time     `json:"created_at" db:"created_at"`
+	ID         uuid.UUID     `json:"id" db:"id"`
+	WalletID   uuid.UUID     `json:"wallet_id" db:"wallet_id"`
+	Type       OperationType `json:"type" db:"type"`
+	Amount     int64         `json:"amount" db:"amount"`
+	TransferID *uuid.UUID    `json:"transfer_id,omitempty" db:"transfer_id"`
+	HoldID     *uuid.UUID    `json:"hold_id,omitempty" db:"hold_id"`
+	FXRate     *string       `json:"fx_rate,omitempty" db:"fx_rate"`
+
+	// BalanceAfter is the wallet balance the operation left, in sequence
+	// order. It is assigned with the sequence and is nil until then.
+	BalanceAfter *int64 `json:"balance_after,omitempty" db:"balance_after"`
+
+	// Sequence numbers the operations of a wallet in commit order. An
+	// operation of a sharded wallet gets it after it commits, and is nil
+	// until then.
+	Sequence *int64 `json:"sequence,omitempty" db:"sequence"`
+
+	// ReversedOperationID is set on a REVERSAL and points at the operation it
+	// compensates; ReversedAmount is how much of this operation has been
+	// reversed so far.
+	ReversedOperationID *uuid.UUID `json:"reversed_operation_id,omitempty" db:"reversed_operation_id"`
+	ReversedAmount      int64      `json:"reversed_amount" db:"reversed_amount"`
+	Reason              string     `json:"reason,omitempty" db:"reason"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// OperationRequest describes a deposit or withdrawal. Currency is optional;
+// when set it must match the wallet currency. An empty IdempotencyKey
+// disables replay protection.
+type OperationRequest struct {
+	WalletID       uuid.UUID
+	Type           OperationType
+	Amount         int64
+	Currency       string
+	IdempotencyKey string
+}
+
+// ReversalResult is the result of reversing (part of) an operation.
+type ReversalResult struct {
+	Operation *Operation `json:"operation"`
+	Original  *Operation `json:"original"`
+	Wallet    *Wallet    `json:"wallet"`
 }
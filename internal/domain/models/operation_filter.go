@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OperationFilter narrows down the operation history of a wallet.
+// Nil bounds and an empty Types slice are not applied.
+type OperationFilter struct {
+	Types     []OperationType
+	MinAmount *int64
+	MaxAmount *int64
+	From      *time.Time
+	To        *time.Time
+
+	Cursor string
+	Limit  int
+}
+
+// OperationCursor is the keyset position of the last operation of a page.
+type OperationCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+type OperationPage struct {
+	Operations []*Operation `json:"operations"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+// OperationEvent is an operation as pushed on a wallet stream. Cursor resumes
+// the stream right after the operation.
+type OperationEvent struct {
+	Cursor    string
+	Operation *Operation
+}
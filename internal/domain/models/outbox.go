@@ -0,0 +1,49 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "PENDING"
+	OutboxPublished OutboxStatus = "PUBLISHED"
+	OutboxDead      OutboxStatus = "DEAD"
+)
+
+// EventBalanceChanged is emitted for every operation that moves money in or
+// out of a wallet.
+const EventBalanceChanged = "wallet.balance_changed"
+
+// OutboxEvent is an event stored in the same transaction as the change it
+// describes and relayed to downstream consumers afterwards.
+type OutboxEvent struct {
+	ID            uuid.UUID       `json:"id" db:"id"`
+	AggregateID   uuid.UUID       `json:"aggregate_id" db:"aggregate_id"`
+	EventType     string          `json:"event_type" db:"event_type"`
+	Payload       json.RawMessage `json:"payload" db:"payload"`
+	Status        OutboxStatus    `json:"status" db:"status"`
+	Attempts      int             `json:"attempts" db:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	LastError     string          `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	PublishedAt   *time.Time      `json:"published_at,omitempty" db:"published_at"`
+}
+
+// BalanceChanged is the payload of EventBalanceChanged.
+type BalanceChanged struct {
+	OperationID uuid.UUID     `json:"operation_id"`
+	WalletID    uuid.UUID     `json:"wallet_id"`
+	Type        OperationType `json:"type"`
+	Amount      int64         `json:"amount"`
+	Currency    string        `json:"currency"`
+	Balance     int64         `json:"balance"`
+	HeldBalance int64         `json:"held_balance"`
+	TransferID  *uuid.UUID    `json:"transfer_id,omitempty"`
+	HoldID      *uuid.UUID    `json:"hold_id,omitempty"`
+	OccurredAt  time.Time     `json:"occurred_at"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReconciliationMode string
+
+const (
+	// ReconcileFull checks every wallet.
+	ReconcileFull ReconciliationMode = "FULL"
+	// ReconcileIncremental checks only the wallets with operations since the
+	// previous completed run.
+	ReconcileIncremental ReconciliationMode = "INCREMENTAL"
+)
+
+type ReconciliationStatus string
+
+const (
+	ReconciliationRunning   ReconciliationStatus = "RUNNING"
+	ReconciliationCompleted ReconciliationStatus = "COMPLETED"
+)
+
+// ReconciliationRun is one pass of the reconciliation job. Checkpoint is the
+// last wallet it has reconciled; a run still RUNNING continues after it.
+type ReconciliationRun struct {
+	ID             uuid.UUID            `json:"id"`
+	Mode           ReconciliationMode   `json:"mode"`
+	Since          *time.Time           `json:"since,omitempty"`
+	Status         ReconciliationStatus `json:"status"`
+	Checkpoint     *uuid.UUID           `json:"checkpoint,omitempty"`
+	WalletsChecked int64                `json:"wallets_checked"`
+	Mismatches     int64                `json:"mismatches"`
+	LastError      string               `json:"last_error,omitempty"`
+	StartedAt      time.Time            `json:"started_at"`
+	FinishedAt     *time.Time           `json:"finished_at,omitempty"`
+}
+
+// WalletReconciliation compares the balance of a wallet with the one
+// recomputed from its opening balance and operations, and with its ledger
+// account. LastBalanceAfter is the balance after the last sequenced
+// operation plus the operations not sequenced yet. LedgerBalance is nil when
+// the wallet has no ledger account, and LastBalanceAfter when it has no
+// operations.
+type WalletReconciliation struct {
+	WalletID         uuid.UUID `json:"wallet_id"`
+	Balance          int64     `json:"balance"`
+	ComputedBalance  int64     `json:"computed_balance"`
+	LedgerBalance    *int64    `json:"ledger_balance"`
+	LastBalanceAfter *int64    `json:"last_balance_after"`
+	Operations       int64     `json:"operations"`
+}
+
+// Matches reports whether the balance agrees with the operations and the
+// ledger, and with the balance_after chain of its operations.
+func (r *WalletReconciliation) Matches() bool {
+	return r.Balance == r.ComputedBalance &&
+		r.LedgerBalance != nil && *r.LedgerBalance == r.Balance &&
+		(r.LastBalanceAfter == nil || *r.LastBalanceAfter == r.Balance)
+}
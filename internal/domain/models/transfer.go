@@ -0,0 +1,15 @@
+package models
+
+import "github.com/google/uuid"
+
+// Transfer is the result of moving funds between two wallets.
+// ID links the TRANSFER_OUT and TRANSFER_IN operations. CreditedAmount
+// differs from Amount only for a cross-currency transfer, converted at Rate.
+type Transfer struct {
+	ID             uuid.UUID `json:"id"`
+	From           *Wallet   `json:"from"`
+	To             *Wallet   `json:"to"`
+	Amount         int64     `json:"amount"`
+	CreditedAmount int64     `json:"credited_amount"`
+	Rate           string    `json:"rate,omitempty"`
+}
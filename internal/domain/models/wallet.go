@@ -6,9 +6,33 @@ import (
 	"github.com/google/uuid"
 )
 
+type WalletStatus string
+
+const (
+	WalletActive WalletStatus = "ACTIVE"
+	WalletFrozen WalletStatus = "FROZEN"
+	WalletClosed WalletStatus = "CLOSED"
+)
+
 type Wallet struct {
-	ID        uuid.UUID `json:"id" db:"id"`
-	Balance   int64     `json:"balance" db:"balance"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	ID               uuid.UUID    `json:"id" db:"id"`
+	Balance          int64        `json:"balance" db:"balance"`
+	HeldBalance      int64        `json:"held_balance" db:"held_balance"`
+	AvailableBalance int64        `json:"available_balance" db:"-"`
+	Currency         string       `json:"currency" db:"currency"`
+	Status           WalletStatus `json:"status" db:"status"`
+	BalanceBuckets   int          `json:"balance_buckets" db:"balance_buckets"`
+	CreatedAt        time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// WalletStatusChange is the audit record of one lifecycle transition.
+type WalletStatusChange struct {
+	ID         uuid.UUID    `json:"id" db:"id"`
+	WalletID   uuid.UUID    `json:"wallet_id" db:"wallet_id"`
+	FromStatus WalletStatus `json:"from_status" db:"from_status"`
+	ToStatus   WalletStatus `json:"to_status" db:"to_status"`
+	Actor      string       `json:"actor" db:"actor"`
+	Reason     string       `json:"reason" db:"reason"`
+	CreatedAt  time.Time    `json:"created_at" db:"created_at"`
 }
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "PENDING"
+	DeliveryDelivered WebhookDeliveryStatus = "DELIVERED"
+	DeliveryFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+// EventTypes lists the event types a webhook subscription can filter on.
+func EventTypes() []string {
+	return []string{EventBalanceChanged}
+}
+
+// WebhookSubscription asks for events to be POSTed to URL. Empty EventTypes
+// or WalletIDs match every event type or wallet.
+type WebhookSubscription struct {
+	ID         uuid.UUID   `json:"id" db:"id"`
+	URL        string      `json:"url" db:"url"`
+	EventTypes []string    `json:"event_types" db:"event_types"`
+	WalletIDs  []uuid.UUID `json:"wallet_ids" db:"wallet_ids"`
+	Secret     string      `json:"secret,omitempty" db:"secret"`
+	Active     bool        `json:"active" db:"active"`
+	CreatedAt  time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// WebhookSubscriptionUpdate changes a subscription; nil fields are left as is.
+type WebhookSubscriptionUpdate struct {
+	URL        *string      `json:"url"`
+	EventTypes *[]string    `json:"event_types"`
+	WalletIDs  *[]uuid.UUID `json:"wallet_ids"`
+	Active     *bool        `json:"active"`
+}
+
+// WebhookDelivery is one event queued for one subscription, together with
+// the outcome of its latest attempt.
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id" db:"id"`
+	SubscriptionID uuid.UUID             `json:"subscription_id" db:"subscription_id"`
+	EventID        uuid.UUID             `json:"event_id" db:"event_id"`
+	EventType      string                `json:"event_type" db:"event_type"`
+	Payload        json.RawMessage       `json:"payload" db:"payload"`
+	Status         WebhookDeliveryStatus `json:"status" db:"status"`
+	Attempts       int                   `json:"attempts" db:"attempts"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at" db:"next_attempt_at"`
+	LastStatusCode *int                  `json:"last_status_code,omitempty" db:"last_status_code"`
+	LastError      string                `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt      time.Time             `json:"created_at" db:"created_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty" db:"delivered_at"`
+
+	// URL and Secret are loaded from the subscription when the delivery is
+	// claimed for sending.
+	URL    string `json:"-" db:"-"`
+	Secret string `json:"-" db:"-"`
+}
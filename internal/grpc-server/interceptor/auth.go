@@ -0,0 +1,86 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/middleware/auth"
+	"wallet-service/internal/lib/logger/sl"
+	"wallet-service/internal/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// APIKeyMetadataKey carries the API key; "authorization: Bearer <key>"
+// works too.
+const APIKeyMetadataKey = "x-api-key"
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, secret string) (*models.Principal, error)
+}
+
+// NewAuth authenticates every unary call with the API key in its metadata
+// and checks the key has the scope scopes gives for the called method.
+// Methods missing from scopes are refused, so a new RPC stays closed until
+// it is given a scope. The principal is stored in the context the same way
+// the HTTP auth middleware does it.
+func NewAuth(log *slog.Logger, a Authenticator, scopes map[string]models.Scope) grpc.UnaryServerInterceptor {
+	log = log.With(
+		slog.String("component", "interceptor/auth"),
+	)
+	log.Info("auth interceptor enabled")
+
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		secret := apiKey(ctx)
+		if secret == "" {
+			return nil, status.Error(codes.Unauthenticated, "missing api key")
+		}
+
+		principal, err := a.Authenticate(ctx, secret)
+		if err != nil {
+			if errors.Is(err, services.ErrInvalidAPIKey) {
+				return nil, status.Error(codes.Unauthenticated, services.ErrInvalidAPIKey.Error())
+			}
+
+			log.ErrorContext(ctx, "failed to authenticate", sl.Err(err))
+			return nil, status.Error(codes.Internal, "internal error")
+		}
+
+		scope, ok := scopes[info.FullMethod]
+		if !ok || !principal.HasScope(scope) {
+			return nil, status.Error(codes.PermissionDenied, "api key lacks scope for "+info.FullMethod)
+		}
+
+		return handler(auth.WithPrincipal(ctx, principal), req)
+	}
+}
+
+func apiKey(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get(APIKeyMetadataKey); len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+
+	for _, value := range md.Get("authorization") {
+		scheme, token, ok := strings.Cut(value, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,119 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/grpc-server/interceptor/mocks"
+	"wallet-service/internal/http-server/middleware/auth"
+	"wallet-service/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuth(t *testing.T) {
+	const (
+		getWallet = "/wallet.v1.WalletService/GetWallet"
+		deposit   = "/wallet.v1.WalletService/Deposit"
+	)
+	scopes := map[string]models.Scope{
+		getWallet: models.ScopeWalletsRead,
+		deposit:   models.ScopeWalletsWrite,
+	}
+	reader := &models.Principal{KeyID: uuid.New(), Name: "reports", Scopes: []models.Scope{models.ScopeWalletsRead}}
+
+	tests := []struct {
+		name      string
+		md        metadata.MD
+		secret    string
+		principal *models.Principal
+		authErr   error
+		method    string
+		wantCode  codes.Code
+	}{
+		{name: "missing key", method: getWallet, wantCode: codes.Unauthenticated},
+		{
+			name:     "invalid key",
+			md:       metadata.Pairs(APIKeyMetadataKey, "wsk_unknown"),
+			secret:   "wsk_unknown",
+			authErr:  services.ErrInvalidAPIKey,
+			method:   getWallet,
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:     "store failure",
+			md:       metadata.Pairs(APIKeyMetadataKey, "wsk_valid"),
+			secret:   "wsk_valid",
+			authErr:  errors.New("connection refused"),
+			method:   getWallet,
+			wantCode: codes.Internal,
+		},
+		{
+			name:      "bearer key with scope",
+			md:        metadata.Pairs("authorization", "Bearer wsk_valid"),
+			secret:    "wsk_valid",
+			principal: reader,
+			method:    getWallet,
+			wantCode:  codes.OK,
+		},
+		{
+			name:      "key without scope",
+			md:        metadata.Pairs(APIKeyMetadataKey, "wsk_valid"),
+			secret:    "wsk_valid",
+			principal: reader,
+			method:    deposit,
+			wantCode:  codes.PermissionDenied,
+		},
+		{
+			name:      "method without scope",
+			md:        metadata.Pairs(APIKeyMetadataKey, "wsk_valid"),
+			secret:    "wsk_valid",
+			principal: &models.Principal{KeyID: uuid.New(), Scopes: []models.Scope{models.ScopeAdmin}},
+			method:    "/wallet.v1.WalletService/CloseWallet",
+			wantCode:  codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuth := mocks.NewMockAuthenticator(ctrl)
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			if tt.secret != "" {
+				mockAuth.
+					EXPECT().
+					Authenticate(gomock.Any(), tt.secret).
+					Return(tt.principal, tt.authErr)
+			}
+
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			var seen *models.Principal
+			_, err := NewAuth(logger, mockAuth, scopes)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method},
+				func(ctx context.Context, _ any) (any, error) {
+					seen, _ = auth.PrincipalFromContext(ctx)
+					return nil, nil
+				})
+
+			require.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.OK {
+				require.Equal(t, tt.principal, seen)
+			}
+		})
+	}
+}
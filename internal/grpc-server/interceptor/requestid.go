@@ -0,0 +1,40 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDMetadataKey carries the request id in both directions.
+const RequestIDMetadataKey = "x-request-id"
+
+// NewRequestID takes the caller's request id from the incoming metadata or
+// generates one, echoes it in the response header and stores it in the
+// context under the same key the HTTP middleware uses, so
+// middleware.GetReqID works for both transports.
+func NewRequestID() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		var requestID string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(RequestIDMetadataKey); len(values) > 0 {
+				requestID = values[0]
+			}
+		}
+		if requestID == "" {
+			requestID = uuid.NewString()
+		}
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDMetadataKey, requestID))
+
+		return handler(context.WithValue(ctx, middleware.RequestIDKey, requestID), req)
+	}
+}
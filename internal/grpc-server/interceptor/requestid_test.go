@@ -0,0 +1,37 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestRequestID(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/wallet.v1.WalletService/GetWallet"}
+
+	t.Run("keeps the caller's id", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDMetadataKey, "req-1"))
+
+		_, err := NewRequestID()(ctx, nil, info, func(ctx context.Context, _ any) (any, error) {
+			require.Equal(t, "req-1", middleware.GetReqID(ctx))
+			return nil, nil
+		})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("generates an id", func(t *testing.T) {
+		_, err := NewRequestID()(context.Background(), nil, info, func(ctx context.Context, _ any) (any, error) {
+			_, err := uuid.Parse(middleware.GetReqID(ctx))
+			require.NoError(t, err)
+			return nil, nil
+		})
+
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,54 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"wallet-service/internal/lib/logger/sl"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus maps a service error to the gRPC status the client sees. Known
+// errors keep their message; anything else is logged and hidden behind
+// codes.Internal.
+func (s *Server) toStatus(ctx context.Context, err error) error {
+	var limitErr *services.LimitExceededError
+
+	switch {
+	case errors.Is(err, storage.ErrWalletNotFound):
+		return status.Error(codes.NotFound, storage.ErrWalletNotFound.Error())
+	case errors.Is(err, storage.ErrInsufficientFunds):
+		return status.Error(codes.FailedPrecondition, storage.ErrInsufficientFunds.Error())
+	case errors.Is(err, storage.ErrWalletFrozen):
+		return status.Error(codes.FailedPrecondition, storage.ErrWalletFrozen.Error())
+	case errors.Is(err, storage.ErrWalletClosed):
+		return status.Error(codes.FailedPrecondition, storage.ErrWalletClosed.Error())
+	case errors.As(err, &limitErr):
+		return status.Error(codes.ResourceExhausted, limitErr.Error())
+	case errors.Is(err, services.ErrIdempotencyKeyReused):
+		return status.Error(codes.AlreadyExists, services.ErrIdempotencyKeyReused.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	}
+
+	for _, known := range []error{
+		services.ErrAmountNegativeValue,
+		services.ErrAmountTooLarge,
+		services.ErrInvalidWalletID,
+		services.ErrInvalidCursor,
+		services.ErrInvalidOperationType,
+		services.ErrUnsupportedCurrency,
+		services.ErrCurrencyMismatch,
+	} {
+		if errors.Is(err, known) {
+			return status.Error(codes.InvalidArgument, known.Error())
+		}
+	}
+
+	s.log.ErrorContext(ctx, "wallet rpc failed", sl.Err(err))
+
+	return status.Error(codes.Internal, "internal error")
+}
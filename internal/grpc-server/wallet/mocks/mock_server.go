@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/grpc-server/wallet/server.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/grpc-server/wallet/server.go -destination=internal/grpc-server/wallet/mocks/mock_server.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWalletService is a mock of WalletService interface.
+type MockWalletService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockWalletServiceMockRecorder is the mock recorder for MockWalletService.
+type MockWalletServiceMockRecorder struct {
+	mock *MockWalletService
+}
+
+// NewMockWalletService creates a new mock instance.
+func NewMockWalletService(ctrl *gomock.Controller) *MockWalletService {
+	mock := &MockWalletService{ctrl: ctrl}
+	mock.recorder = &MockWalletServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletService) EXPECT() *MockWalletServiceMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockWalletService) Apply(ctx context.Context, req models.OperationRequest) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", ctx, req)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockWalletServiceMockRecorder) Apply(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockWalletService)(nil).Apply), ctx, req)
+}
+
+// CreateWallet mocks base method.
+func (m *MockWalletService) CreateWallet(ctx context.Context, amount int64, currency string) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWallet", ctx, amount, currency)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWallet indicates an expected call of CreateWallet.
+func (mr *MockWalletServiceMockRecorder) CreateWallet(ctx, amount, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallet", reflect.TypeOf((*MockWalletService)(nil).CreateWallet), ctx, amount, currency)
+}
+
+// GetWallet mocks base method.
+func (m *MockWalletService) GetWallet(ctx context.Context, id uuid.UUID) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallet", ctx, id)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallet indicates an expected call of GetWallet.
+func (mr *MockWalletServiceMockRecorder) GetWallet(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallet", reflect.TypeOf((*MockWalletService)(nil).GetWallet), ctx, id)
+}
+
+// ListOperations mocks base method.
+func (m *MockWalletService) ListOperations(ctx context.Context, walletID uuid.UUID, filter models.OperationFilter) (*models.OperationPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOperations", ctx, walletID, filter)
+	ret0, _ := ret[0].(*models.OperationPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOperations indicates an expected call of ListOperations.
+func (mr *MockWalletServiceMockRecorder) ListOperations(ctx, walletID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOperations", reflect.TypeOf((*MockWalletService)(nil).ListOperations), ctx, walletID, filter)
+}
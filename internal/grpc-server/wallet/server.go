@@ -0,0 +1,191 @@
+package wallet
+
+import (
+	"context"
+	"log/slog"
+	"wallet-service/internal/domain/models"
+	walletv1 "wallet-service/pkg/api/wallet/v1"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const maxIdempotencyKeyLength = 255
+
+type WalletService interface {
+	CreateWallet(ctx context.Context, amount int64, currency string) (*models.Wallet, error)
+	GetWallet(ctx context.Context, id uuid.UUID) (*models.Wallet, error)
+	Apply(ctx context.Context, req models.OperationRequest) (*models.Wallet, error)
+	ListOperations(ctx context.Context, walletID uuid.UUID, filter models.OperationFilter) (*models.OperationPage, error)
+}
+
+// Server implements walletv1.WalletServiceServer on top of the same service
+// the HTTP handlers use.
+type Server struct {
+	walletv1.UnimplementedWalletServiceServer
+
+	log     *slog.Logger
+	service WalletService
+}
+
+func New(log *slog.Logger, service WalletService) *Server {
+	return &Server{
+		log:     log,
+		service: service,
+	}
+}
+
+func (s *Server) CreateWallet(
+	ctx context.Context,
+	req *walletv1.CreateWalletRequest,
+) (*walletv1.CreateWalletResponse, error) {
+	wallet, err := s.service.CreateWallet(ctx, req.GetAmount(), req.GetCurrency())
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	return &walletv1.CreateWalletResponse{Wallet: toWallet(wallet)}, nil
+}
+
+func (s *Server) GetWallet(ctx context.Context, req *walletv1.GetWalletRequest) (*walletv1.GetWalletResponse, error) {
+	id, err := parseWalletID(req.GetWalletId())
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := s.service.GetWallet(ctx, id)
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	return &walletv1.GetWalletResponse{Wallet: toWallet(wallet)}, nil
+}
+
+func (s *Server) Deposit(ctx context.Context, req *walletv1.DepositRequest) (*walletv1.DepositResponse, error) {
+	wallet, err := s.apply(ctx, models.Deposit, req.GetWalletId(), req.GetAmount(), req.GetCurrency(), req.GetIdempotencyKey())
+	if err != nil {
+		return nil, err
+	}
+
+	return &walletv1.DepositResponse{Wallet: toWallet(wallet)}, nil
+}
+
+func (s *Server) Withdraw(ctx context.Context, req *walletv1.WithdrawRequest) (*walletv1.WithdrawResponse, error) {
+	wallet, err := s.apply(ctx, models.Withdraw, req.GetWalletId(), req.GetAmount(), req.GetCurrency(), req.GetIdempotencyKey())
+	if err != nil {
+		return nil, err
+	}
+
+	return &walletv1.WithdrawResponse{Wallet: toWallet(wallet)}, nil
+}
+
+func (s *Server) ListOperations(
+	ctx context.Context,
+	req *walletv1.ListOperationsRequest,
+) (*walletv1.ListOperationsResponse, error) {
+	id, err := parseWalletID(req.GetWalletId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+
+	page, err := s.service.ListOperations(ctx, id, models.OperationFilter{
+		Cursor: req.GetCursor(),
+		Limit:  int(req.GetLimit()),
+	})
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	operations := make([]*walletv1.Operation, 0, len(page.Operations))
+	for _, operation := range page.Operations {
+		operations = append(operations, toOperation(operation))
+	}
+
+	return &walletv1.ListOperationsResponse{
+		Operations: operations,
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+func (s *Server) apply(
+	ctx context.Context,
+	opType models.OperationType,
+	walletID string,
+	amount int64,
+	currency string,
+	idempotencyKey string,
+) (*models.Wallet, error) {
+	id, err := parseWalletID(walletID)
+	if err != nil {
+		return nil, err
+	}
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		return nil, status.Error(codes.InvalidArgument, "idempotency_key is too long")
+	}
+
+	wallet, err := s.service.Apply(ctx, models.OperationRequest{
+		WalletID:       id,
+		Type:           opType,
+		Amount:         amount,
+		Currency:       currency,
+		IdempotencyKey: idempotencyKey,
+	})
+	if err != nil {
+		return nil, s.toStatus(ctx, err)
+	}
+
+	return wallet, nil
+}
+
+func parseWalletID(raw string) (uuid.UUID, error) {
+	id, err := uuid.Parse(raw)
+	if err != nil || id == uuid.Nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "invalid argument: wallet_id")
+	}
+
+	return id, nil
+}
+
+func toWallet(wallet *models.Wallet) *walletv1.Wallet {
+	return &walletv1.Wallet{
+		Id:               wallet.ID.String(),
+		Balance:          wallet.Balance,
+		HeldBalance:      wallet.HeldBalance,
+		AvailableBalance: wallet.AvailableBalance,
+		Currency:         wallet.Currency,
+		Status:           string(wallet.Status),
+		CreatedAt:        timestamppb.New(wallet.CreatedAt),
+		UpdatedAt:        timestamppb.New(wallet.UpdatedAt),
+	}
+}
+
+func toOperation(operation *models.Operation) *walletv1.Operation {
+	out := &walletv1.Operation{
+		Id:             operation.ID.String(),
+		WalletId:       operation.WalletID.String(),
+		Type:           string(operation.Type),
+		Amount:         operation.Amount,
+		ReversedAmount: operation.ReversedAmount,
+		Reason:         operation.Reason,
+		CreatedAt:      timestamppb.New(operation.CreatedAt),
+	}
+	if operation.TransferID != nil {
+		out.TransferId = operation.TransferID.String()
+	}
+	if operation.HoldID != nil {
+		out.HoldId = operation.HoldID.String()
+	}
+	if operation.FXRate != nil {
+		out.FxRate = *operation.FXRate
+	}
+	if operation.ReversedOperationID != nil {
+		out.ReversedOperationId = operation.ReversedOperationID.String()
+	}
+
+	return out
+}
@@ -0,0 +1,189 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/grpc-server/interceptor"
+	"wallet-service/internal/grpc-server/wallet/mocks"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	walletv1 "wallet-service/pkg/api/wallet/v1"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newClient serves s over an in-memory listener with the production
+// interceptor chain.
+func newClient(t *testing.T, service WalletService) walletv1.WalletServiceClient {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	listener := bufconn.Listen(1 << 20)
+
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		interceptor.NewRequestID(),
+		interceptor.NewLogger(log),
+	))
+	walletv1.RegisterWalletServiceServer(srv, New(log, service))
+
+	go func() { _ = srv.Serve(listener) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return walletv1.NewWalletServiceClient(conn)
+}
+
+func TestServer_Deposit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockWalletService(ctrl)
+	client := newClient(t, mockService)
+
+	walletID := uuid.New()
+
+	mockService.
+		EXPECT().
+		Apply(gomock.Any(), models.OperationRequest{
+			WalletID:       walletID,
+			Type:           models.Deposit,
+			Amount:         100,
+			IdempotencyKey: "order-42",
+		}).
+		Return(&models.Wallet{ID: walletID, Balance: 100, Currency: "USD", Status: models.WalletActive}, nil)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), interceptor.RequestIDMetadataKey, "req-1")
+
+	var header metadata.MD
+	resp, err := client.Deposit(ctx, &walletv1.DepositRequest{
+		WalletId:       walletID.String(),
+		Amount:         100,
+		IdempotencyKey: "order-42",
+	}, grpc.Header(&header))
+
+	require.NoError(t, err)
+	require.Equal(t, walletID.String(), resp.GetWallet().GetId())
+	require.Equal(t, int64(100), resp.GetWallet().GetBalance())
+	require.Equal(t, "ACTIVE", resp.GetWallet().GetStatus())
+	require.Equal(t, []string{"req-1"}, header.Get(interceptor.RequestIDMetadataKey))
+}
+
+func TestServer_ListOperations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockWalletService(ctrl)
+	client := newClient(t, mockService)
+
+	walletID := uuid.New()
+	holdID := uuid.New()
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	mockService.
+		EXPECT().
+		ListOperations(gomock.Any(), walletID, models.OperationFilter{Cursor: "abc", Limit: 10}).
+		Return(&models.OperationPage{
+			Operations: []*models.Operation{{
+				ID:        uuid.New(),
+				WalletID:  walletID,
+				Type:      models.Capture,
+				Amount:    30,
+				HoldID:    &holdID,
+				CreatedAt: createdAt,
+			}},
+			NextCursor: "next",
+		}, nil)
+
+	resp, err := client.ListOperations(context.Background(), &walletv1.ListOperationsRequest{
+		WalletId: walletID.String(),
+		Limit:    10,
+		Cursor:   "abc",
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "next", resp.GetNextCursor())
+	require.Len(t, resp.GetOperations(), 1)
+	require.Equal(t, holdID.String(), resp.GetOperations()[0].GetHoldId())
+	require.Empty(t, resp.GetOperations()[0].GetTransferId())
+	require.True(t, createdAt.Equal(resp.GetOperations()[0].GetCreatedAt().AsTime()))
+}
+
+func TestServer_ErrorCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{name: "wallet not found", err: storage.ErrWalletNotFound, code: codes.NotFound},
+		{name: "insufficient funds", err: storage.ErrInsufficientFunds, code: codes.FailedPrecondition},
+		{name: "negative amount", err: services.ErrAmountNegativeValue, code: codes.InvalidArgument},
+		{name: "amount too large", err: services.ErrAmountTooLarge, code: codes.InvalidArgument},
+		{name: "wallet frozen", err: storage.ErrWalletFrozen, code: codes.FailedPrecondition},
+		{name: "idempotency key reused", err: services.ErrIdempotencyKeyReused, code: codes.AlreadyExists},
+		{
+			name: "limit exceeded",
+			err:  &services.LimitExceededError{Limit: models.LimitDailyWithdrawal, Max: 10, Attempted: 20},
+			code: codes.ResourceExhausted,
+		},
+		{name: "unknown", err: errors.New("connection reset"), code: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockWalletService(ctrl)
+			client := newClient(t, mockService)
+
+			walletID := uuid.New()
+
+			mockService.
+				EXPECT().
+				Apply(gomock.Any(), gomock.Any()).
+				Return(nil, tt.err)
+
+			_, err := client.Withdraw(context.Background(), &walletv1.WithdrawRequest{
+				WalletId: walletID.String(),
+				Amount:   50,
+			})
+
+			require.Equal(t, tt.code, status.Code(err))
+			if tt.code == codes.Internal {
+				require.Equal(t, "internal error", status.Convert(err).Message())
+			}
+		})
+	}
+
+	t.Run("invalid wallet id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		client := newClient(t, mocks.NewMockWalletService(ctrl))
+
+		_, err := client.GetWallet(context.Background(), &walletv1.GetWalletRequest{WalletId: "nope"})
+
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
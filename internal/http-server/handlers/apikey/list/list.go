@@ -0,0 +1,41 @@
+package list
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/pkg/helpers"
+)
+
+type KeyLister interface {
+	ListKeys(ctx context.Context) ([]*models.APIKey, error)
+}
+
+type response struct {
+	Status  string           `json:"status"`
+	APIKeys []*models.APIKey `json:"api_keys"`
+	Error   string           `json:"error,omitempty"`
+}
+
+func New(log *slog.Logger, kl KeyLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		keys, err := kl.ListKeys(r.Context())
+		if err != nil {
+			log.Error(err.Error())
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusOK,
+			helpers.Envelope{"data": response{APIKeys: keys, Status: "success"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
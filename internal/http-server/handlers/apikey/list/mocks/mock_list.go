@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/apikey/list/list.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/apikey/list/list.go -destination=internal/http-server/handlers/apikey/list/mocks/mock_list.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockKeyLister is a mock of KeyLister interface.
+type MockKeyLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyListerMockRecorder
+	isgomock struct{}
+}
+
+// MockKeyListerMockRecorder is the mock recorder for MockKeyLister.
+type MockKeyListerMockRecorder struct {
+	mock *MockKeyLister
+}
+
+// NewMockKeyLister creates a new mock instance.
+func NewMockKeyLister(ctrl *gomock.Controller) *MockKeyLister {
+	mock := &MockKeyLister{ctrl: ctrl}
+	mock.recorder = &MockKeyListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyLister) EXPECT() *MockKeyListerMockRecorder {
+	return m.recorder
+}
+
+// ListKeys mocks base method.
+func (m *MockKeyLister) ListKeys(ctx context.Context) ([]*models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListKeys", ctx)
+	ret0, _ := ret[0].([]*models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListKeys indicates an expected call of ListKeys.
+func (mr *MockKeyListerMockRecorder) ListKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListKeys", reflect.TypeOf((*MockKeyLister)(nil).ListKeys), ctx)
+}
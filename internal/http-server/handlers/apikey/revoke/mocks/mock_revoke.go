@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/apikey/revoke/revoke.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/apikey/revoke/revoke.go -destination=internal/http-server/handlers/apikey/revoke/mocks/mock_revoke.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockKeyRevoker is a mock of KeyRevoker interface.
+type MockKeyRevoker struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyRevokerMockRecorder
+	isgomock struct{}
+}
+
+// MockKeyRevokerMockRecorder is the mock recorder for MockKeyRevoker.
+type MockKeyRevokerMockRecorder struct {
+	mock *MockKeyRevoker
+}
+
+// NewMockKeyRevoker creates a new mock instance.
+func NewMockKeyRevoker(ctrl *gomock.Controller) *MockKeyRevoker {
+	mock := &MockKeyRevoker{ctrl: ctrl}
+	mock.recorder = &MockKeyRevokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyRevoker) EXPECT() *MockKeyRevokerMockRecorder {
+	return m.recorder
+}
+
+// RevokeKey mocks base method.
+func (m *MockKeyRevoker) RevokeKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeKey", ctx, id)
+	ret0, _ := ret[0].(*models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeKey indicates an expected call of RevokeKey.
+func (mr *MockKeyRevokerMockRecorder) RevokeKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeKey", reflect.TypeOf((*MockKeyRevoker)(nil).RevokeKey), ctx, id)
+}
@@ -0,0 +1,59 @@
+package revoke
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type KeyRevoker interface {
+	RevokeKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error)
+}
+
+type response struct {
+	Status string         `json:"status"`
+	APIKey *models.APIKey `json:"api_key,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// New revokes an API key. Revoking a key twice keeps the first revocation
+// time.
+func New(log *slog.Logger, kr KeyRevoker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := helpers.ReadUUIDParam(r, "API_KEY_UUID")
+		if err != nil || id == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: api_key_id"))
+			return
+		}
+
+		key, err := kr.RevokeKey(r.Context(), id)
+		if err != nil {
+			log.Error(err.Error())
+
+			if errors.Is(err, storage.ErrAPIKeyNotFound) {
+				handlers.ErrorResponse(w, r, http.StatusNotFound, storage.ErrAPIKeyNotFound.Error())
+				return
+			}
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusOK,
+			helpers.Envelope{"data": response{APIKey: key, Status: "api key was revoked"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
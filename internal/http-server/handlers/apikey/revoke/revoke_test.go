@@ -0,0 +1,66 @@
+package revoke
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/apikey/revoke/mocks"
+	"wallet-service/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(id uuid.UUID) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api-keys/"+id.String()+"/revoke", nil)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("API_KEY_UUID", id.String())
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestRevokeHandler(t *testing.T) {
+	revokedAt := time.Now()
+
+	tests := []struct {
+		name   string
+		key    *models.APIKey
+		err    error
+		status int
+	}{
+		{name: "revoked", key: &models.APIKey{RevokedAt: &revokedAt}, status: http.StatusOK},
+		{name: "not found", err: storage.ErrAPIKeyNotFound, status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockKeyRevoker(ctrl)
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			id := uuid.New()
+
+			mockService.
+				EXPECT().
+				RevokeKey(gomock.Any(), id).
+				Return(tt.key, tt.err)
+
+			handler := New(logger, mockService)
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, newRequest(id))
+
+			require.Equal(t, tt.status, w.Code)
+		})
+	}
+}
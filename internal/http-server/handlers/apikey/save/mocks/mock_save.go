@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/apikey/save/save.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/apikey/save/save.go -destination=internal/http-server/handlers/apikey/save/mocks/mock_save.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockKeyCreator is a mock of KeyCreator interface.
+type MockKeyCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyCreatorMockRecorder
+	isgomock struct{}
+}
+
+// MockKeyCreatorMockRecorder is the mock recorder for MockKeyCreator.
+type MockKeyCreatorMockRecorder struct {
+	mock *MockKeyCreator
+}
+
+// NewMockKeyCreator creates a new mock instance.
+func NewMockKeyCreator(ctrl *gomock.Controller) *MockKeyCreator {
+	mock := &MockKeyCreator{ctrl: ctrl}
+	mock.recorder = &MockKeyCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyCreator) EXPECT() *MockKeyCreatorMockRecorder {
+	return m.recorder
+}
+
+// CreateKey mocks base method.
+func (m *MockKeyCreator) CreateKey(ctx context.Context, name string, scopes []models.Scope) (*models.APIKey, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKey", ctx, name, scopes)
+	ret0, _ := ret[0].(*models.APIKey)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateKey indicates an expected call of CreateKey.
+func (mr *MockKeyCreatorMockRecorder) CreateKey(ctx, name, scopes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKey", reflect.TypeOf((*MockKeyCreator)(nil).CreateKey), ctx, name, scopes)
+}
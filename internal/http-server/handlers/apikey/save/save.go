@@ -0,0 +1,67 @@
+package save
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/pkg/helpers"
+)
+
+type KeyCreator interface {
+	CreateKey(ctx context.Context, name string, scopes []models.Scope) (*models.APIKey, string, error)
+}
+
+type request struct {
+	Name   string         `json:"name"`
+	Scopes []models.Scope `json:"scopes"`
+}
+
+type response struct {
+	Status string         `json:"status"`
+	APIKey *models.APIKey `json:"api_key,omitempty"`
+	Secret string         `json:"secret,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// New issues an API key. The response is the only place the key itself is
+// returned: only its hash is stored.
+func New(log *slog.Logger, kc KeyCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request
+
+		err := helpers.ReadJSON(w, r, &req)
+		if err != nil {
+			log.Error("failed to decode request body")
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "ops! decode json")
+			return
+		}
+
+		key, secret, err := kc.CreateKey(r.Context(), req.Name, req.Scopes)
+		if err != nil {
+			log.Error(err.Error())
+
+			switch {
+			case errors.Is(err, services.ErrInvalidAPIKeyName),
+				errors.Is(err, services.ErrInvalidScope):
+				handlers.BadRequestResponse(w, r, err)
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusCreated,
+			helpers.Envelope{"data": response{APIKey: key, Secret: secret, Status: "api key was created"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package save
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/apikey/save/mocks"
+	"wallet-service/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSaveHandler(t *testing.T) {
+	t.Run("created", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockKeyCreator(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		mockService.
+			EXPECT().
+			CreateKey(gomock.Any(), "billing", []models.Scope{models.ScopeWalletsWrite}).
+			Return(&models.APIKey{ID: uuid.New(), Name: "billing", Prefix: "wsk_abcdefgh"}, "wsk_secret", nil)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api-keys",
+			strings.NewReader(`{"name":"billing","scopes":["wallets:write"]}`)))
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.Contains(t, w.Body.String(), `"secret":"wsk_secret"`)
+	})
+
+	t.Run("invalid scope", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockKeyCreator(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		mockService.
+			EXPECT().
+			CreateKey(gomock.Any(), "billing", []models.Scope{"wallets:delete"}).
+			Return(nil, "", services.ErrInvalidScope)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api-keys",
+			strings.NewReader(`{"name":"billing","scopes":["wallets:delete"]}`)))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -2,9 +2,18 @@ package handlers
 
 import (
 	"net/http"
+	"wallet-service/internal/services"
 	"wallet-service/pkg/helpers"
 )
 
+// limitError details which limit an operation would exceed.
+type limitError struct {
+	Message   string `json:"message"`
+	Limit     string `json:"limit"`
+	Max       int64  `json:"max"`
+	Attempted int64  `json:"attempted"`
+}
+
 func ErrorResponse(w http.ResponseWriter, r *http.Request, status int, message any) {
 	env := helpers.Envelope{"error": message}
 
@@ -17,3 +26,13 @@ func ErrorResponse(w http.ResponseWriter, r *http.Request, status int, message a
 func BadRequestResponse(w http.ResponseWriter, r *http.Request, err error) {
 	ErrorResponse(w, r, http.StatusBadRequest, err.Error())
 }
+
+// LimitExceededResponse answers 422 with the limit the operation would exceed.
+func LimitExceededResponse(w http.ResponseWriter, r *http.Request, err *services.LimitExceededError) {
+	ErrorResponse(w, r, http.StatusUnprocessableEntity, limitError{
+		Message:   services.ErrLimitExceeded.Error(),
+		Limit:     err.Limit,
+		Max:       err.Max,
+		Attempted: err.Attempted,
+	})
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/fx/quote/quote.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/fx/quote/quote.go -destination=internal/http-server/handlers/fx/quote/mocks/mock_quote.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockQuoteCreator is a mock of QuoteCreator interface.
+type MockQuoteCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuoteCreatorMockRecorder
+	isgomock struct{}
+}
+
+// MockQuoteCreatorMockRecorder is the mock recorder for MockQuoteCreator.
+type MockQuoteCreatorMockRecorder struct {
+	mock *MockQuoteCreator
+}
+
+// NewMockQuoteCreator creates a new mock instance.
+func NewMockQuoteCreator(ctrl *gomock.Controller) *MockQuoteCreator {
+	mock := &MockQuoteCreator{ctrl: ctrl}
+	mock.recorder = &MockQuoteCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuoteCreator) EXPECT() *MockQuoteCreatorMockRecorder {
+	return m.recorder
+}
+
+// Quote mocks base method.
+func (m *MockQuoteCreator) Quote(ctx context.Context, fromCurrency, toCurrency string) (*models.FXQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quote", ctx, fromCurrency, toCurrency)
+	ret0, _ := ret[0].(*models.FXQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Quote indicates an expected call of Quote.
+func (mr *MockQuoteCreatorMockRecorder) Quote(ctx, fromCurrency, toCurrency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quote", reflect.TypeOf((*MockQuoteCreator)(nil).Quote), ctx, fromCurrency, toCurrency)
+}
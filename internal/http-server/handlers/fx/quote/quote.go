@@ -0,0 +1,71 @@
+package quote
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/pkg/helpers"
+)
+
+type QuoteCreator interface {
+	Quote(ctx context.Context, fromCurrency, toCurrency string) (*models.FXQuote, error)
+}
+
+type request struct {
+	FromCurrency string `json:"from_currency"`
+	ToCurrency   string `json:"to_currency"`
+}
+
+type response struct {
+	Status string          `json:"status"`
+	Quote  *models.FXQuote `json:"quote,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+func New(log *slog.Logger, qc QuoteCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request
+
+		err := helpers.ReadJSON(w, r, &req)
+		if err != nil {
+			log.Error("failed to decode request body")
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "ops! decode json")
+			return
+		}
+
+		if req.FromCurrency == "" || req.ToCurrency == "" {
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: from_currency, to_currency"))
+			return
+		}
+
+		quote, err := qc.Quote(r.Context(), req.FromCurrency, req.ToCurrency)
+		if err != nil {
+			log.Error(err.Error())
+
+			switch {
+			case errors.Is(err, services.ErrUnsupportedCurrency),
+				errors.Is(err, services.ErrSameCurrency):
+				handlers.BadRequestResponse(w, r, err)
+			case errors.Is(err, services.ErrRateUnavailable):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, services.ErrRateUnavailable.Error())
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusCreated,
+			helpers.Envelope{"data": response{Quote: quote, Status: "rate was locked"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
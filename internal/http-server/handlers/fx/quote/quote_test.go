@@ -0,0 +1,93 @@
+package quote
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/fx/quote/mocks"
+	"wallet-service/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQuoteHandler(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockQuoteCreator(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		expected := &models.FXQuote{
+			ID:           uuid.New(),
+			FromCurrency: "EUR",
+			ToCurrency:   "USD",
+			Rate:         "1.0825",
+			ExpiresAt:    time.Now().Add(30 * time.Second),
+		}
+
+		mockService.
+			EXPECT().
+			Quote(gomock.Any(), "EUR", "USD").
+			Return(expected, nil)
+
+		handler := New(logger, mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/fx/quotes",
+			strings.NewReader(`{"from_currency":"EUR","to_currency":"USD"}`))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.Contains(t, w.Body.String(), expected.ID.String())
+		require.Contains(t, w.Body.String(), `"rate":"1.0825"`)
+	})
+
+	t.Run("missing currency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockQuoteCreator(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		handler := New(logger, mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/fx/quotes", strings.NewReader(`{"from_currency":"EUR"}`))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("rate unavailable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockQuoteCreator(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		mockService.
+			EXPECT().
+			Quote(gomock.Any(), "USD", "KWD").
+			Return(nil, services.ErrRateUnavailable)
+
+		handler := New(logger, mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/fx/quotes",
+			strings.NewReader(`{"from_currency":"USD","to_currency":"KWD"}`))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/health/ready/ready.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/health/ready/ready.go -destination=internal/http-server/handlers/health/ready/mocks/mock_ready.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReadinessChecker is a mock of ReadinessChecker interface.
+type MockReadinessChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadinessCheckerMockRecorder
+	isgomock struct{}
+}
+
+// MockReadinessCheckerMockRecorder is the mock recorder for MockReadinessChecker.
+type MockReadinessCheckerMockRecorder struct {
+	mock *MockReadinessChecker
+}
+
+// NewMockReadinessChecker creates a new mock instance.
+func NewMockReadinessChecker(ctrl *gomock.Controller) *MockReadinessChecker {
+	mock := &MockReadinessChecker{ctrl: ctrl}
+	mock.recorder = &MockReadinessCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadinessChecker) EXPECT() *MockReadinessCheckerMockRecorder {
+	return m.recorder
+}
+
+// Ready mocks base method.
+func (m *MockReadinessChecker) Ready(ctx context.Context) *models.Readiness {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ready", ctx)
+	ret0, _ := ret[0].(*models.Readiness)
+	return ret0
+}
+
+// Ready indicates an expected call of Ready.
+func (mr *MockReadinessCheckerMockRecorder) Ready(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ready", reflect.TypeOf((*MockReadinessChecker)(nil).Ready), ctx)
+}
@@ -0,0 +1,40 @@
+package ready
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/pkg/helpers"
+)
+
+type ReadinessChecker interface {
+	Ready(ctx context.Context) *models.Readiness
+}
+
+// New reports 200 while the instance can take traffic and 503 otherwise,
+// with the result of every check in both cases.
+func New(log *slog.Logger, rc ReadinessChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		readiness := rc.Ready(r.Context())
+
+		status := http.StatusOK
+		if !readiness.Ready {
+			status = http.StatusServiceUnavailable
+
+			attrs := []any{slog.Bool("draining", readiness.Draining)}
+			for _, check := range readiness.Checks {
+				if !check.OK {
+					attrs = append(attrs, slog.String(check.Name, check.Error))
+				}
+			}
+			log.Warn("instance is not ready", attrs...)
+		}
+
+		err := helpers.WriteJSON(w, status, helpers.Envelope{"data": readiness}, nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package ready
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/health/ready/mocks"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReadyHandler(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockChecker := mocks.NewMockReadinessChecker(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		mockChecker.
+			EXPECT().
+			Ready(gomock.Any()).
+			Return(&models.Readiness{
+				Ready:         true,
+				SchemaVersion: 19,
+				Checks:        []models.HealthCheck{{Name: "database", OK: true}, {Name: "schema", OK: true}},
+				Pool:          &models.PoolUsage{AcquiredConns: 5, MaxConns: 10, Saturation: 0.5},
+			})
+
+		w := httptest.NewRecorder()
+		New(logger, mockChecker).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `"ready":true`)
+		require.Contains(t, w.Body.String(), `"saturation":0.5`)
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockChecker := mocks.NewMockReadinessChecker(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		mockChecker.
+			EXPECT().
+			Ready(gomock.Any()).
+			Return(&models.Readiness{
+				Checks: []models.HealthCheck{
+					{Name: "database", OK: true},
+					{Name: "schema", Error: "schema version 18, expected 19"},
+				},
+			})
+
+		w := httptest.NewRecorder()
+		New(logger, mockChecker).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		require.Contains(t, w.Body.String(), "schema version 18, expected 19")
+	})
+
+	t.Run("draining", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockChecker := mocks.NewMockReadinessChecker(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		mockChecker.
+			EXPECT().
+			Ready(gomock.Any()).
+			Return(&models.Readiness{Draining: true})
+
+		w := httptest.NewRecorder()
+		New(logger, mockChecker).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+		require.Equal(t, http.StatusServiceUnavailable, w.Code)
+		require.Contains(t, w.Body.String(), `"draining":true`)
+	})
+}
@@ -0,0 +1,86 @@
+package authorize
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type HoldAuthorizer interface {
+	Authorize(ctx context.Context, walletID uuid.UUID, amount int64, ttl time.Duration) (*models.HoldResult, error)
+}
+
+type request struct {
+	Amount     int64 `json:"amount"`
+	TTLSeconds int64 `json:"ttl_seconds"`
+}
+
+type response struct {
+	Status string         `json:"status"`
+	Hold   *models.Hold   `json:"hold,omitempty"`
+	Wallet *models.Wallet `json:"wallet,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+func New(log *slog.Logger, ha HoldAuthorizer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := helpers.ReadUUIDParam(r, "WALLET_UUID")
+		if err != nil || id == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: wallet_id"))
+			return
+		}
+
+		var req request
+
+		err = helpers.ReadJSON(w, r, &req)
+		if err != nil {
+			log.Error("failed to decode request body")
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "ops! decode json")
+			return
+		}
+
+		if req.Amount == 0 {
+			handlers.BadRequestResponse(w, r, errors.New("error the value is zero: amount"))
+			return
+		}
+
+		result, err := ha.Authorize(r.Context(), id, req.Amount, time.Duration(req.TTLSeconds)*time.Second)
+		if err != nil {
+			log.Error(err.Error())
+
+			switch {
+			case errors.Is(err, storage.ErrInsufficientFunds):
+				handlers.ErrorResponse(w, r, http.StatusBadRequest, "insufficient funds")
+			case errors.Is(err, services.ErrAmountNegativeValue):
+				handlers.ErrorResponse(w, r, http.StatusBadRequest, "amount negative value")
+			case errors.Is(err, services.ErrInvalidHoldTTL):
+				handlers.BadRequestResponse(w, r, services.ErrInvalidHoldTTL)
+			case errors.Is(err, storage.ErrWalletNotFound):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, "wallet not found")
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusCreated,
+			helpers.Envelope{"data": response{Hold: result.Hold, Wallet: result.Wallet, Status: "funds were held"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
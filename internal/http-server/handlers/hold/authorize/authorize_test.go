@@ -0,0 +1,97 @@
+package authorize
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/hold/authorize/mocks"
+	"wallet-service/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(id uuid.UUID, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/wallets/"+id.String()+"/holds", strings.NewReader(body))
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("WALLET_UUID", id.String())
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestAuthorizeHandler(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockHoldAuthorizer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		walletID := uuid.New()
+		holdID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Authorize(gomock.Any(), walletID, int64(25), 60*time.Second).
+			Return(&models.HoldResult{
+				Hold:   &models.Hold{ID: holdID, WalletID: walletID, Amount: 25, Status: models.HoldActive},
+				Wallet: &models.Wallet{ID: walletID, Balance: 100, HeldBalance: 25, AvailableBalance: 75},
+			}, nil)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(walletID, `{"amount":25,"ttl_seconds":60}`))
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.Contains(t, w.Body.String(), holdID.String())
+		require.Contains(t, w.Body.String(), `"available_balance":75`)
+	})
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockHoldAuthorizer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		walletID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Authorize(gomock.Any(), walletID, int64(500), time.Duration(0)).
+			Return(nil, storage.ErrInsufficientFunds)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(walletID, `{"amount":500}`))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "insufficient funds")
+	})
+
+	t.Run("zero amount", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockHoldAuthorizer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(uuid.New(), `{"amount":0}`))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
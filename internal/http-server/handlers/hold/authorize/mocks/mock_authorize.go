@@ -0,0 +1,59 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/hold/authorize/authorize.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/hold/authorize/authorize.go -destination=internal/http-server/handlers/hold/authorize/mocks/mock_authorize.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHoldAuthorizer is a mock of HoldAuthorizer interface.
+type MockHoldAuthorizer struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldAuthorizerMockRecorder
+	isgomock struct{}
+}
+
+// MockHoldAuthorizerMockRecorder is the mock recorder for MockHoldAuthorizer.
+type MockHoldAuthorizerMockRecorder struct {
+	mock *MockHoldAuthorizer
+}
+
+// NewMockHoldAuthorizer creates a new mock instance.
+func NewMockHoldAuthorizer(ctrl *gomock.Controller) *MockHoldAuthorizer {
+	mock := &MockHoldAuthorizer{ctrl: ctrl}
+	mock.recorder = &MockHoldAuthorizerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldAuthorizer) EXPECT() *MockHoldAuthorizerMockRecorder {
+	return m.recorder
+}
+
+// Authorize mocks base method.
+func (m *MockHoldAuthorizer) Authorize(ctx context.Context, walletID uuid.UUID, amount int64, ttl time.Duration) (*models.HoldResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorize", ctx, walletID, amount, ttl)
+	ret0, _ := ret[0].(*models.HoldResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Authorize indicates an expected call of Authorize.
+func (mr *MockHoldAuthorizerMockRecorder) Authorize(ctx, walletID, amount, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockHoldAuthorizer)(nil).Authorize), ctx, walletID, amount, ttl)
+}
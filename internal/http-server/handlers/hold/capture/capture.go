@@ -0,0 +1,89 @@
+package capture
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type HoldCapturer interface {
+	Capture(ctx context.Context, holdID uuid.UUID, amount int64) (*models.HoldResult, error)
+}
+
+// request.Amount is optional: zero or an empty body captures the whole hold.
+type request struct {
+	Amount int64 `json:"amount"`
+}
+
+type response struct {
+	Status string         `json:"status"`
+	Hold   *models.Hold   `json:"hold,omitempty"`
+	Wallet *models.Wallet `json:"wallet,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+func New(log *slog.Logger, hc HoldCapturer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := helpers.ReadUUIDParam(r, "HOLD_UUID")
+		if err != nil || id == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: hold_id"))
+			return
+		}
+
+		var req request
+
+		if r.ContentLength != 0 {
+			err = helpers.ReadJSON(w, r, &req)
+			if err != nil {
+				log.Error("failed to decode request body")
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "ops! decode json")
+				return
+			}
+		}
+
+		result, err := hc.Capture(r.Context(), id, req.Amount)
+		if err != nil {
+			log.Error(err.Error())
+
+			var limitErr *services.LimitExceededError
+			switch {
+			case errors.Is(err, storage.ErrHoldNotFound):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, "hold not found")
+			case errors.Is(err, services.ErrHoldNotActive), errors.Is(err, services.ErrHoldExpired):
+				handlers.ErrorResponse(w, r, http.StatusConflict, err.Error())
+			case errors.Is(err, services.ErrCaptureExceedsHold):
+				handlers.BadRequestResponse(w, r, services.ErrCaptureExceedsHold)
+			case errors.Is(err, services.ErrAmountNegativeValue):
+				handlers.ErrorResponse(w, r, http.StatusBadRequest, "amount negative value")
+			case errors.Is(err, storage.ErrWalletFrozen):
+				handlers.ErrorResponse(w, r, http.StatusLocked, storage.ErrWalletFrozen.Error())
+			case errors.Is(err, storage.ErrWalletClosed):
+				handlers.ErrorResponse(w, r, http.StatusGone, storage.ErrWalletClosed.Error())
+			case errors.As(err, &limitErr):
+				handlers.LimitExceededResponse(w, r, limitErr)
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusOK,
+			helpers.Envelope{"data": response{Hold: result.Hold, Wallet: result.Wallet, Status: "hold was captured"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
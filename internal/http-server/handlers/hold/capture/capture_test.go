@@ -0,0 +1,153 @@
+package capture
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/hold/capture/mocks"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(id uuid.UUID, body string) *http.Request {
+	var req *http.Request
+	if body == "" {
+		req = httptest.NewRequest(http.MethodPost, "/holds/"+id.String()+"/capture", nil)
+	} else {
+		req = httptest.NewRequest(http.MethodPost, "/holds/"+id.String()+"/capture", strings.NewReader(body))
+	}
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("HOLD_UUID", id.String())
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestCaptureHandler(t *testing.T) {
+	t.Run("full capture without body", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockHoldCapturer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		holdID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Capture(gomock.Any(), holdID, int64(0)).
+			Return(&models.HoldResult{
+				Hold:   &models.Hold{ID: holdID, Status: models.HoldCaptured, CapturedAmount: 40},
+				Wallet: &models.Wallet{Balance: 60, AvailableBalance: 60},
+			}, nil)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(holdID, ""))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `"status":"CAPTURED"`)
+	})
+
+	t.Run("partial capture", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockHoldCapturer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		holdID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Capture(gomock.Any(), holdID, int64(15)).
+			Return(&models.HoldResult{
+				Hold:   &models.Hold{ID: holdID, Status: models.HoldCaptured, CapturedAmount: 15},
+				Wallet: &models.Wallet{},
+			}, nil)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(holdID, `{"amount":15}`))
+
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockHoldCapturer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		holdID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Capture(gomock.Any(), holdID, int64(0)).
+			Return(nil, services.ErrHoldExpired)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(holdID, ""))
+
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockHoldCapturer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		holdID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Capture(gomock.Any(), holdID, int64(0)).
+			Return(nil, storage.ErrHoldNotFound)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(holdID, ""))
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("frozen wallet", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockHoldCapturer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		holdID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Capture(gomock.Any(), holdID, int64(0)).
+			Return(nil, storage.ErrWalletFrozen)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(holdID, ""))
+
+		require.Equal(t, http.StatusLocked, w.Code)
+	})
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/hold/capture/capture.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/hold/capture/capture.go -destination=internal/http-server/handlers/hold/capture/mocks/mock_capture.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHoldCapturer is a mock of HoldCapturer interface.
+type MockHoldCapturer struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldCapturerMockRecorder
+	isgomock struct{}
+}
+
+// MockHoldCapturerMockRecorder is the mock recorder for MockHoldCapturer.
+type MockHoldCapturerMockRecorder struct {
+	mock *MockHoldCapturer
+}
+
+// NewMockHoldCapturer creates a new mock instance.
+func NewMockHoldCapturer(ctrl *gomock.Controller) *MockHoldCapturer {
+	mock := &MockHoldCapturer{ctrl: ctrl}
+	mock.recorder = &MockHoldCapturerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldCapturer) EXPECT() *MockHoldCapturerMockRecorder {
+	return m.recorder
+}
+
+// Capture mocks base method.
+func (m *MockHoldCapturer) Capture(ctx context.Context, holdID uuid.UUID, amount int64) (*models.HoldResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capture", ctx, holdID, amount)
+	ret0, _ := ret[0].(*models.HoldResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Capture indicates an expected call of Capture.
+func (mr *MockHoldCapturerMockRecorder) Capture(ctx, holdID, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capture", reflect.TypeOf((*MockHoldCapturer)(nil).Capture), ctx, holdID, amount)
+}
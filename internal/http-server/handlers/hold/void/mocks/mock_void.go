@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/hold/void/void.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/hold/void/void.go -destination=internal/http-server/handlers/hold/void/mocks/mock_void.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHoldVoider is a mock of HoldVoider interface.
+type MockHoldVoider struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldVoiderMockRecorder
+	isgomock struct{}
+}
+
+// MockHoldVoiderMockRecorder is the mock recorder for MockHoldVoider.
+type MockHoldVoiderMockRecorder struct {
+	mock *MockHoldVoider
+}
+
+// NewMockHoldVoider creates a new mock instance.
+func NewMockHoldVoider(ctrl *gomock.Controller) *MockHoldVoider {
+	mock := &MockHoldVoider{ctrl: ctrl}
+	mock.recorder = &MockHoldVoiderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldVoider) EXPECT() *MockHoldVoiderMockRecorder {
+	return m.recorder
+}
+
+// Void mocks base method.
+func (m *MockHoldVoider) Void(ctx context.Context, holdID uuid.UUID) (*models.HoldResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Void", ctx, holdID)
+	ret0, _ := ret[0].(*models.HoldResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Void indicates an expected call of Void.
+func (mr *MockHoldVoiderMockRecorder) Void(ctx, holdID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Void", reflect.TypeOf((*MockHoldVoider)(nil).Void), ctx, holdID)
+}
@@ -0,0 +1,62 @@
+package void
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type HoldVoider interface {
+	Void(ctx context.Context, holdID uuid.UUID) (*models.HoldResult, error)
+}
+
+type response struct {
+	Status string         `json:"status"`
+	Hold   *models.Hold   `json:"hold,omitempty"`
+	Wallet *models.Wallet `json:"wallet,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+func New(log *slog.Logger, hv HoldVoider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := helpers.ReadUUIDParam(r, "HOLD_UUID")
+		if err != nil || id == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: hold_id"))
+			return
+		}
+
+		result, err := hv.Void(r.Context(), id)
+		if err != nil {
+			log.Error(err.Error())
+
+			switch {
+			case errors.Is(err, storage.ErrHoldNotFound):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, "hold not found")
+			case errors.Is(err, services.ErrHoldNotActive):
+				handlers.ErrorResponse(w, r, http.StatusConflict, err.Error())
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusOK,
+			helpers.Envelope{"data": response{Hold: result.Hold, Wallet: result.Wallet, Status: "hold was voided"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
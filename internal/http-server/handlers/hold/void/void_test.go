@@ -0,0 +1,77 @@
+package void
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/hold/void/mocks"
+	"wallet-service/internal/services"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(id uuid.UUID) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/holds/"+id.String()+"/void", nil)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("HOLD_UUID", id.String())
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestVoidHandler(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockHoldVoider(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		holdID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Void(gomock.Any(), holdID).
+			Return(&models.HoldResult{
+				Hold:   &models.Hold{ID: holdID, Status: models.HoldVoided},
+				Wallet: &models.Wallet{Balance: 100, AvailableBalance: 100},
+			}, nil)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(holdID))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `"status":"VOIDED"`)
+	})
+
+	t.Run("not active", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockHoldVoider(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		holdID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Void(gomock.Any(), holdID).
+			Return(nil, services.ErrHoldNotActive)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(holdID))
+
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/operation/reverse/reverse.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/operation/reverse/reverse.go -destination=internal/http-server/handlers/operation/reverse/mocks/mock_reverse.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOperationReverser is a mock of OperationReverser interface.
+type MockOperationReverser struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationReverserMockRecorder
+	isgomock struct{}
+}
+
+// MockOperationReverserMockRecorder is the mock recorder for MockOperationReverser.
+type MockOperationReverserMockRecorder struct {
+	mock *MockOperationReverser
+}
+
+// NewMockOperationReverser creates a new mock instance.
+func NewMockOperationReverser(ctrl *gomock.Controller) *MockOperationReverser {
+	mock := &MockOperationReverser{ctrl: ctrl}
+	mock.recorder = &MockOperationReverserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationReverser) EXPECT() *MockOperationReverserMockRecorder {
+	return m.recorder
+}
+
+// Reverse mocks base method.
+func (m *MockOperationReverser) Reverse(ctx context.Context, operationID uuid.UUID, amount int64, reason string) (*models.ReversalResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reverse", ctx, operationID, amount, reason)
+	ret0, _ := ret[0].(*models.ReversalResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reverse indicates an expected call of Reverse.
+func (mr *MockOperationReverserMockRecorder) Reverse(ctx, operationID, amount, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reverse", reflect.TypeOf((*MockOperationReverser)(nil).Reverse), ctx, operationID, amount, reason)
+}
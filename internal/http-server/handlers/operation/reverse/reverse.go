@@ -0,0 +1,92 @@
+package reverse
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type OperationReverser interface {
+	Reverse(ctx context.Context, operationID uuid.UUID, amount int64, reason string) (*models.ReversalResult, error)
+}
+
+type request struct {
+	Amount int64  `json:"amount"`
+	Reason string `json:"reason"`
+}
+
+type response struct {
+	Status   string                 `json:"status"`
+	Reversal *models.ReversalResult `json:"reversal,omitempty"`
+	Error    string                 `json:"error,omitempty"`
+}
+
+func New(log *slog.Logger, or OperationReverser) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := helpers.ReadUUIDParam(r, "OPERATION_UUID")
+		if err != nil || id == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: operation_id"))
+			return
+		}
+
+		var req request
+
+		err = helpers.ReadJSON(w, r, &req)
+		if err != nil {
+			log.Error("failed to decode request body")
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "ops! decode json")
+			return
+		}
+
+		if req.Reason == "" {
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: reason"))
+			return
+		}
+
+		reversal, err := or.Reverse(r.Context(), id, req.Amount, req.Reason)
+		if err != nil {
+			log.Error(err.Error())
+
+			var limitErr *services.LimitExceededError
+			switch {
+			case errors.Is(err, storage.ErrInsufficientFunds):
+				handlers.ErrorResponse(w, r, http.StatusBadRequest, "insufficient funds")
+			case errors.Is(err, services.ErrAmountNegativeValue):
+				handlers.ErrorResponse(w, r, http.StatusBadRequest, "amount negative value")
+			case errors.Is(err, services.ErrInvalidOperationID),
+				errors.Is(err, services.ErrInvalidReversalReason),
+				errors.Is(err, services.ErrOperationNotReversible),
+				errors.Is(err, services.ErrReversalExceedsAmount):
+				handlers.BadRequestResponse(w, r, err)
+			case errors.Is(err, services.ErrOperationAlreadyReversed):
+				handlers.ErrorResponse(w, r, http.StatusConflict, err.Error())
+			case errors.Is(err, storage.ErrOperationNotFound):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, "operation not found")
+			case errors.As(err, &limitErr):
+				handlers.LimitExceededResponse(w, r, limitErr)
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusCreated,
+			helpers.Envelope{"data": response{Reversal: reversal, Status: "operation was reversed"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package reverse
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/operation/reverse/mocks"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(id uuid.UUID, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/operations/"+id.String()+"/reverse", strings.NewReader(body))
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("OPERATION_UUID", id.String())
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestReverseHandler(t *testing.T) {
+	t.Run("partial refund", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockOperationReverser(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		operationID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Reverse(gomock.Any(), operationID, int64(40), "duplicate charge").
+			Return(&models.ReversalResult{
+				Operation: &models.Operation{ID: uuid.New(), Type: models.Reversal, Amount: 40},
+				Original:  &models.Operation{ID: operationID, Amount: 100, ReversedAmount: 40},
+				Wallet:    &models.Wallet{Balance: 60},
+			}, nil)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(operationID, `{"amount":40,"reason":"duplicate charge"}`))
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.Contains(t, w.Body.String(), `"reversed_amount":40`)
+	})
+
+	t.Run("missing reason", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockOperationReverser(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(uuid.New(), `{"amount":40}`))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "reason")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			err    error
+			status int
+		}{
+			{name: "already reversed", err: services.ErrOperationAlreadyReversed, status: http.StatusConflict},
+			{name: "exceeds amount", err: services.ErrReversalExceedsAmount, status: http.StatusBadRequest},
+			{name: "deposit spent", err: storage.ErrInsufficientFunds, status: http.StatusBadRequest},
+			{name: "not found", err: storage.ErrOperationNotFound, status: http.StatusNotFound},
+			{name: "invalid operation id", err: services.ErrInvalidOperationID, status: http.StatusBadRequest},
+			{
+				name:   "limit exceeded",
+				err:    &services.LimitExceededError{Limit: models.LimitMaxBalance, Max: 100, Attempted: 150},
+				status: http.StatusUnprocessableEntity,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				mockService := mocks.NewMockOperationReverser(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				mockService.
+					EXPECT().
+					Reverse(gomock.Any(), gomock.Any(), int64(0), "mistake").
+					Return(nil, tt.err)
+
+				handler := New(logger, mockService)
+
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, newRequest(uuid.New(), `{"reason":"mistake"}`))
+
+				require.Equal(t, tt.status, w.Code)
+			})
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package balance
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type BalanceReader interface {
+	BalanceAt(ctx context.Context, walletID uuid.UUID, at time.Time) (*models.WalletBalance, error)
+}
+
+type response struct {
+	Status  string                `json:"status"`
+	Balance *models.WalletBalance `json:"balance,omitempty"`
+	Error   string                `json:"error,omitempty"`
+}
+
+// New returns the balance a wallet had at the moment given by the "at" query
+// parameter.
+func New(log *slog.Logger, br BalanceReader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := helpers.ReadUUIDParam(r, "WALLET_UUID")
+		if err != nil || id == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: wallet_id"))
+			return
+		}
+
+		at, err := helpers.ReadOptionalTime(r.URL.Query(), "at")
+		if err == nil && at == nil {
+			err = errors.New("at is required")
+		}
+		if err != nil {
+			log.Error("failed to validate request")
+			handlers.BadRequestResponse(w, r, err)
+			return
+		}
+
+		balance, err := br.BalanceAt(r.Context(), id, *at)
+		if err != nil {
+			log.Error(err.Error())
+
+			switch {
+			case errors.Is(err, services.ErrBalanceTimeInFuture):
+				handlers.BadRequestResponse(w, r, err)
+			case errors.Is(err, storage.ErrWalletNotFound):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, "wallet not found")
+			case errors.Is(err, services.ErrWalletNotOpenedYet):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, services.ErrWalletNotOpenedYet.Error())
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusOK,
+			helpers.Envelope{"data": response{Status: "success", Balance: balance}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
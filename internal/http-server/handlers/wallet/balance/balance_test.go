@@ -0,0 +1,110 @@
+package balance
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/wallet/balance/mocks"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(id uuid.UUID, query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/wallets/"+id.String()+"/balance"+query, nil)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("WALLET_UUID", id.String())
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestBalanceHandler(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockReader := mocks.NewMockBalanceReader(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		id := uuid.New()
+		at := time.Date(2025, 3, 31, 23, 59, 59, 0, time.UTC)
+
+		mockReader.
+			EXPECT().
+			BalanceAt(gomock.Any(), id, at).
+			Return(&models.WalletBalance{WalletID: id, Currency: "USD", At: at, Balance: 4200}, nil)
+
+		handler := New(logger, mockReader)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest(id, "?at=2025-03-31T23:59:59Z"))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"balance":4200`)
+	})
+
+	t.Run("missing at", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := New(slog.New(slog.NewTextHandler(io.Discard, nil)), mocks.NewMockBalanceReader(ctrl))
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest(uuid.New(), ""))
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("invalid at", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := New(slog.New(slog.NewTextHandler(io.Discard, nil)), mocks.NewMockBalanceReader(ctrl))
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest(uuid.New(), "?at=yesterday"))
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	errorCases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"future", services.ErrBalanceTimeInFuture, http.StatusBadRequest},
+		{"not found", storage.ErrWalletNotFound, http.StatusNotFound},
+		{"not opened yet", services.ErrWalletNotOpenedYet, http.StatusNotFound},
+		{"internal", context.DeadlineExceeded, http.StatusInternalServerError},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReader := mocks.NewMockBalanceReader(ctrl)
+			mockReader.
+				EXPECT().
+				BalanceAt(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, tc.err)
+
+			handler := New(slog.New(slog.NewTextHandler(io.Discard, nil)), mockReader)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, newRequest(uuid.New(), "?at=2025-03-31T23:59:59Z"))
+
+			require.Equal(t, tc.code, rr.Code)
+		})
+	}
+}
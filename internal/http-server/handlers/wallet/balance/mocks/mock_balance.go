@@ -0,0 +1,59 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/wallet/balance/balance.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/wallet/balance/balance.go -destination=internal/http-server/handlers/wallet/balance/mocks/mock_balance.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBalanceReader is a mock of BalanceReader interface.
+type MockBalanceReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceReaderMockRecorder
+	isgomock struct{}
+}
+
+// MockBalanceReaderMockRecorder is the mock recorder for MockBalanceReader.
+type MockBalanceReaderMockRecorder struct {
+	mock *MockBalanceReader
+}
+
+// NewMockBalanceReader creates a new mock instance.
+func NewMockBalanceReader(ctrl *gomock.Controller) *MockBalanceReader {
+	mock := &MockBalanceReader{ctrl: ctrl}
+	mock.recorder = &MockBalanceReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalanceReader) EXPECT() *MockBalanceReaderMockRecorder {
+	return m.recorder
+}
+
+// BalanceAt mocks base method.
+func (m *MockBalanceReader) BalanceAt(ctx context.Context, walletID uuid.UUID, at time.Time) (*models.WalletBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BalanceAt", ctx, walletID, at)
+	ret0, _ := ret[0].(*models.WalletBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BalanceAt indicates an expected call of BalanceAt.
+func (mr *MockBalanceReaderMockRecorder) BalanceAt(ctx, walletID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BalanceAt", reflect.TypeOf((*MockBalanceReader)(nil).BalanceAt), ctx, walletID, at)
+}
@@ -0,0 +1,137 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type BatchApplier interface {
+	ApplyBatch(ctx context.Context, mode models.BatchMode, items []models.OperationRequest) ([]models.BatchItemResult, error)
+}
+
+type item struct {
+	WalletID      uuid.UUID `json:"wallet_id"`
+	OperationType string    `json:"operation_type"`
+	Amount        int64     `json:"amount"`
+	Currency      string    `json:"currency"`
+}
+
+type request struct {
+	Mode  models.BatchMode `json:"mode"`
+	Items []item           `json:"items"`
+}
+
+type itemResult struct {
+	Index  int            `json:"index"`
+	Status string         `json:"status"`
+	Wallet *models.Wallet `json:"wallet,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+type response struct {
+	Mode      models.BatchMode `json:"mode"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []itemResult     `json:"results"`
+}
+
+// itemError names the item that made an atomic batch fail.
+type itemError struct {
+	Message string `json:"message"`
+	Index   int    `json:"index"`
+}
+
+// New serves POST /wallets/operations/batch. timeout replaces the server write
+// timeout, which is tuned for single operations.
+func New(log *slog.Logger, ba BatchApplier, timeout time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request
+		err := helpers.ReadJSON(w, r, &req)
+		if err != nil {
+			log.Error("failed to decode request body")
+			handlers.BadRequestResponse(w, r, err)
+			return
+		}
+
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout))
+
+		items := make([]models.OperationRequest, 0, len(req.Items))
+		for _, it := range req.Items {
+			items = append(items, models.OperationRequest{
+				WalletID: it.WalletID,
+				Type:     models.OperationType(it.OperationType),
+				Amount:   it.Amount,
+				Currency: it.Currency,
+			})
+		}
+
+		results, err := ba.ApplyBatch(r.Context(), req.Mode, items)
+		if err != nil {
+			log.Error(err.Error())
+
+			var batchErr *services.BatchItemError
+			switch {
+			case errors.As(err, &batchErr):
+				handlers.ErrorResponse(w, r, itemStatus(batchErr.Err), itemError{
+					Message: batchErr.Err.Error(),
+					Index:   batchErr.Index,
+				})
+			case errors.Is(err, services.ErrInvalidBatchMode),
+				errors.Is(err, services.ErrEmptyBatch),
+				errors.Is(err, services.ErrBatchTooLarge):
+				handlers.BadRequestResponse(w, r, err)
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		resp := response{
+			Mode:    req.Mode,
+			Results: make([]itemResult, 0, len(results)),
+		}
+		for i, result := range results {
+			if result.Err != nil {
+				resp.Failed++
+				resp.Results = append(resp.Results, itemResult{Index: i, Status: "failed", Error: result.Err.Error()})
+				continue
+			}
+
+			resp.Succeeded++
+			resp.Results = append(resp.Results, itemResult{Index: i, Status: "ok", Wallet: result.Wallet})
+		}
+
+		err = helpers.WriteJSON(w, http.StatusOK, helpers.Envelope{"data": resp}, nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
+
+// itemStatus is the status an atomic batch fails with, matching the status
+// the single-operation endpoint returns for the same error.
+func itemStatus(err error) int {
+	switch {
+	case errors.Is(err, storage.ErrWalletNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, storage.ErrWalletFrozen):
+		return http.StatusLocked
+	case errors.Is(err, storage.ErrWalletClosed):
+		return http.StatusGone
+	case errors.Is(err, services.ErrLimitExceeded):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusBadRequest
+	}
+}
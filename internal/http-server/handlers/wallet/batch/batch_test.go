@@ -0,0 +1,142 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/wallet/batch/mocks"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestBatchHandler(t *testing.T) {
+	t.Run("best effort reports every item", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockApplier := mocks.NewMockBatchApplier(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		first, second := uuid.New(), uuid.New()
+
+		mockApplier.
+			EXPECT().
+			ApplyBatch(gomock.Any(), models.BatchBestEffort, []models.OperationRequest{
+				{WalletID: first, Type: models.Deposit, Amount: 100},
+				{WalletID: second, Type: models.Withdraw, Amount: 50, Currency: "USD"},
+			}).
+			DoAndReturn(func(context.Context, models.BatchMode, []models.OperationRequest) ([]models.BatchItemResult, error) {
+				return []models.BatchItemResult{
+					{Wallet: &models.Wallet{ID: first, Balance: 100}},
+					{Err: storage.ErrInsufficientFunds},
+				}, nil
+			})
+
+		handler := New(logger, mockApplier, time.Minute)
+
+		body := fmt.Sprintf(`{"mode":"best_effort","items":[
+			{"wallet_id":"%s","operation_type":"DEPOSIT","amount":100},
+			{"wallet_id":"%s","operation_type":"WITHDRAW","amount":50,"currency":"USD"}]}`, first, second)
+		req := httptest.NewRequest(http.MethodPost, "/wallets/operations/batch", strings.NewReader(body))
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"succeeded":1`)
+		require.Contains(t, rr.Body.String(), `"failed":1`)
+		require.Contains(t, rr.Body.String(), `{"index":1,"status":"failed","error":"insufficient funds"}`)
+		require.Contains(t, rr.Body.String(), first.String())
+	})
+
+	t.Run("atomic failure names the item", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			err    error
+			status int
+		}{
+			{name: "not found", err: storage.ErrWalletNotFound, status: http.StatusNotFound},
+			{name: "frozen", err: storage.ErrWalletFrozen, status: http.StatusLocked},
+			{name: "insufficient funds", err: storage.ErrInsufficientFunds, status: http.StatusBadRequest},
+			{
+				name:   "limit exceeded",
+				err:    &services.LimitExceededError{Limit: models.LimitMaxBalance, Max: 10, Attempted: 20},
+				status: http.StatusUnprocessableEntity,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				mockApplier := mocks.NewMockBatchApplier(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				mockApplier.
+					EXPECT().
+					ApplyBatch(gomock.Any(), models.BatchAtomic, gomock.Any()).
+					Return(nil, &services.BatchItemError{Index: 3, Err: tt.err})
+
+				handler := New(logger, mockApplier, time.Minute)
+
+				body := fmt.Sprintf(`{"mode":"atomic","items":[{"wallet_id":"%s","operation_type":"DEPOSIT","amount":1}]}`, uuid.New())
+				req := httptest.NewRequest(http.MethodPost, "/wallets/operations/batch", strings.NewReader(body))
+				rr := httptest.NewRecorder()
+
+				handler.ServeHTTP(rr, req)
+
+				require.Equal(t, tt.status, rr.Code)
+				require.Contains(t, rr.Body.String(), `"index":3`)
+			})
+		}
+	})
+
+	t.Run("invalid batch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockApplier := mocks.NewMockBatchApplier(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		mockApplier.
+			EXPECT().
+			ApplyBatch(gomock.Any(), models.BatchMode("parallel"), gomock.Any()).
+			Return(nil, services.ErrInvalidBatchMode)
+
+		handler := New(logger, mockApplier, time.Minute)
+
+		req := httptest.NewRequest(http.MethodPost, "/wallets/operations/batch", strings.NewReader(`{"mode":"parallel","items":[]}`))
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+		require.Contains(t, rr.Body.String(), services.ErrInvalidBatchMode.Error())
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := New(slog.New(slog.NewTextHandler(io.Discard, nil)), mocks.NewMockBatchApplier(ctrl), time.Minute)
+
+		req := httptest.NewRequest(http.MethodPost, "/wallets/operations/batch", strings.NewReader(`{"items":`))
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
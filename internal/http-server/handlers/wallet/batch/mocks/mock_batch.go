@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/wallet/batch/batch.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/wallet/batch/batch.go -destination=internal/http-server/handlers/wallet/batch/mocks/mock_batch.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBatchApplier is a mock of BatchApplier interface.
+type MockBatchApplier struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchApplierMockRecorder
+	isgomock struct{}
+}
+
+// MockBatchApplierMockRecorder is the mock recorder for MockBatchApplier.
+type MockBatchApplierMockRecorder struct {
+	mock *MockBatchApplier
+}
+
+// NewMockBatchApplier creates a new mock instance.
+func NewMockBatchApplier(ctrl *gomock.Controller) *MockBatchApplier {
+	mock := &MockBatchApplier{ctrl: ctrl}
+	mock.recorder = &MockBatchApplierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchApplier) EXPECT() *MockBatchApplierMockRecorder {
+	return m.recorder
+}
+
+// ApplyBatch mocks base method.
+func (m *MockBatchApplier) ApplyBatch(ctx context.Context, mode models.BatchMode, items []models.OperationRequest) ([]models.BatchItemResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyBatch", ctx, mode, items)
+	ret0, _ := ret[0].([]models.BatchItemResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyBatch indicates an expected call of ApplyBatch.
+func (mr *MockBatchApplierMockRecorder) ApplyBatch(ctx, mode, items any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyBatch", reflect.TypeOf((*MockBatchApplier)(nil).ApplyBatch), ctx, mode, items)
+}
@@ -0,0 +1,81 @@
+package buckets
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type BalanceSharder interface {
+	SetBalanceBuckets(ctx context.Context, walletID uuid.UUID, buckets int) (*models.Wallet, error)
+}
+
+type request struct {
+	Buckets *int `json:"buckets"`
+}
+
+type response struct {
+	Status string         `json:"status"`
+	Wallet *models.Wallet `json:"wallet,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// New sets the number of buckets the balance of a wallet is sharded into;
+// zero turns sharding off.
+func New(log *slog.Logger, bs BalanceSharder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := helpers.ReadUUIDParam(r, "WALLET_UUID")
+		if err != nil || id == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: wallet_id"))
+			return
+		}
+
+		var req request
+
+		err = helpers.ReadJSON(w, r, &req)
+		if err != nil {
+			log.Error("failed to decode request body")
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "ops! decode json")
+			return
+		}
+
+		if req.Buckets == nil {
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: buckets"))
+			return
+		}
+
+		wallet, err := bs.SetBalanceBuckets(r.Context(), id, *req.Buckets)
+		if err != nil {
+			log.Error(err.Error())
+
+			switch {
+			case errors.Is(err, services.ErrInvalidBalanceBuckets):
+				handlers.BadRequestResponse(w, r, err)
+			case errors.Is(err, storage.ErrWalletNotFound):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, "wallet not found")
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusOK,
+			helpers.Envelope{"data": response{Wallet: wallet, Status: "wallet balance buckets were updated"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package buckets
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/wallet/buckets/mocks"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(id uuid.UUID, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPut, "/wallets/"+id.String()+"/buckets", strings.NewReader(body))
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("WALLET_UUID", id.String())
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestBucketsHandler(t *testing.T) {
+	t.Run("shard", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockBalanceSharder(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		walletID := uuid.New()
+
+		mockService.
+			EXPECT().
+			SetBalanceBuckets(gomock.Any(), walletID, 16).
+			Return(&models.Wallet{ID: walletID, Balance: 100, BalanceBuckets: 16}, nil)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(walletID, `{"buckets":16}`))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `"balance_buckets":16`)
+	})
+
+	t.Run("missing buckets", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := New(slog.New(slog.NewTextHandler(io.Discard, nil)), mocks.NewMockBalanceSharder(ctrl))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(uuid.New(), `{}`))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			err    error
+			status int
+		}{
+			{name: "invalid buckets", err: services.ErrInvalidBalanceBuckets, status: http.StatusBadRequest},
+			{name: "wallet not found", err: storage.ErrWalletNotFound, status: http.StatusNotFound},
+			{name: "internal", err: context.DeadlineExceeded, status: http.StatusInternalServerError},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				mockService := mocks.NewMockBalanceSharder(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				walletID := uuid.New()
+
+				mockService.
+					EXPECT().
+					SetBalanceBuckets(gomock.Any(), walletID, 100).
+					Return(nil, tt.err)
+
+				handler := New(logger, mockService)
+
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, newRequest(walletID, `{"buckets":100}`))
+
+				require.Equal(t, tt.status, w.Code)
+			})
+		}
+	})
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/wallet/buckets/buckets.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/wallet/buckets/buckets.go -destination=internal/http-server/handlers/wallet/buckets/mocks/mock_buckets.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBalanceSharder is a mock of BalanceSharder interface.
+type MockBalanceSharder struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceSharderMockRecorder
+	isgomock struct{}
+}
+
+// MockBalanceSharderMockRecorder is the mock recorder for MockBalanceSharder.
+type MockBalanceSharderMockRecorder struct {
+	mock *MockBalanceSharder
+}
+
+// NewMockBalanceSharder creates a new mock instance.
+func NewMockBalanceSharder(ctrl *gomock.Controller) *MockBalanceSharder {
+	mock := &MockBalanceSharder{ctrl: ctrl}
+	mock.recorder = &MockBalanceSharderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalanceSharder) EXPECT() *MockBalanceSharderMockRecorder {
+	return m.recorder
+}
+
+// SetBalanceBuckets mocks base method.
+func (m *MockBalanceSharder) SetBalanceBuckets(ctx context.Context, walletID uuid.UUID, buckets int) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBalanceBuckets", ctx, walletID, buckets)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBalanceBuckets indicates an expected call of SetBalanceBuckets.
+func (mr *MockBalanceSharderMockRecorder) SetBalanceBuckets(ctx, walletID, buckets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBalanceBuckets", reflect.TypeOf((*MockBalanceSharder)(nil).SetBalanceBuckets), ctx, walletID, buckets)
+}
@@ -0,0 +1,104 @@
+package dailybalance
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type BalanceSeriesReader interface {
+	DailyBalances(
+		ctx context.Context,
+		walletID uuid.UUID,
+		from time.Time,
+		to time.Time,
+		loc *time.Location,
+	) (*models.BalanceSeries, error)
+}
+
+type response struct {
+	Status string                `json:"status"`
+	Series *models.BalanceSeries `json:"series,omitempty"`
+	Error  string                `json:"error,omitempty"`
+}
+
+// New returns the end-of-day balances of a wallet for the days "from" to "to"
+// (YYYY-MM-DD, both included) of the "tz" time zone, UTC by default.
+func New(log *slog.Logger, bsr BalanceSeriesReader) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := helpers.ReadUUIDParam(r, "WALLET_UUID")
+		if err != nil || id == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: wallet_id"))
+			return
+		}
+
+		from, to, loc, err := readRange(r)
+		if err != nil {
+			log.Error("failed to validate request")
+			handlers.BadRequestResponse(w, r, err)
+			return
+		}
+
+		series, err := bsr.DailyBalances(r.Context(), id, from, to, loc)
+		if err != nil {
+			log.Error(err.Error())
+
+			switch {
+			case errors.Is(err, services.ErrInvalidDateRange), errors.Is(err, services.ErrBalanceTimeInFuture):
+				handlers.BadRequestResponse(w, r, err)
+			case errors.Is(err, storage.ErrWalletNotFound):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, "wallet not found")
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusOK,
+			helpers.Envelope{"data": response{Status: "success", Series: series}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
+
+func readRange(r *http.Request) (time.Time, time.Time, *time.Location, error) {
+	qs := r.URL.Query()
+
+	from, err := helpers.ReadOptionalDate(qs, "from")
+	if err != nil {
+		return time.Time{}, time.Time{}, nil, err
+	}
+	if from == nil {
+		return time.Time{}, time.Time{}, nil, errors.New("from is required")
+	}
+
+	to, err := helpers.ReadOptionalDate(qs, "to")
+	if err != nil {
+		return time.Time{}, time.Time{}, nil, err
+	}
+	if to == nil {
+		return time.Time{}, time.Time{}, nil, errors.New("to is required")
+	}
+
+	loc, err := time.LoadLocation(helpers.ReadString(qs, "tz", "UTC"))
+	if err != nil {
+		return time.Time{}, time.Time{}, nil, errors.New("tz must be an IANA time zone name")
+	}
+
+	return *from, *to, loc, nil
+}
@@ -0,0 +1,129 @@
+package dailybalance
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/wallet/dailybalance/mocks"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(id uuid.UUID, query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/wallets/"+id.String()+"/balance/daily"+query, nil)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("WALLET_UUID", id.String())
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestDailyBalanceHandler(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockReader := mocks.NewMockBalanceSeriesReader(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		id := uuid.New()
+
+		mockReader.
+			EXPECT().
+			DailyBalances(gomock.Any(), id, gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(
+				_ context.Context,
+				_ uuid.UUID,
+				from time.Time,
+				to time.Time,
+				loc *time.Location,
+			) (*models.BalanceSeries, error) {
+				require.Equal(t, "2025-03-01", from.Format(time.DateOnly))
+				require.Equal(t, "2025-03-02", to.Format(time.DateOnly))
+				require.Equal(t, "Europe/Moscow", loc.String())
+
+				return &models.BalanceSeries{
+					WalletID: id,
+					Currency: "RUB",
+					TimeZone: loc.String(),
+					Days: []models.DailyBalance{
+						{Date: "2025-03-01", Balance: 100},
+						{Date: "2025-03-02", Balance: 250},
+					},
+				}, nil
+			})
+
+		handler := New(logger, mockReader)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest(id, "?from=2025-03-01&to=2025-03-02&tz=Europe/Moscow"))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), `"date":"2025-03-02"`)
+		require.Contains(t, rr.Body.String(), `"balance":250`)
+	})
+
+	badRequests := []struct {
+		name  string
+		query string
+	}{
+		{"missing from", "?to=2025-03-02"},
+		{"missing to", "?from=2025-03-01"},
+		{"invalid date", "?from=03/01/2025&to=2025-03-02"},
+		{"unknown time zone", "?from=2025-03-01&to=2025-03-02&tz=Mars/Olympus"},
+	}
+
+	for _, tc := range badRequests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler := New(slog.New(slog.NewTextHandler(io.Discard, nil)), mocks.NewMockBalanceSeriesReader(ctrl))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, newRequest(uuid.New(), tc.query))
+
+			require.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+
+	errorCases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"invalid range", services.ErrInvalidDateRange, http.StatusBadRequest},
+		{"future", services.ErrBalanceTimeInFuture, http.StatusBadRequest},
+		{"not found", storage.ErrWalletNotFound, http.StatusNotFound},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockReader := mocks.NewMockBalanceSeriesReader(ctrl)
+			mockReader.
+				EXPECT().
+				DailyBalances(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, tc.err)
+
+			handler := New(slog.New(slog.NewTextHandler(io.Discard, nil)), mockReader)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, newRequest(uuid.New(), "?from=2025-03-01&to=2025-03-02"))
+
+			require.Equal(t, tc.code, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/wallet/dailybalance/dailybalance.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/wallet/dailybalance/dailybalance.go -destination=internal/http-server/handlers/wallet/dailybalance/mocks/mock_dailybalance.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBalanceSeriesReader is a mock of BalanceSeriesReader interface.
+type MockBalanceSeriesReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceSeriesReaderMockRecorder
+	isgomock struct{}
+}
+
+// MockBalanceSeriesReaderMockRecorder is the mock recorder for MockBalanceSeriesReader.
+type MockBalanceSeriesReaderMockRecorder struct {
+	mock *MockBalanceSeriesReader
+}
+
+// NewMockBalanceSeriesReader creates a new mock instance.
+func NewMockBalanceSeriesReader(ctrl *gomock.Controller) *MockBalanceSeriesReader {
+	mock := &MockBalanceSeriesReader{ctrl: ctrl}
+	mock.recorder = &MockBalanceSeriesReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalanceSeriesReader) EXPECT() *MockBalanceSeriesReaderMockRecorder {
+	return m.recorder
+}
+
+// DailyBalances mocks base method.
+func (m *MockBalanceSeriesReader) DailyBalances(ctx context.Context, walletID uuid.UUID, from, to time.Time, loc *time.Location) (*models.BalanceSeries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DailyBalances", ctx, walletID, from, to, loc)
+	ret0, _ := ret[0].(*models.BalanceSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DailyBalances indicates an expected call of DailyBalances.
+func (mr *MockBalanceSeriesReaderMockRecorder) DailyBalances(ctx, walletID, from, to, loc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DailyBalances", reflect.TypeOf((*MockBalanceSeriesReader)(nil).DailyBalances), ctx, walletID, from, to, loc)
+}
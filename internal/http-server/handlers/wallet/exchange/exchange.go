@@ -0,0 +1,121 @@
+package exchange
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type WalletExchanger interface {
+	Exchange(
+		ctx context.Context,
+		quoteID, fromWalletID, toWalletID uuid.UUID,
+		amount int64,
+	) (*models.Exchange, error)
+}
+
+type request struct {
+	QuoteID      uuid.UUID `json:"quote_id"`
+	FromWalletID uuid.UUID `json:"from_wallet_id"`
+	ToWalletID   uuid.UUID `json:"to_wallet_id"`
+	Amount       int64     `json:"amount"`
+}
+
+type response struct {
+	Status   string           `json:"status"`
+	Exchange *models.Exchange `json:"exchange,omitempty"`
+	Error    string           `json:"error,omitempty"`
+}
+
+func New(log *slog.Logger, we WalletExchanger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request
+
+		err := helpers.ReadJSON(w, r, &req)
+		if err != nil {
+			log.Error("failed to decode request body")
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "ops! decode json")
+			return
+		}
+
+		if err := validateRequest(req); err != nil {
+			log.Error("failed to validate request")
+			handlers.BadRequestResponse(w, r, err)
+			return
+		}
+
+		exchange, err := we.Exchange(r.Context(), req.QuoteID, req.FromWalletID, req.ToWalletID, req.Amount)
+		if err != nil {
+			log.Error(err.Error())
+
+			var limitErr *services.LimitExceededError
+			switch {
+			case errors.Is(err, storage.ErrInsufficientFunds):
+				handlers.ErrorResponse(w, r, http.StatusBadRequest, "insufficient funds")
+			case errors.Is(err, services.ErrAmountNegativeValue):
+				handlers.ErrorResponse(w, r, http.StatusBadRequest, "amount negative value")
+			case errors.Is(err, services.ErrAmountTooLarge):
+				handlers.ErrorResponse(w, r, http.StatusUnprocessableEntity, services.ErrAmountTooLarge.Error())
+			case errors.Is(err, services.ErrSameWallet),
+				errors.Is(err, services.ErrCurrencyMismatch),
+				errors.Is(err, services.ErrInvalidQuoteID),
+				errors.Is(err, services.ErrExchangeAmountTooSmall):
+				handlers.BadRequestResponse(w, r, err)
+			case errors.Is(err, services.ErrQuoteExpired),
+				errors.Is(err, services.ErrQuoteUsed):
+				handlers.ErrorResponse(w, r, http.StatusConflict, err.Error())
+			case errors.Is(err, storage.ErrQuoteNotFound):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, "fx quote not found")
+			case errors.Is(err, storage.ErrWalletNotFound):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, "wallet not found")
+			case errors.Is(err, storage.ErrWalletFrozen):
+				handlers.ErrorResponse(w, r, http.StatusLocked, storage.ErrWalletFrozen.Error())
+			case errors.Is(err, storage.ErrWalletClosed):
+				handlers.ErrorResponse(w, r, http.StatusGone, storage.ErrWalletClosed.Error())
+			case errors.As(err, &limitErr):
+				handlers.LimitExceededResponse(w, r, limitErr)
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusOK,
+			helpers.Envelope{"data": response{Exchange: exchange, Status: "exchange was completed successfully"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
+
+func validateRequest(req request) error {
+	if req.QuoteID == uuid.Nil {
+		return errors.New("error invalid argument: quote_id")
+	}
+
+	if req.FromWalletID == uuid.Nil {
+		return errors.New("error invalid argument: from_wallet_id")
+	}
+
+	if req.ToWalletID == uuid.Nil {
+		return errors.New("error invalid argument: to_wallet_id")
+	}
+
+	if req.Amount == 0 {
+		return errors.New("error the value is zero: amount")
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package exchange
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/wallet/exchange/mocks"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExchangeHandler(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletExchanger(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		quoteID := uuid.New()
+		fromID := uuid.New()
+		toID := uuid.New()
+
+		expected := &models.Exchange{
+			ID:             uuid.New(),
+			QuoteID:        quoteID,
+			Rate:           "1.0825",
+			DebitedAmount:  1000,
+			CreditedAmount: 1082,
+		}
+
+		mockService.
+			EXPECT().
+			Exchange(gomock.Any(), quoteID, fromID, toID, int64(1000)).
+			Return(expected, nil)
+
+		handler := New(logger, mockService)
+
+		body := fmt.Sprintf(`{"quote_id":"%s","from_wallet_id":"%s","to_wallet_id":"%s","amount":1000}`,
+			quoteID, fromID, toID)
+		req := httptest.NewRequest(http.MethodPost, "/wallets/exchanges", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `"credited_amount":1082`)
+	})
+
+	t.Run("missing quote", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletExchanger(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		handler := New(logger, mockService)
+
+		body := fmt.Sprintf(`{"from_wallet_id":"%s","to_wallet_id":"%s","amount":1000}`, uuid.New(), uuid.New())
+		req := httptest.NewRequest(http.MethodPost, "/wallets/exchanges", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "quote_id")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			err    error
+			status int
+		}{
+			{name: "quote expired", err: services.ErrQuoteExpired, status: http.StatusConflict},
+			{name: "quote used", err: services.ErrQuoteUsed, status: http.StatusConflict},
+			{name: "quote not found", err: storage.ErrQuoteNotFound, status: http.StatusNotFound},
+			{name: "currency mismatch", err: services.ErrCurrencyMismatch, status: http.StatusBadRequest},
+			{name: "invalid quote id", err: services.ErrInvalidQuoteID, status: http.StatusBadRequest},
+			{name: "insufficient funds", err: storage.ErrInsufficientFunds, status: http.StatusBadRequest},
+			{name: "amount too large", err: services.ErrAmountTooLarge, status: http.StatusUnprocessableEntity},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				mockService := mocks.NewMockWalletExchanger(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				mockService.
+					EXPECT().
+					Exchange(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), int64(10)).
+					Return(nil, tt.err)
+
+				handler := New(logger, mockService)
+
+				body := fmt.Sprintf(`{"quote_id":"%s","from_wallet_id":"%s","to_wallet_id":"%s","amount":10}`,
+					uuid.New(), uuid.New(), uuid.New())
+				req := httptest.NewRequest(http.MethodPost, "/wallets/exchanges", strings.NewReader(body))
+				w := httptest.NewRecorder()
+
+				handler.ServeHTTP(w, req)
+
+				require.Equal(t, tt.status, w.Code)
+			})
+		}
+	})
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/wallet/exchange/exchange.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/wallet/exchange/exchange.go -destination=internal/http-server/handlers/wallet/exchange/mocks/mock_exchange.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWalletExchanger is a mock of WalletExchanger interface.
+type MockWalletExchanger struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletExchangerMockRecorder
+	isgomock struct{}
+}
+
+// MockWalletExchangerMockRecorder is the mock recorder for MockWalletExchanger.
+type MockWalletExchangerMockRecorder struct {
+	mock *MockWalletExchanger
+}
+
+// NewMockWalletExchanger creates a new mock instance.
+func NewMockWalletExchanger(ctrl *gomock.Controller) *MockWalletExchanger {
+	mock := &MockWalletExchanger{ctrl: ctrl}
+	mock.recorder = &MockWalletExchangerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletExchanger) EXPECT() *MockWalletExchangerMockRecorder {
+	return m.recorder
+}
+
+// Exchange mocks base method.
+func (m *MockWalletExchanger) Exchange(ctx context.Context, quoteID, fromWalletID, toWalletID uuid.UUID, amount int64) (*models.Exchange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", ctx, quoteID, fromWalletID, toWalletID, amount)
+	ret0, _ := ret[0].(*models.Exchange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockWalletExchangerMockRecorder) Exchange(ctx, quoteID, fromWalletID, toWalletID, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockWalletExchanger)(nil).Exchange), ctx, quoteID, fromWalletID, toWalletID, amount)
+}
@@ -0,0 +1,123 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type OperationLister interface {
+	ListOperations(ctx context.Context, walletID uuid.UUID, filter models.OperationFilter) (*models.OperationPage, error)
+}
+
+type response struct {
+	Status     string              `json:"status"`
+	Operations []*models.Operation `json:"operations"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+	Error      string              `json:"error,omitempty"`
+}
+
+func New(log *slog.Logger, ol OperationLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := helpers.ReadUUIDParam(r, "WALLET_UUID")
+		if err != nil || id == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: wallet_id"))
+			return
+		}
+
+		filter, err := readFilter(r)
+		if err != nil {
+			log.Error("failed to validate request")
+			handlers.BadRequestResponse(w, r, err)
+			return
+		}
+
+		page, err := ol.ListOperations(r.Context(), id, filter)
+		if err != nil {
+			log.Error(err.Error())
+
+			switch {
+			case errors.Is(err, services.ErrInvalidCursor):
+				handlers.BadRequestResponse(w, r, services.ErrInvalidCursor)
+			case errors.Is(err, storage.ErrWalletNotFound):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, "wallet not found")
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusOK,
+			helpers.Envelope{"data": response{
+				Status:     "success",
+				Operations: page.Operations,
+				NextCursor: page.NextCursor,
+			}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
+
+func readFilter(r *http.Request) (models.OperationFilter, error) {
+	qs := r.URL.Query()
+
+	var filter models.OperationFilter
+	var err error
+
+	filter.Cursor = helpers.ReadString(qs, "cursor", "")
+
+	filter.Limit, err = helpers.ReadInt(qs, "limit", 0)
+	if err != nil {
+		return filter, err
+	}
+	if filter.Limit < 0 {
+		return filter, errors.New("limit must not be negative")
+	}
+
+	for _, t := range helpers.ReadCSV(qs, "type", nil) {
+		opType := models.OperationType(t)
+		switch opType {
+		case models.Deposit, models.Withdraw, models.TransferOut, models.TransferIn, models.Capture,
+			models.ExchangeOut, models.ExchangeIn, models.Reversal:
+			filter.Types = append(filter.Types, opType)
+		default:
+			return filter, errors.New("unknown operation type: " + t)
+		}
+	}
+
+	if filter.MinAmount, err = helpers.ReadOptionalInt64(qs, "min_amount"); err != nil {
+		return filter, err
+	}
+	if filter.MaxAmount, err = helpers.ReadOptionalInt64(qs, "max_amount"); err != nil {
+		return filter, err
+	}
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return filter, errors.New("min_amount must not exceed max_amount")
+	}
+
+	if filter.From, err = helpers.ReadOptionalTime(qs, "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = helpers.ReadOptionalTime(qs, "to"); err != nil {
+		return filter, err
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return filter, errors.New("from must not be after to")
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,141 @@
+package history
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/wallet/history/mocks"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(id uuid.UUID, query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/wallets/"+id.String()+"/operations"+query, nil)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("WALLET_UUID", id.String())
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestHistoryHandler(t *testing.T) {
+	t.Run("success with filters", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockLister := mocks.NewMockOperationLister(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		id := uuid.New()
+		operationID := uuid.New()
+
+		mockLister.
+			EXPECT().
+			ListOperations(gomock.Any(), id, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, filter models.OperationFilter) (*models.OperationPage, error) {
+				require.Equal(t, "abc", filter.Cursor)
+				require.Equal(t, 10, filter.Limit)
+				require.Equal(t, []models.OperationType{models.Deposit, models.Withdraw}, filter.Types)
+				require.Equal(t, int64(5), *filter.MinAmount)
+				require.NotNil(t, filter.From)
+				require.Nil(t, filter.To)
+
+				return &models.OperationPage{
+					Operations: []*models.Operation{{ID: operationID, WalletID: id}},
+					NextCursor: "next",
+				}, nil
+			})
+
+		handler := New(logger, mockLister)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest(id, "?cursor=abc&limit=10&type=DEPOSIT,WITHDRAW&min_amount=5&from=2025-01-01T00:00:00Z"))
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Contains(t, rr.Body.String(), operationID.String())
+		require.Contains(t, rr.Body.String(), `"next_cursor":"next"`)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockLister := mocks.NewMockOperationLister(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		handler := New(logger, mockLister)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest(uuid.New(), "?type=REFUND"))
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("inverted amount range", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockLister := mocks.NewMockOperationLister(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		handler := New(logger, mockLister)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest(uuid.New(), "?min_amount=10&max_amount=1"))
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockLister := mocks.NewMockOperationLister(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		id := uuid.New()
+
+		mockLister.
+			EXPECT().
+			ListOperations(gomock.Any(), id, gomock.Any()).
+			Return(nil, services.ErrInvalidCursor)
+
+		handler := New(logger, mockLister)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest(id, "?cursor=broken"))
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("wallet not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockLister := mocks.NewMockOperationLister(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		id := uuid.New()
+
+		mockLister.
+			EXPECT().
+			ListOperations(gomock.Any(), id, gomock.Any()).
+			Return(nil, storage.ErrWalletNotFound)
+
+		handler := New(logger, mockLister)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest(id, ""))
+
+		require.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
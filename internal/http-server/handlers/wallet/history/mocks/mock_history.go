@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/wallet/history/history.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/wallet/history/history.go -destination=internal/http-server/handlers/wallet/history/mocks/mock_history.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOperationLister is a mock of OperationLister interface.
+type MockOperationLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationListerMockRecorder
+	isgomock struct{}
+}
+
+// MockOperationListerMockRecorder is the mock recorder for MockOperationLister.
+type MockOperationListerMockRecorder struct {
+	mock *MockOperationLister
+}
+
+// NewMockOperationLister creates a new mock instance.
+func NewMockOperationLister(ctrl *gomock.Controller) *MockOperationLister {
+	mock := &MockOperationLister{ctrl: ctrl}
+	mock.recorder = &MockOperationListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationLister) EXPECT() *MockOperationListerMockRecorder {
+	return m.recorder
+}
+
+// ListOperations mocks base method.
+func (m *MockOperationLister) ListOperations(ctx context.Context, walletID uuid.UUID, filter models.OperationFilter) (*models.OperationPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOperations", ctx, walletID, filter)
+	ret0, _ := ret[0].(*models.OperationPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOperations indicates an expected call of ListOperations.
+func (mr *MockOperationListerMockRecorder) ListOperations(ctx, walletID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOperations", reflect.TypeOf((*MockOperationLister)(nil).ListOperations), ctx, walletID, filter)
+}
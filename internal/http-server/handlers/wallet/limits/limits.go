@@ -0,0 +1,92 @@
+package limits
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type WalletLimitSetter interface {
+	SetLimits(ctx context.Context, walletID uuid.UUID, overrides *models.LimitOverrides) (*models.Limits, error)
+}
+
+type limitsView struct {
+	MaxSingleWithdrawal    int64 `json:"max_single_withdrawal"`
+	DailyWithdrawal        int64 `json:"daily_withdrawal"`
+	MonthlyWithdrawal      int64 `json:"monthly_withdrawal"`
+	MaxBalance             int64 `json:"max_balance"`
+	MaxOperations          int64 `json:"max_operations"`
+	OperationWindowSeconds int64 `json:"operation_window_seconds"`
+}
+
+type response struct {
+	Status string      `json:"status"`
+	Limits *limitsView `json:"limits,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// New replaces the limit overrides of a wallet. Fields omitted or set to null
+// fall back to the global defaults; the response carries the limits now in
+// effect, where 0 means unlimited.
+func New(log *slog.Logger, ws WalletLimitSetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := helpers.ReadUUIDParam(r, "WALLET_UUID")
+		if err != nil || id == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: wallet_id"))
+			return
+		}
+
+		var req models.LimitOverrides
+
+		err = helpers.ReadJSON(w, r, &req)
+		if err != nil {
+			log.Error("failed to decode request body")
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "ops! decode json")
+			return
+		}
+
+		limits, err := ws.SetLimits(r.Context(), id, &req)
+		if err != nil {
+			log.Error(err.Error())
+
+			switch {
+			case errors.Is(err, services.ErrInvalidLimits):
+				handlers.BadRequestResponse(w, r, err)
+			case errors.Is(err, storage.ErrWalletNotFound):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, "wallet not found")
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusOK,
+			helpers.Envelope{"data": response{
+				Status: "wallet limits were updated",
+				Limits: &limitsView{
+					MaxSingleWithdrawal:    limits.MaxSingleWithdrawal,
+					DailyWithdrawal:        limits.DailyWithdrawal,
+					MonthlyWithdrawal:      limits.MonthlyWithdrawal,
+					MaxBalance:             limits.MaxBalance,
+					MaxOperations:          limits.MaxOperations,
+					OperationWindowSeconds: int64(limits.OperationWindow.Seconds()),
+				},
+			}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package limits
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/wallet/limits/mocks"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(id uuid.UUID, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPut, "/wallets/"+id.String()+"/limits", strings.NewReader(body))
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("WALLET_UUID", id.String())
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestLimitsHandler(t *testing.T) {
+	t.Run("set overrides", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletLimitSetter(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		walletID := uuid.New()
+
+		mockService.
+			EXPECT().
+			SetLimits(gomock.Any(), walletID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ uuid.UUID, overrides *models.LimitOverrides) (*models.Limits, error) {
+				require.Equal(t, int64(5000), *overrides.DailyWithdrawal)
+				require.Nil(t, overrides.MaxBalance)
+				return &models.Limits{DailyWithdrawal: 5000, OperationWindow: time.Hour}, nil
+			})
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(walletID, `{"daily_withdrawal":5000,"max_balance":null}`))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `"daily_withdrawal":5000`)
+		require.Contains(t, w.Body.String(), `"operation_window_seconds":3600`)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			err    error
+			status int
+		}{
+			{name: "invalid limits", err: services.ErrInvalidLimits, status: http.StatusBadRequest},
+			{name: "wallet not found", err: storage.ErrWalletNotFound, status: http.StatusNotFound},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				mockService := mocks.NewMockWalletLimitSetter(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				walletID := uuid.New()
+
+				mockService.
+					EXPECT().
+					SetLimits(gomock.Any(), walletID, gomock.Any()).
+					Return(nil, tt.err)
+
+				handler := New(logger, mockService)
+
+				w := httptest.NewRecorder()
+				handler.ServeHTTP(w, newRequest(walletID, `{"max_balance":-1}`))
+
+				require.Equal(t, tt.status, w.Code)
+			})
+		}
+	})
+}
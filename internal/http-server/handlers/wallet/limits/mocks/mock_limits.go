@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/wallet/limits/limits.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/wallet/limits/limits.go -destination=internal/http-server/handlers/wallet/limits/mocks/mock_limits.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWalletLimitSetter is a mock of WalletLimitSetter interface.
+type MockWalletLimitSetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletLimitSetterMockRecorder
+	isgomock struct{}
+}
+
+// MockWalletLimitSetterMockRecorder is the mock recorder for MockWalletLimitSetter.
+type MockWalletLimitSetterMockRecorder struct {
+	mock *MockWalletLimitSetter
+}
+
+// NewMockWalletLimitSetter creates a new mock instance.
+func NewMockWalletLimitSetter(ctrl *gomock.Controller) *MockWalletLimitSetter {
+	mock := &MockWalletLimitSetter{ctrl: ctrl}
+	mock.recorder = &MockWalletLimitSetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletLimitSetter) EXPECT() *MockWalletLimitSetterMockRecorder {
+	return m.recorder
+}
+
+// SetLimits mocks base method.
+func (m *MockWalletLimitSetter) SetLimits(ctx context.Context, walletID uuid.UUID, overrides *models.LimitOverrides) (*models.Limits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLimits", ctx, walletID, overrides)
+	ret0, _ := ret[0].(*models.Limits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetLimits indicates an expected call of SetLimits.
+func (mr *MockWalletLimitSetterMockRecorder) SetLimits(ctx, walletID, overrides any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLimits", reflect.TypeOf((*MockWalletLimitSetter)(nil).SetLimits), ctx, walletID, overrides)
+}
@@ -42,6 +42,21 @@ func (m *MockWalletService) EXPECT() *MockWalletServiceMockRecorder {
 	return m.recorder
 }
 
+// Apply mocks base method.
+func (m *MockWalletService) Apply(ctx context.Context, req models.OperationRequest) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", ctx, req)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockWalletServiceMockRecorder) Apply(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockWalletService)(nil).Apply), ctx, req)
+}
+
 // Deposit mocks base method.
 func (m *MockWalletService) Deposit(ctx context.Context, walletID uuid.UUID, amount int64) (*models.Wallet, error) {
 	m.ctrl.T.Helper()
@@ -19,15 +19,23 @@ var (
 	emptyAmount    int64 = 0
 )
 
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	maxIdempotencyKeyLength = 255
+)
+
 type WalletService interface {
 	Deposit(ctx context.Context, walletID uuid.UUID, amount int64) (*models.Wallet, error)
 	Withdraw(ctx context.Context, walletID uuid.UUID, amount int64) (*models.Wallet, error)
+	Apply(ctx context.Context, req models.OperationRequest) (*models.Wallet, error)
 }
 
 type request struct {
 	WalletID      uuid.UUID `json:"wallet_id"`
 	OperationType string    `json:"operation_type"`
 	Amount        int64     `json:"amount"`
+	Currency      string    `json:"currency"`
 }
 
 type response struct {
@@ -52,13 +60,30 @@ func New(log *slog.Logger, ws WalletService) http.HandlerFunc {
 			return
 		}
 
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if len(key) > maxIdempotencyKeyLength {
+			log.Error("failed to validate request")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid header: Idempotency-Key is too long"))
+			return
+		}
+
 		var wallet *models.Wallet
 
-		switch req.OperationType {
-		case "DEPOSIT":
+		plain := key == "" && req.Currency == ""
+
+		switch {
+		case req.OperationType == "DEPOSIT" && plain:
 			wallet, err = ws.Deposit(r.Context(), req.WalletID, req.Amount)
-		case "WITHDRAW":
+		case req.OperationType == "WITHDRAW" && plain:
 			wallet, err = ws.Withdraw(r.Context(), req.WalletID, req.Amount)
+		case req.OperationType == "DEPOSIT" || req.OperationType == "WITHDRAW":
+			wallet, err = ws.Apply(r.Context(), models.OperationRequest{
+				WalletID:       req.WalletID,
+				Type:           models.OperationType(req.OperationType),
+				Amount:         req.Amount,
+				Currency:       req.Currency,
+				IdempotencyKey: key,
+			})
 		default:
 			log.Error("failed to validate request")
 			handlers.BadRequestResponse(w, r, errors.New("unknow operation"))
@@ -71,11 +96,39 @@ func New(log *slog.Logger, ws WalletService) http.HandlerFunc {
 				handlers.ErrorResponse(w, r, http.StatusBadRequest, "insufficient funds")
 				return
 			}
+			if errors.Is(err, storage.ErrWalletNotFound) {
+				handlers.ErrorResponse(w, r, http.StatusNotFound, "wallet not found")
+				return
+			}
+			if errors.Is(err, storage.ErrWalletFrozen) {
+				handlers.ErrorResponse(w, r, http.StatusLocked, storage.ErrWalletFrozen.Error())
+				return
+			}
+			if errors.Is(err, storage.ErrWalletClosed) {
+				handlers.ErrorResponse(w, r, http.StatusGone, storage.ErrWalletClosed.Error())
+				return
+			}
 			if errors.Is(err, services.ErrAmountNegativeValue) {
 				handlers.ErrorResponse(w, r, http.StatusBadRequest, "amount negative value")
 				return
 			}
 
+			if errors.Is(err, services.ErrUnsupportedCurrency) || errors.Is(err, services.ErrCurrencyMismatch) {
+				handlers.BadRequestResponse(w, r, err)
+				return
+			}
+
+			if errors.Is(err, services.ErrIdempotencyKeyReused) {
+				handlers.ErrorResponse(w, r, http.StatusConflict, services.ErrIdempotencyKeyReused.Error())
+				return
+			}
+
+			var limitErr *services.LimitExceededError
+			if errors.As(err, &limitErr) {
+				handlers.LimitExceededResponse(w, r, limitErr)
+				return
+			}
+
 			handlers.ErrorResponse(w, r, http.StatusInternalServerError, err.Error())
 			return
 		}
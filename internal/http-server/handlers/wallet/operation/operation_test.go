@@ -171,4 +171,190 @@ func TestOperationHandler(t *testing.T) {
 		require.Equal(t, http.StatusBadRequest, w.Code)
 		require.Contains(t, w.Body.String(), "amount negative value")
 	})
+
+	t.Run("deposit with idempotency key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletService(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		walletID := uuid.New()
+		amount := int64(100)
+		key := "order-42"
+
+		mockService.
+			EXPECT().
+			Apply(gomock.Any(), models.OperationRequest{
+				WalletID:       walletID,
+				Type:           models.Deposit,
+				Amount:         amount,
+				IdempotencyKey: key,
+			}).
+			Return(&models.Wallet{ID: walletID, Balance: 100}, nil)
+
+		handler := New(logger, mockService)
+
+		reqBody := fmt.Sprintf(`{"wallet_id":"%s","operation_type":"DEPOSIT","amount":%d}`, walletID, amount)
+		req := httptest.NewRequest(http.MethodPost, "/operations", strings.NewReader(reqBody))
+		req.Header.Set(IdempotencyKeyHeader, key)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("idempotency key reused with different payload", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletService(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		walletID := uuid.New()
+		amount := int64(50)
+		key := "order-42"
+
+		mockService.
+			EXPECT().
+			Apply(gomock.Any(), models.OperationRequest{
+				WalletID:       walletID,
+				Type:           models.Withdraw,
+				Amount:         amount,
+				IdempotencyKey: key,
+			}).
+			Return(nil, services.ErrIdempotencyKeyReused)
+
+		handler := New(logger, mockService)
+
+		reqBody := fmt.Sprintf(`{"wallet_id":"%s","operation_type":"WITHDRAW","amount":%d}`, walletID, amount)
+		req := httptest.NewRequest(http.MethodPost, "/operations", strings.NewReader(reqBody))
+		req.Header.Set(IdempotencyKeyHeader, key)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("currency mismatch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletService(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		walletID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Apply(gomock.Any(), models.OperationRequest{
+				WalletID: walletID,
+				Type:     models.Deposit,
+				Amount:   100,
+				Currency: "EUR",
+			}).
+			Return(nil, services.ErrCurrencyMismatch)
+
+		handler := New(logger, mockService)
+
+		reqBody := fmt.Sprintf(`{"wallet_id":"%s","operation_type":"DEPOSIT","amount":100,"currency":"EUR"}`, walletID)
+		req := httptest.NewRequest(http.MethodPost, "/operations", strings.NewReader(reqBody))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), services.ErrCurrencyMismatch.Error())
+	})
+
+	t.Run("wallet not active", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			err    error
+			status int
+		}{
+			{name: "frozen", err: storage.ErrWalletFrozen, status: http.StatusLocked},
+			{name: "closed", err: storage.ErrWalletClosed, status: http.StatusGone},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				mockService := mocks.NewMockWalletService(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				walletID := uuid.New()
+
+				mockService.
+					EXPECT().
+					Deposit(gomock.Any(), walletID, int64(10)).
+					Return(nil, fmt.Errorf("services.wallet.Deposit: %w", tt.err))
+
+				handler := New(logger, mockService)
+
+				reqBody := fmt.Sprintf(`{"wallet_id":"%s","operation_type":"DEPOSIT","amount":10}`, walletID)
+				req := httptest.NewRequest(http.MethodPost, "/operations", strings.NewReader(reqBody))
+				w := httptest.NewRecorder()
+
+				handler.ServeHTTP(w, req)
+
+				require.Equal(t, tt.status, w.Code)
+			})
+		}
+	})
+
+	t.Run("limit exceeded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletService(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		walletID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Withdraw(gomock.Any(), walletID, int64(300)).
+			Return(nil, &services.LimitExceededError{
+				Limit:     models.LimitDailyWithdrawal,
+				Max:       1000,
+				Attempted: 1200,
+			})
+
+		handler := New(logger, mockService)
+
+		reqBody := fmt.Sprintf(`{"wallet_id":"%s","operation_type":"WITHDRAW","amount":300}`, walletID)
+		req := httptest.NewRequest(http.MethodPost, "/operations", strings.NewReader(reqBody))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		require.JSONEq(t,
+			`{"error":{"message":"limit exceeded","limit":"daily_withdrawal","max":1000,"attempted":1200}}`,
+			w.Body.String())
+	})
+
+	t.Run("idempotency key too long", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletService(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		handler := New(logger, mockService)
+
+		reqBody := fmt.Sprintf(`{"wallet_id":"%s","operation_type":"DEPOSIT","amount":10}`, uuid.New())
+		req := httptest.NewRequest(http.MethodPost, "/operations", strings.NewReader(reqBody))
+		req.Header.Set(IdempotencyKeyHeader, strings.Repeat("k", 256))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
@@ -42,16 +42,16 @@ func (m *MockWalletSaver) EXPECT() *MockWalletSaverMockRecorder {
 }
 
 // CreateWallet mocks base method.
-func (m *MockWalletSaver) CreateWallet(ctx context.Context, amount int64) (*models.Wallet, error) {
+func (m *MockWalletSaver) CreateWallet(ctx context.Context, amount int64, currency string) (*models.Wallet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateWallet", ctx, amount)
+	ret := m.ctrl.Call(m, "CreateWallet", ctx, amount, currency)
 	ret0, _ := ret[0].(*models.Wallet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateWallet indicates an expected call of CreateWallet.
-func (mr *MockWalletSaverMockRecorder) CreateWallet(ctx, amount any) *gomock.Call {
+func (mr *MockWalletSaverMockRecorder) CreateWallet(ctx, amount, currency any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallet", reflect.TypeOf((*MockWalletSaver)(nil).CreateWallet), ctx, amount)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallet", reflect.TypeOf((*MockWalletSaver)(nil).CreateWallet), ctx, amount, currency)
 }
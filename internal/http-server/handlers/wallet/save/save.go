@@ -2,19 +2,22 @@ package save
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"wallet-service/internal/domain/models"
 	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
 	"wallet-service/pkg/helpers"
 )
 
 type WalletSaver interface {
-	CreateWallet(ctx context.Context, amount int64) (*models.Wallet, error)
+	CreateWallet(ctx context.Context, amount int64, currency string) (*models.Wallet, error)
 }
 
 type request struct {
-	Amount int64 `json:"amount"`
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
 }
 
 type response struct {
@@ -33,8 +36,13 @@ func New(log *slog.Logger, ws WalletSaver) http.HandlerFunc {
 			return
 		}
 
-		wallet, err := ws.CreateWallet(r.Context(), req.Amount)
+		wallet, err := ws.CreateWallet(r.Context(), req.Amount, req.Currency)
 		if err != nil {
+			if errors.Is(err, services.ErrUnsupportedCurrency) {
+				handlers.BadRequestResponse(w, r, services.ErrUnsupportedCurrency)
+				return
+			}
+
 			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "error create wallet")
 			return
 		}
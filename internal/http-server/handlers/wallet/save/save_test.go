@@ -10,6 +10,7 @@ import (
 	"testing"
 	"wallet-service/internal/domain/models"
 	"wallet-service/internal/http-server/handlers/wallet/save/mocks"
+	"wallet-service/internal/services"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -32,7 +33,7 @@ func TestCreateWalletHandler(t *testing.T) {
 
 		mockService.
 			EXPECT().
-			CreateWallet(gomock.Any(), int64(100)).
+			CreateWallet(gomock.Any(), int64(100), "").
 			Return(expectedWallet, nil)
 
 		handler := New(logger, mockService)
@@ -48,6 +49,53 @@ func TestCreateWalletHandler(t *testing.T) {
 		require.Contains(t, w.Body.String(), expectedWallet.ID.String())
 	})
 
+	t.Run("with currency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletSaver(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		mockService.
+			EXPECT().
+			CreateWallet(gomock.Any(), int64(500), "JPY").
+			Return(&models.Wallet{ID: uuid.New(), Balance: 500, Currency: "JPY"}, nil)
+
+		handler := New(logger, mockService)
+
+		body := `{"amount":500,"currency":"JPY"}`
+		req := httptest.NewRequest(http.MethodPost, "/wallets", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `"currency":"JPY"`)
+	})
+
+	t.Run("unsupported currency", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletSaver(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		mockService.
+			EXPECT().
+			CreateWallet(gomock.Any(), int64(100), "XYZ").
+			Return(nil, services.ErrUnsupportedCurrency)
+
+		handler := New(logger, mockService)
+
+		body := `{"amount":100,"currency":"XYZ"}`
+		req := httptest.NewRequest(http.MethodPost, "/wallets", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
 	t.Run("service error", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -57,7 +105,7 @@ func TestCreateWalletHandler(t *testing.T) {
 
 		mockService.
 			EXPECT().
-			CreateWallet(gomock.Any(), int64(100)).
+			CreateWallet(gomock.Any(), int64(100), "").
 			Return(nil, errors.New("service error"))
 
 		handler := New(logger, mockService)
@@ -0,0 +1,169 @@
+package statement
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"wallet-service/internal/domain/models"
+)
+
+type statementWriter interface {
+	models.StatementWriter
+	started() bool
+}
+
+var formats = map[string]func(w http.ResponseWriter) statementWriter{
+	"csv":    newCSVWriter,
+	"json":   newJSONWriter,
+	"ndjson": newNDJSONWriter,
+}
+
+// download sends the response headers of a statement file before its first
+// byte.
+type download struct {
+	w    http.ResponseWriter
+	sent bool
+}
+
+func (d *download) start(statement *models.Statement, contentType, extension string) {
+	filename := fmt.Sprintf("statement-%s-%s-%s.%s",
+		statement.WalletID,
+		statement.From.UTC().Format("20060102"),
+		statement.To.UTC().Format("20060102"),
+		extension,
+	)
+
+	d.w.Header().Set("Content-Type", contentType)
+	d.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	d.w.WriteHeader(http.StatusOK)
+	d.sent = true
+}
+
+func (d *download) started() bool {
+	return d.sent
+}
+
+// csvWriter renders one row per operation between an opening and a closing
+// balance row; balance is the running balance.
+type csvWriter struct {
+	download
+	csv       *csv.Writer
+	statement *models.Statement
+}
+
+func newCSVWriter(w http.ResponseWriter) statementWriter {
+	return &csvWriter{download: download{w: w}, csv: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Opening(statement *models.Statement) error {
+	c.start(statement, "text/csv; charset=utf-8", "csv")
+	c.statement = statement
+
+	_ = c.csv.Write([]string{"date", "operation_id", "type", "amount", "currency", "balance"})
+	return c.csv.Write([]string{
+		statement.From.Format(time.RFC3339Nano), "", "OPENING_BALANCE", "",
+		statement.Currency, strconv.FormatInt(statement.OpeningBalance, 10),
+	})
+}
+
+func (c *csvWriter) Line(operation *models.Operation) error {
+	return c.csv.Write([]string{
+		operation.CreatedAt.Format(time.RFC3339Nano),
+		operation.ID.String(),
+		string(operation.Type),
+		strconv.FormatInt(operation.Amount, 10),
+		c.statement.Currency,
+		strconv.FormatInt(*operation.BalanceAfter, 10),
+	})
+}
+
+func (c *csvWriter) Closing(balance int64) error {
+	_ = c.csv.Write([]string{
+		c.statement.To.Format(time.RFC3339Nano), "", "CLOSING_BALANCE", "",
+		c.statement.Currency, strconv.FormatInt(balance, 10),
+	})
+
+	c.csv.Flush()
+	return c.csv.Error()
+}
+
+// jsonWriter renders the statement as a single object whose operations array
+// is written element by element.
+type jsonWriter struct {
+	download
+	lines int
+}
+
+func newJSONWriter(w http.ResponseWriter) statementWriter {
+	return &jsonWriter{download: download{w: w}}
+}
+
+func (j *jsonWriter) Opening(statement *models.Statement) error {
+	j.start(statement, "application/json", "json")
+
+	header, err := json.Marshal(statement)
+	if err != nil {
+		return err
+	}
+
+	// The header object is left open for the operations array.
+	_, err = fmt.Fprintf(j.w, `%s,"operations":[`, header[:len(header)-1])
+	return err
+}
+
+func (j *jsonWriter) Line(operation *models.Operation) error {
+	line, err := json.Marshal(operation)
+	if err != nil {
+		return err
+	}
+
+	if j.lines > 0 {
+		line = append([]byte{','}, line...)
+	}
+	j.lines++
+
+	_, err = j.w.Write(line)
+	return err
+}
+
+func (j *jsonWriter) Closing(balance int64) error {
+	_, err := fmt.Fprintf(j.w, `],"closing_balance":%d}`+"\n", balance)
+	return err
+}
+
+// ndjsonWriter renders one JSON object per line: the opening, each
+// operation and the closing, told apart by their "type" field.
+type ndjsonWriter struct {
+	download
+	enc *json.Encoder
+}
+
+func newNDJSONWriter(w http.ResponseWriter) statementWriter {
+	return &ndjsonWriter{download: download{w: w}, enc: json.NewEncoder(w)}
+}
+
+func (n *ndjsonWriter) Opening(statement *models.Statement) error {
+	n.start(statement, "application/x-ndjson", "ndjson")
+
+	return n.enc.Encode(struct {
+		Kind string `json:"type"`
+		*models.Statement
+	}{"opening", statement})
+}
+
+func (n *ndjsonWriter) Line(operation *models.Operation) error {
+	return n.enc.Encode(struct {
+		Kind      string            `json:"type"`
+		Operation *models.Operation `json:"operation"`
+	}{"operation", operation})
+}
+
+func (n *ndjsonWriter) Closing(balance int64) error {
+	return n.enc.Encode(struct {
+		Kind    string `json:"type"`
+		Balance int64  `json:"closing_balance"`
+	}{"closing", balance})
+}
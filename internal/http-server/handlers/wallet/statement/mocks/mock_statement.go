@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/wallet/statement/statement.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/wallet/statement/statement.go -destination=internal/http-server/handlers/wallet/statement/mocks/mock_statement.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatementExporter is a mock of StatementExporter interface.
+type MockStatementExporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementExporterMockRecorder
+	isgomock struct{}
+}
+
+// MockStatementExporterMockRecorder is the mock recorder for MockStatementExporter.
+type MockStatementExporterMockRecorder struct {
+	mock *MockStatementExporter
+}
+
+// NewMockStatementExporter creates a new mock instance.
+func NewMockStatementExporter(ctrl *gomock.Controller) *MockStatementExporter {
+	mock := &MockStatementExporter{ctrl: ctrl}
+	mock.recorder = &MockStatementExporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementExporter) EXPECT() *MockStatementExporterMockRecorder {
+	return m.recorder
+}
+
+// Statement mocks base method.
+func (m *MockStatementExporter) Statement(ctx context.Context, walletID uuid.UUID, from, to time.Time, sw models.StatementWriter) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Statement", ctx, walletID, from, to, sw)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Statement indicates an expected call of Statement.
+func (mr *MockStatementExporterMockRecorder) Statement(ctx, walletID, from, to, sw any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Statement", reflect.TypeOf((*MockStatementExporter)(nil).Statement), ctx, walletID, from, to, sw)
+}
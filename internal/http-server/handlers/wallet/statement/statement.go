@@ -0,0 +1,82 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type StatementExporter interface {
+	Statement(ctx context.Context, walletID uuid.UUID, from time.Time, to time.Time, sw models.StatementWriter) error
+}
+
+// New streams the statement of a wallet for [from, to) as a download in the
+// requested format: csv (default), json or ndjson. timeout replaces the
+// server write timeout, which is tuned for small responses.
+func New(log *slog.Logger, se StatementExporter, timeout time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := helpers.ReadUUIDParam(r, "WALLET_UUID")
+		if err != nil || id == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: wallet_id"))
+			return
+		}
+
+		qs := r.URL.Query()
+
+		newWriter, ok := formats[helpers.ReadString(qs, "format", "csv")]
+		if !ok {
+			handlers.BadRequestResponse(w, r, errors.New("format must be one of csv, json, ndjson"))
+			return
+		}
+
+		var from, to time.Time
+		if t, err := helpers.ReadOptionalTime(qs, "from"); err != nil {
+			handlers.BadRequestResponse(w, r, err)
+			return
+		} else if t != nil {
+			from = *t
+		}
+		if t, err := helpers.ReadOptionalTime(qs, "to"); err != nil {
+			handlers.BadRequestResponse(w, r, err)
+			return
+		} else if t != nil {
+			to = *t
+		}
+
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout))
+
+		sw := newWriter(w)
+
+		err = se.Statement(r.Context(), id, from, to, sw)
+		if err == nil {
+			return
+		}
+
+		log.Error(err.Error())
+
+		if sw.started() {
+			// Break the connection so the client cannot take the truncated
+			// download for a complete statement.
+			panic(http.ErrAbortHandler)
+		}
+
+		switch {
+		case errors.Is(err, services.ErrInvalidDateRange):
+			handlers.BadRequestResponse(w, r, errors.New("from must not be after to"))
+		case errors.Is(err, storage.ErrWalletNotFound):
+			handlers.ErrorResponse(w, r, http.StatusNotFound, "wallet not found")
+		default:
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package statement
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/wallet/statement/mocks"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(id uuid.UUID, query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/wallets/"+id.String()+"/statement"+query, nil)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("WALLET_UUID", id.String())
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+var (
+	statementFrom = time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	statementTo   = time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// writeStatement plays a two-operation statement into sw.
+func writeStatement(walletID uuid.UUID, sw models.StatementWriter) error {
+	err := sw.Opening(&models.Statement{
+		WalletID:       walletID,
+		Currency:       "USD",
+		From:           statementFrom,
+		To:             statementTo,
+		OpeningBalance: 100,
+	})
+	if err != nil {
+		return err
+	}
+
+	balanceAfter := func(v int64) *int64 { return &v }
+	for _, operation := range []*models.Operation{
+		{ID: uuid.New(), WalletID: walletID, Type: models.Deposit, Amount: 50, BalanceAfter: balanceAfter(150)},
+		{ID: uuid.New(), WalletID: walletID, Type: models.Withdraw, Amount: 30, BalanceAfter: balanceAfter(120)},
+	} {
+		if err := sw.Line(operation); err != nil {
+			return err
+		}
+	}
+
+	return sw.Closing(120)
+}
+
+func serveStatement(t *testing.T, walletID uuid.UUID, query string) *httptest.ResponseRecorder {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockExporter := mocks.NewMockStatementExporter(ctrl)
+	mockExporter.
+		EXPECT().
+		Statement(gomock.Any(), walletID, statementFrom, statementTo, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, _, _ time.Time, sw models.StatementWriter) error {
+			return writeStatement(walletID, sw)
+		})
+
+	handler := New(slog.New(slog.NewTextHandler(io.Discard, nil)), mockExporter, time.Minute)
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, newRequest(walletID, query))
+
+	return rr
+}
+
+func TestStatementHandler(t *testing.T) {
+	const period = "from=2025-03-01T00:00:00Z&to=2025-04-01T00:00:00Z"
+
+	t.Run("csv", func(t *testing.T) {
+		walletID := uuid.New()
+
+		rr := serveStatement(t, walletID, "?"+period)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, "text/csv; charset=utf-8", rr.Header().Get("Content-Type"))
+		require.Equal(t,
+			`attachment; filename="statement-`+walletID.String()+`-20250301-20250401.csv"`,
+			rr.Header().Get("Content-Disposition"))
+
+		lines := strings.Split(strings.TrimSpace(rr.Body.String()), "\n")
+		require.Len(t, lines, 5)
+		require.Equal(t, "date,operation_id,type,amount,currency,balance", lines[0])
+		require.Equal(t, "2025-03-01T00:00:00Z,,OPENING_BALANCE,,USD,100", lines[1])
+		require.Contains(t, lines[2], ",DEPOSIT,50,USD,150")
+		require.Contains(t, lines[3], ",WITHDRAW,30,USD,120")
+		require.Equal(t, "2025-04-01T00:00:00Z,,CLOSING_BALANCE,,USD,120", lines[4])
+	})
+
+	t.Run("json", func(t *testing.T) {
+		rr := serveStatement(t, uuid.New(), "?format=json&"+period)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+		var body struct {
+			OpeningBalance int64               `json:"opening_balance"`
+			Operations     []*models.Operation `json:"operations"`
+			ClosingBalance int64               `json:"closing_balance"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+		require.Equal(t, int64(100), body.OpeningBalance)
+		require.Len(t, body.Operations, 2)
+		require.Equal(t, int64(150), *body.Operations[0].BalanceAfter)
+		require.Equal(t, int64(120), body.ClosingBalance)
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		rr := serveStatement(t, uuid.New(), "?format=ndjson&"+period)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, "application/x-ndjson", rr.Header().Get("Content-Type"))
+
+		var kinds []string
+		scanner := bufio.NewScanner(rr.Body)
+		for scanner.Scan() {
+			var line struct {
+				Kind string `json:"type"`
+			}
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+			kinds = append(kinds, line.Kind)
+		}
+		require.Equal(t, []string{"opening", "operation", "operation", "closing"}, kinds)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := New(slog.New(slog.NewTextHandler(io.Discard, nil)), mocks.NewMockStatementExporter(ctrl), time.Minute)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest(uuid.New(), "?format=pdf"))
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	errorCases := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"invalid range", services.ErrInvalidDateRange, http.StatusBadRequest},
+		{"not found", storage.ErrWalletNotFound, http.StatusNotFound},
+		{"internal", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockExporter := mocks.NewMockStatementExporter(ctrl)
+			mockExporter.
+				EXPECT().
+				Statement(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(tc.err)
+
+			handler := New(slog.New(slog.NewTextHandler(io.Discard, nil)), mockExporter, time.Minute)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, newRequest(uuid.New(), ""))
+
+			require.Equal(t, tc.code, rr.Code)
+		})
+	}
+
+	t.Run("failure after start aborts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockExporter := mocks.NewMockStatementExporter(ctrl)
+		mockExporter.
+			EXPECT().
+			Statement(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, walletID uuid.UUID, _, _ time.Time, sw models.StatementWriter) error {
+				_ = sw.Opening(&models.Statement{WalletID: walletID})
+				return errors.New("connection lost")
+			})
+
+		handler := New(slog.New(slog.NewTextHandler(io.Discard, nil)), mockExporter, time.Minute)
+
+		require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			handler.ServeHTTP(httptest.NewRecorder(), newRequest(uuid.New(), ""))
+		})
+	})
+}
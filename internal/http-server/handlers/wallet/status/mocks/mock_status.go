@@ -0,0 +1,88 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/wallet/status/status.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/wallet/status/status.go -destination=internal/http-server/handlers/wallet/status/mocks/mock_status.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWalletStatusChanger is a mock of WalletStatusChanger interface.
+type MockWalletStatusChanger struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletStatusChangerMockRecorder
+	isgomock struct{}
+}
+
+// MockWalletStatusChangerMockRecorder is the mock recorder for MockWalletStatusChanger.
+type MockWalletStatusChangerMockRecorder struct {
+	mock *MockWalletStatusChanger
+}
+
+// NewMockWalletStatusChanger creates a new mock instance.
+func NewMockWalletStatusChanger(ctrl *gomock.Controller) *MockWalletStatusChanger {
+	mock := &MockWalletStatusChanger{ctrl: ctrl}
+	mock.recorder = &MockWalletStatusChangerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletStatusChanger) EXPECT() *MockWalletStatusChangerMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockWalletStatusChanger) Close(ctx context.Context, walletID uuid.UUID, actor, reason string) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close", ctx, walletID, actor, reason)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockWalletStatusChangerMockRecorder) Close(ctx, walletID, actor, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockWalletStatusChanger)(nil).Close), ctx, walletID, actor, reason)
+}
+
+// Freeze mocks base method.
+func (m *MockWalletStatusChanger) Freeze(ctx context.Context, walletID uuid.UUID, actor, reason string) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Freeze", ctx, walletID, actor, reason)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Freeze indicates an expected call of Freeze.
+func (mr *MockWalletStatusChangerMockRecorder) Freeze(ctx, walletID, actor, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Freeze", reflect.TypeOf((*MockWalletStatusChanger)(nil).Freeze), ctx, walletID, actor, reason)
+}
+
+// Unfreeze mocks base method.
+func (m *MockWalletStatusChanger) Unfreeze(ctx context.Context, walletID uuid.UUID, actor, reason string) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unfreeze", ctx, walletID, actor, reason)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unfreeze indicates an expected call of Unfreeze.
+func (mr *MockWalletStatusChangerMockRecorder) Unfreeze(ctx, walletID, actor, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unfreeze", reflect.TypeOf((*MockWalletStatusChanger)(nil).Unfreeze), ctx, walletID, actor, reason)
+}
@@ -0,0 +1,99 @@
+package status
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type WalletStatusChanger interface {
+	Freeze(ctx context.Context, walletID uuid.UUID, actor, reason string) (*models.Wallet, error)
+	Unfreeze(ctx context.Context, walletID uuid.UUID, actor, reason string) (*models.Wallet, error)
+	Close(ctx context.Context, walletID uuid.UUID, actor, reason string) (*models.Wallet, error)
+}
+
+type request struct {
+	Status models.WalletStatus `json:"status"`
+	Actor  string              `json:"actor"`
+	Reason string              `json:"reason"`
+}
+
+type response struct {
+	Status string         `json:"status"`
+	Wallet *models.Wallet `json:"wallet,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+func New(log *slog.Logger, ws WalletStatusChanger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := helpers.ReadUUIDParam(r, "WALLET_UUID")
+		if err != nil || id == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: wallet_id"))
+			return
+		}
+
+		var req request
+
+		err = helpers.ReadJSON(w, r, &req)
+		if err != nil {
+			log.Error("failed to decode request body")
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "ops! decode json")
+			return
+		}
+
+		if req.Actor == "" || req.Reason == "" {
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: actor, reason"))
+			return
+		}
+
+		var wallet *models.Wallet
+
+		switch req.Status {
+		case models.WalletFrozen:
+			wallet, err = ws.Freeze(r.Context(), id, req.Actor, req.Reason)
+		case models.WalletActive:
+			wallet, err = ws.Unfreeze(r.Context(), id, req.Actor, req.Reason)
+		case models.WalletClosed:
+			wallet, err = ws.Close(r.Context(), id, req.Actor, req.Reason)
+		default:
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: status"))
+			return
+		}
+
+		if err != nil {
+			log.Error(err.Error())
+
+			switch {
+			case errors.Is(err, services.ErrInvalidStatusChange):
+				handlers.BadRequestResponse(w, r, err)
+			case errors.Is(err, services.ErrInvalidStatusTransition),
+				errors.Is(err, services.ErrWalletNotEmpty):
+				handlers.ErrorResponse(w, r, http.StatusConflict, err.Error())
+			case errors.Is(err, storage.ErrWalletNotFound):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, "wallet not found")
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusOK,
+			helpers.Envelope{"data": response{Wallet: wallet, Status: "wallet status was changed"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package status
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/wallet/status/mocks"
+	"wallet-service/internal/services"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(id uuid.UUID, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/wallets/"+id.String()+"/status", strings.NewReader(body))
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("WALLET_UUID", id.String())
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestStatusHandler(t *testing.T) {
+	t.Run("freeze", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletStatusChanger(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		walletID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Freeze(gomock.Any(), walletID, "support:alice", "chargeback investigation").
+			Return(&models.Wallet{ID: walletID, Status: models.WalletFrozen}, nil)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(walletID,
+			`{"status":"FROZEN","actor":"support:alice","reason":"chargeback investigation"}`))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `"status":"FROZEN"`)
+	})
+
+	t.Run("close non-empty wallet", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletStatusChanger(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		walletID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Close(gomock.Any(), walletID, "support:bob", "customer request").
+			Return(nil, services.ErrWalletNotEmpty)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(walletID, `{"status":"CLOSED","actor":"support:bob","reason":"customer request"}`))
+
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletStatusChanger(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(uuid.New(), `{"status":"DELETED","actor":"a","reason":"b"}`))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/wallet/stream/stream.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/wallet/stream/stream.go -destination=internal/http-server/handlers/wallet/stream/mocks/mock_stream.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOperationStreamer is a mock of OperationStreamer interface.
+type MockOperationStreamer struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationStreamerMockRecorder
+	isgomock struct{}
+}
+
+// MockOperationStreamerMockRecorder is the mock recorder for MockOperationStreamer.
+type MockOperationStreamerMockRecorder struct {
+	mock *MockOperationStreamer
+}
+
+// NewMockOperationStreamer creates a new mock instance.
+func NewMockOperationStreamer(ctrl *gomock.Controller) *MockOperationStreamer {
+	mock := &MockOperationStreamer{ctrl: ctrl}
+	mock.recorder = &MockOperationStreamerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationStreamer) EXPECT() *MockOperationStreamerMockRecorder {
+	return m.recorder
+}
+
+// OperationsSince mocks base method.
+func (m *MockOperationStreamer) OperationsSince(ctx context.Context, walletID uuid.UUID, cursor string) ([]models.OperationEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OperationsSince", ctx, walletID, cursor)
+	ret0, _ := ret[0].([]models.OperationEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OperationsSince indicates an expected call of OperationsSince.
+func (mr *MockOperationStreamerMockRecorder) OperationsSince(ctx, walletID, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OperationsSince", reflect.TypeOf((*MockOperationStreamer)(nil).OperationsSince), ctx, walletID, cursor)
+}
+
+// StreamCursor mocks base method.
+func (m *MockOperationStreamer) StreamCursor(ctx context.Context, walletID uuid.UUID, lastEventID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamCursor", ctx, walletID, lastEventID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StreamCursor indicates an expected call of StreamCursor.
+func (mr *MockOperationStreamerMockRecorder) StreamCursor(ctx, walletID, lastEventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamCursor", reflect.TypeOf((*MockOperationStreamer)(nil).StreamCursor), ctx, walletID, lastEventID)
+}
+
+// MockSubscriber is a mock of Subscriber interface.
+type MockSubscriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriberMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriberMockRecorder is the mock recorder for MockSubscriber.
+type MockSubscriberMockRecorder struct {
+	mock *MockSubscriber
+}
+
+// NewMockSubscriber creates a new mock instance.
+func NewMockSubscriber(ctrl *gomock.Controller) *MockSubscriber {
+	mock := &MockSubscriber{ctrl: ctrl}
+	mock.recorder = &MockSubscriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriber) EXPECT() *MockSubscriberMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockSubscriber) Subscribe(walletID uuid.UUID) (<-chan struct{}, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", walletID)
+	ret0, _ := ret[0].(<-chan struct{})
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockSubscriberMockRecorder) Subscribe(walletID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockSubscriber)(nil).Subscribe), walletID)
+}
@@ -0,0 +1,144 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/lib/logger/sl"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+// LastEventIDHeader is sent by reconnecting EventSource clients with the id
+// of the last event they received.
+const LastEventIDHeader = "Last-Event-ID"
+
+type OperationStreamer interface {
+	StreamCursor(ctx context.Context, walletID uuid.UUID, lastEventID string) (string, error)
+	OperationsSince(ctx context.Context, walletID uuid.UUID, cursor string) ([]models.OperationEvent, error)
+}
+
+type Subscriber interface {
+	Subscribe(walletID uuid.UUID) (signals <-chan struct{}, unsubscribe func())
+}
+
+func New(log *slog.Logger, streamer OperationStreamer, sub Subscriber, heartbeat time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := helpers.ReadUUIDParam(r, "WALLET_UUID")
+		if err != nil || id == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: wallet_id"))
+			return
+		}
+
+		// Subscribe before reading the cursor, so an operation committed in
+		// between is signalled rather than lost.
+		signals, unsubscribe := sub.Subscribe(id)
+		defer unsubscribe()
+
+		cursor, err := streamer.StreamCursor(r.Context(), id, r.Header.Get(LastEventIDHeader))
+		if err != nil {
+			log.Error(err.Error())
+
+			switch {
+			case errors.Is(err, services.ErrInvalidCursor):
+				handlers.BadRequestResponse(w, r, errors.New("invalid Last-Event-ID"))
+			case errors.Is(err, storage.ErrWalletNotFound):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, "wallet not found")
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		rc := http.NewResponseController(w)
+		// The server write timeout is meant for ordinary requests.
+		_ = rc.SetWriteDeadline(time.Time{})
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		if err := rc.Flush(); err != nil {
+			log.Error("streaming is not supported", sl.Err(err))
+			return
+		}
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		for {
+			cursor, err = catchUp(r.Context(), w, streamer, id, cursor)
+			if err != nil {
+				if r.Context().Err() == nil {
+					log.Error("failed to stream operations", sl.Err(err))
+				}
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+
+			select {
+			case <-r.Context().Done():
+				return
+			case _, ok := <-signals:
+				if !ok {
+					return
+				}
+			case <-ticker.C:
+				// Also a safety net for notifications lost while the
+				// database listener reconnected.
+				if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// catchUp writes every operation committed after cursor, batch by batch
+// until an empty one, and returns the cursor of the last one written.
+func catchUp(
+	ctx context.Context,
+	w http.ResponseWriter,
+	streamer OperationStreamer,
+	walletID uuid.UUID,
+	cursor string,
+) (string, error) {
+	for {
+		events, err := streamer.OperationsSince(ctx, walletID, cursor)
+		if err != nil {
+			return cursor, err
+		}
+
+		for _, event := range events {
+			data, err := json.Marshal(event.Operation)
+			if err != nil {
+				return cursor, err
+			}
+
+			_, err = fmt.Fprintf(w, "id: %s\nevent: operation\ndata: %s\n\n", event.Cursor, data)
+			if err != nil {
+				return cursor, err
+			}
+
+			cursor = event.Cursor
+		}
+
+		if len(events) == 0 {
+			return cursor, nil
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package stream
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/wallet/stream/mocks"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(ctx context.Context, id uuid.UUID) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/wallets/"+id.String()+"/stream", nil)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("WALLET_UUID", id.String())
+
+	return req.WithContext(context.WithValue(ctx, chi.RouteCtxKey, rctx))
+}
+
+func TestStreamHandler(t *testing.T) {
+	t.Run("resumes after last event id and follows signals", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStreamer := mocks.NewMockOperationStreamer(ctrl)
+		mockSubscriber := mocks.NewMockSubscriber(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		id := uuid.New()
+		first := &models.Operation{ID: uuid.New(), WalletID: id, Type: models.Deposit, Amount: 100}
+		second := &models.Operation{ID: uuid.New(), WalletID: id, Type: models.Withdraw, Amount: 40}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		signals := make(chan struct{}, 1)
+		unsubscribed := false
+
+		mockSubscriber.
+			EXPECT().
+			Subscribe(id).
+			Return(signals, func() { unsubscribed = true })
+
+		mockStreamer.
+			EXPECT().
+			StreamCursor(gomock.Any(), id, "c0").
+			Return("c0", nil)
+
+		gomock.InOrder(
+			mockStreamer.
+				EXPECT().
+				OperationsSince(gomock.Any(), id, "c0").
+				Return([]models.OperationEvent{{Cursor: "c1", Operation: first}}, nil),
+			mockStreamer.
+				EXPECT().
+				OperationsSince(gomock.Any(), id, "c1").
+				DoAndReturn(func(context.Context, uuid.UUID, string) ([]models.OperationEvent, error) {
+					signals <- struct{}{}
+					return nil, nil
+				}),
+			mockStreamer.
+				EXPECT().
+				OperationsSince(gomock.Any(), id, "c1").
+				Return([]models.OperationEvent{{Cursor: "c2", Operation: second}}, nil),
+			mockStreamer.
+				EXPECT().
+				OperationsSince(gomock.Any(), id, "c2").
+				DoAndReturn(func(context.Context, uuid.UUID, string) ([]models.OperationEvent, error) {
+					cancel()
+					return nil, nil
+				}),
+		)
+
+		handler := New(logger, mockStreamer, mockSubscriber, time.Hour)
+
+		req := newRequest(ctx, id)
+		req.Header.Set(LastEventIDHeader, "c0")
+		rr := httptest.NewRecorder()
+
+		handler.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+		require.True(t, unsubscribed)
+
+		body := rr.Body.String()
+		require.Contains(t, body, "id: c1\nevent: operation\ndata: {\"id\":\""+first.ID.String())
+		require.Contains(t, body, "id: c2\nevent: operation\ndata: {\"id\":\""+second.ID.String())
+		require.Less(t, strings.Index(body, "id: c1"), strings.Index(body, "id: c2"))
+	})
+
+	t.Run("heartbeat", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockStreamer := mocks.NewMockOperationStreamer(ctrl)
+		mockSubscriber := mocks.NewMockSubscriber(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		id := uuid.New()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		mockSubscriber.
+			EXPECT().
+			Subscribe(id).
+			Return(make(chan struct{}), func() {})
+
+		mockStreamer.
+			EXPECT().
+			StreamCursor(gomock.Any(), id, "").
+			Return("", nil)
+
+		calls := 0
+		mockStreamer.
+			EXPECT().
+			OperationsSince(gomock.Any(), id, "").
+			DoAndReturn(func(context.Context, uuid.UUID, string) ([]models.OperationEvent, error) {
+				calls++
+				if calls == 2 {
+					cancel()
+				}
+				return nil, nil
+			}).
+			Times(2)
+
+		handler := New(logger, mockStreamer, mockSubscriber, 10*time.Millisecond)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest(ctx, id))
+
+		require.Equal(t, ": ping\n\n", rr.Body.String())
+	})
+
+	t.Run("errors before streaming", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			err    error
+			status int
+		}{
+			{name: "invalid last event id", err: services.ErrInvalidCursor, status: http.StatusBadRequest},
+			{name: "wallet not found", err: storage.ErrWalletNotFound, status: http.StatusNotFound},
+			{name: "internal", err: context.DeadlineExceeded, status: http.StatusInternalServerError},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+
+				mockStreamer := mocks.NewMockOperationStreamer(ctrl)
+				mockSubscriber := mocks.NewMockSubscriber(ctrl)
+				logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+				id := uuid.New()
+
+				mockSubscriber.
+					EXPECT().
+					Subscribe(id).
+					Return(make(chan struct{}), func() {})
+
+				mockStreamer.
+					EXPECT().
+					StreamCursor(gomock.Any(), id, "").
+					Return("", tt.err)
+
+				handler := New(logger, mockStreamer, mockSubscriber, time.Hour)
+
+				rr := httptest.NewRecorder()
+				handler.ServeHTTP(rr, newRequest(context.Background(), id))
+
+				require.Equal(t, tt.status, rr.Code)
+			})
+		}
+	})
+
+	t.Run("invalid wallet id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := New(
+			slog.New(slog.NewTextHandler(io.Discard, nil)),
+			mocks.NewMockOperationStreamer(ctrl),
+			mocks.NewMockSubscriber(ctrl),
+			time.Hour)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newRequest(context.Background(), uuid.Nil))
+
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
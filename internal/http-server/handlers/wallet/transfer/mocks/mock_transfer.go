@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/wallet/transfer/transfer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/wallet/transfer/transfer.go -destination=internal/http-server/handlers/wallet/transfer/mocks/mock_transfer.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWalletTransferer is a mock of WalletTransferer interface.
+type MockWalletTransferer struct {
+	ctrl     *gomock.Controller
+	recorder *MockWalletTransfererMockRecorder
+	isgomock struct{}
+}
+
+// MockWalletTransfererMockRecorder is the mock recorder for MockWalletTransferer.
+type MockWalletTransfererMockRecorder struct {
+	mock *MockWalletTransferer
+}
+
+// NewMockWalletTransferer creates a new mock instance.
+func NewMockWalletTransferer(ctrl *gomock.Controller) *MockWalletTransferer {
+	mock := &MockWalletTransferer{ctrl: ctrl}
+	mock.recorder = &MockWalletTransfererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWalletTransferer) EXPECT() *MockWalletTransfererMockRecorder {
+	return m.recorder
+}
+
+// Transfer mocks base method.
+func (m *MockWalletTransferer) Transfer(ctx context.Context, fromWalletID, toWalletID uuid.UUID, amount int64, conversion *models.Conversion) (*models.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transfer", ctx, fromWalletID, toWalletID, amount, conversion)
+	ret0, _ := ret[0].(*models.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Transfer indicates an expected call of Transfer.
+func (mr *MockWalletTransfererMockRecorder) Transfer(ctx, fromWalletID, toWalletID, amount, conversion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transfer", reflect.TypeOf((*MockWalletTransferer)(nil).Transfer), ctx, fromWalletID, toWalletID, amount, conversion)
+}
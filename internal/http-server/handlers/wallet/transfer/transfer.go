@@ -0,0 +1,123 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type WalletTransferer interface {
+	Transfer(
+		ctx context.Context,
+		fromWalletID, toWalletID uuid.UUID,
+		amount int64,
+		conversion *models.Conversion,
+	) (*models.Transfer, error)
+}
+
+type request struct {
+	FromWalletID uuid.UUID          `json:"from_wallet_id"`
+	ToWalletID   uuid.UUID          `json:"to_wallet_id"`
+	Amount       int64              `json:"amount"`
+	Conversion   *models.Conversion `json:"conversion,omitempty"`
+}
+
+type response struct {
+	Status   string           `json:"status"`
+	Transfer *models.Transfer `json:"transfer,omitempty"`
+	Error    string           `json:"error,omitempty"`
+}
+
+func New(log *slog.Logger, wt WalletTransferer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request
+
+		err := helpers.ReadJSON(w, r, &req)
+		if err != nil {
+			log.Error("failed to decode request body")
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "ops! decode json")
+			return
+		}
+
+		if err := validateRequest(req); err != nil {
+			log.Error("failed to validate request")
+			handlers.BadRequestResponse(w, r, err)
+			return
+		}
+
+		transfer, err := wt.Transfer(r.Context(), req.FromWalletID, req.ToWalletID, req.Amount, req.Conversion)
+		if err != nil {
+			log.Error(err.Error())
+
+			var limitErr *services.LimitExceededError
+			switch {
+			case errors.Is(err, storage.ErrInsufficientFunds):
+				handlers.ErrorResponse(w, r, http.StatusBadRequest, "insufficient funds")
+			case errors.Is(err, services.ErrAmountNegativeValue):
+				handlers.ErrorResponse(w, r, http.StatusBadRequest, "amount negative value")
+			case errors.Is(err, services.ErrSameWallet),
+				errors.Is(err, services.ErrCurrencyMismatch),
+				errors.Is(err, services.ErrUnexpectedConversion),
+				errors.Is(err, services.ErrInvalidQuoteID),
+				errors.Is(err, services.ErrExchangeAmountTooSmall):
+				handlers.BadRequestResponse(w, r, err)
+			case errors.Is(err, services.ErrAmountTooLarge):
+				handlers.ErrorResponse(w, r, http.StatusUnprocessableEntity, services.ErrAmountTooLarge.Error())
+			case errors.Is(err, services.ErrQuoteExpired),
+				errors.Is(err, services.ErrQuoteUsed):
+				handlers.ErrorResponse(w, r, http.StatusConflict, err.Error())
+			case errors.Is(err, storage.ErrQuoteNotFound):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, "fx quote not found")
+			case errors.Is(err, storage.ErrWalletNotFound):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, "wallet not found")
+			case errors.Is(err, storage.ErrWalletFrozen):
+				handlers.ErrorResponse(w, r, http.StatusLocked, storage.ErrWalletFrozen.Error())
+			case errors.Is(err, storage.ErrWalletClosed):
+				handlers.ErrorResponse(w, r, http.StatusGone, storage.ErrWalletClosed.Error())
+			case errors.As(err, &limitErr):
+				handlers.LimitExceededResponse(w, r, limitErr)
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusOK,
+			helpers.Envelope{"data": response{Transfer: transfer, Status: "transfer was completed successfully"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
+
+func validateRequest(req request) error {
+	if req.FromWalletID == uuid.Nil {
+		return errors.New("error invalid argument: from_wallet_id")
+	}
+
+	if req.ToWalletID == uuid.Nil {
+		return errors.New("error invalid argument: to_wallet_id")
+	}
+
+	if req.Amount == 0 {
+		return errors.New("error the value is zero: amount")
+	}
+
+	if req.Conversion != nil && req.Conversion.QuoteID == uuid.Nil {
+		return errors.New("error invalid argument: conversion.quote_id")
+	}
+
+	return nil
+}
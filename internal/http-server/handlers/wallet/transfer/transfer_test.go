@@ -0,0 +1,323 @@
+package transfer
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/wallet/transfer/mocks"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTransferHandler(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletTransferer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		fromID := uuid.New()
+		toID := uuid.New()
+		amount := int64(100)
+
+		expected := &models.Transfer{
+			ID:     uuid.New(),
+			From:   &models.Wallet{ID: fromID, Balance: 0},
+			To:     &models.Wallet{ID: toID, Balance: 100},
+			Amount: amount,
+		}
+
+		mockService.
+			EXPECT().
+			Transfer(gomock.Any(), fromID, toID, amount, nil).
+			Return(expected, nil)
+
+		handler := New(logger, mockService)
+
+		body := fmt.Sprintf(`{"from_wallet_id":"%s","to_wallet_id":"%s","amount":%d}`, fromID, toID, amount)
+		req := httptest.NewRequest(http.MethodPost, "/wallets/transfers", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), expected.ID.String())
+		require.Contains(t, w.Body.String(), "transfer was completed successfully")
+	})
+
+	t.Run("cross-currency with conversion", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletTransferer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		fromID := uuid.New()
+		toID := uuid.New()
+		quoteID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Transfer(gomock.Any(), fromID, toID, int64(1000), &models.Conversion{QuoteID: quoteID}).
+			Return(&models.Transfer{ID: uuid.New(), Amount: 1000, CreditedAmount: 1082, Rate: "1.0825"}, nil)
+
+		handler := New(logger, mockService)
+
+		body := fmt.Sprintf(
+			`{"from_wallet_id":"%s","to_wallet_id":"%s","amount":1000,"conversion":{"quote_id":"%s"}}`,
+			fromID, toID, quoteID,
+		)
+		req := httptest.NewRequest(http.MethodPost, "/wallets/transfers", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `"credited_amount":1082`)
+	})
+
+	t.Run("conversion without quote", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletTransferer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		handler := New(logger, mockService)
+
+		body := fmt.Sprintf(
+			`{"from_wallet_id":"%s","to_wallet_id":"%s","amount":1000,"conversion":{}}`,
+			uuid.New(), uuid.New(),
+		)
+		req := httptest.NewRequest(http.MethodPost, "/wallets/transfers", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "conversion.quote_id")
+	})
+
+	t.Run("expired quote", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletTransferer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		mockService.
+			EXPECT().
+			Transfer(gomock.Any(), gomock.Any(), gomock.Any(), int64(1000), gomock.Any()).
+			Return(nil, services.ErrQuoteExpired)
+
+		handler := New(logger, mockService)
+
+		body := fmt.Sprintf(
+			`{"from_wallet_id":"%s","to_wallet_id":"%s","amount":1000,"conversion":{"quote_id":"%s"}}`,
+			uuid.New(), uuid.New(), uuid.New(),
+		)
+		req := httptest.NewRequest(http.MethodPost, "/wallets/transfers", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("currency mismatch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletTransferer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		fromID := uuid.New()
+		toID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Transfer(gomock.Any(), fromID, toID, int64(100), nil).
+			Return(nil, services.ErrCurrencyMismatch)
+
+		handler := New(logger, mockService)
+
+		body := fmt.Sprintf(`{"from_wallet_id":"%s","to_wallet_id":"%s","amount":100}`, fromID, toID)
+		req := httptest.NewRequest(http.MethodPost, "/wallets/transfers", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), services.ErrCurrencyMismatch.Error())
+	})
+
+	t.Run("missing destination", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletTransferer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		handler := New(logger, mockService)
+
+		body := fmt.Sprintf(`{"from_wallet_id":"%s","amount":100}`, uuid.New())
+		req := httptest.NewRequest(http.MethodPost, "/wallets/transfers", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "to_wallet_id")
+	})
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletTransferer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		fromID := uuid.New()
+		toID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Transfer(gomock.Any(), fromID, toID, int64(500), nil).
+			Return(nil, storage.ErrInsufficientFunds)
+
+		handler := New(logger, mockService)
+
+		body := fmt.Sprintf(`{"from_wallet_id":"%s","to_wallet_id":"%s","amount":500}`, fromID, toID)
+		req := httptest.NewRequest(http.MethodPost, "/wallets/transfers", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), "insufficient funds")
+	})
+
+	t.Run("limit exceeded", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletTransferer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		fromID := uuid.New()
+		toID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Transfer(gomock.Any(), fromID, toID, int64(500), nil).
+			Return(nil, fmt.Errorf("services.wallet.Transfer: %w", &services.LimitExceededError{
+				Limit:     models.LimitDailyWithdrawal,
+				Max:       100,
+				Attempted: 500,
+			}))
+
+		handler := New(logger, mockService)
+
+		body := fmt.Sprintf(`{"from_wallet_id":"%s","to_wallet_id":"%s","amount":500}`, fromID, toID)
+		req := httptest.NewRequest(http.MethodPost, "/wallets/transfers", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		require.Contains(t, w.Body.String(), `"limit":"daily_withdrawal"`)
+	})
+
+	t.Run("invalid quote id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletTransferer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		fromID := uuid.New()
+		toID := uuid.New()
+		quoteID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Transfer(gomock.Any(), fromID, toID, int64(1000), &models.Conversion{QuoteID: quoteID}).
+			Return(nil, services.ErrInvalidQuoteID)
+
+		handler := New(logger, mockService)
+
+		body := fmt.Sprintf(
+			`{"from_wallet_id":"%s","to_wallet_id":"%s","amount":1000,"conversion":{"quote_id":"%s"}}`,
+			fromID, toID, quoteID,
+		)
+		req := httptest.NewRequest(http.MethodPost, "/wallets/transfers", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.Contains(t, w.Body.String(), services.ErrInvalidQuoteID.Error())
+		require.NotContains(t, w.Body.String(), services.ErrInvalidWalletID.Error())
+	})
+
+	t.Run("same wallet", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletTransferer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		id := uuid.New()
+
+		mockService.
+			EXPECT().
+			Transfer(gomock.Any(), id, id, int64(10), nil).
+			Return(nil, services.ErrSameWallet)
+
+		handler := New(logger, mockService)
+
+		body := fmt.Sprintf(`{"from_wallet_id":"%s","to_wallet_id":"%s","amount":10}`, id, id)
+		req := httptest.NewRequest(http.MethodPost, "/wallets/transfers", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("wallet not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWalletTransferer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		fromID := uuid.New()
+		toID := uuid.New()
+
+		mockService.
+			EXPECT().
+			Transfer(gomock.Any(), fromID, toID, int64(10), nil).
+			Return(nil, storage.ErrWalletNotFound)
+
+		handler := New(logger, mockService)
+
+		body := fmt.Sprintf(`{"from_wallet_id":"%s","to_wallet_id":"%s","amount":10}`, fromID, toID)
+		req := httptest.NewRequest(http.MethodPost, "/wallets/transfers", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,65 @@
+package deliveries
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type DeliveryLister interface {
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*models.WebhookDelivery, error)
+}
+
+type response struct {
+	Status     string                    `json:"status"`
+	Deliveries []*models.WebhookDelivery `json:"deliveries"`
+	Error      string                    `json:"error,omitempty"`
+}
+
+// New returns the delivery log of a subscription, newest first.
+func New(log *slog.Logger, dl DeliveryLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := helpers.ReadUUIDParam(r, "WEBHOOK_UUID")
+		if err != nil || id == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: webhook_id"))
+			return
+		}
+
+		limit, err := helpers.ReadInt(r.URL.Query(), "limit", 0)
+		if err != nil || limit < 0 {
+			log.Error("failed to validate request")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: limit"))
+			return
+		}
+
+		deliveries, err := dl.ListDeliveries(r.Context(), id, limit)
+		if err != nil {
+			log.Error(err.Error())
+
+			if errors.Is(err, storage.ErrWebhookNotFound) {
+				handlers.ErrorResponse(w, r, http.StatusNotFound, storage.ErrWebhookNotFound.Error())
+				return
+			}
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusOK,
+			helpers.Envelope{"data": response{Deliveries: deliveries, Status: "success"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
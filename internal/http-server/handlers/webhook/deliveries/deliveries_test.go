@@ -0,0 +1,93 @@
+package deliveries
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/webhook/deliveries/mocks"
+	"wallet-service/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(id uuid.UUID, query string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/webhooks/"+id.String()+"/deliveries"+query, nil)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("WEBHOOK_UUID", id.String())
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestDeliveriesHandler(t *testing.T) {
+	t.Run("log", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockDeliveryLister(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		id := uuid.New()
+		statusCode := http.StatusServiceUnavailable
+
+		mockService.
+			EXPECT().
+			ListDeliveries(gomock.Any(), id, 10).
+			Return([]*models.WebhookDelivery{{
+				ID:             uuid.New(),
+				SubscriptionID: id,
+				Status:         models.DeliveryPending,
+				Attempts:       2,
+				LastStatusCode: &statusCode,
+			}}, nil)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(id, "?limit=10"))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `"last_status_code":503`)
+	})
+
+	t.Run("unknown webhook", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockDeliveryLister(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		id := uuid.New()
+
+		mockService.
+			EXPECT().
+			ListDeliveries(gomock.Any(), id, 0).
+			Return(nil, storage.ErrWebhookNotFound)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(id, ""))
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := New(slog.New(slog.NewTextHandler(io.Discard, nil)), mocks.NewMockDeliveryLister(ctrl))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(uuid.New(), "?limit=-1"))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
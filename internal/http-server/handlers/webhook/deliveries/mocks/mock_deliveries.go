@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/webhook/deliveries/deliveries.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/webhook/deliveries/deliveries.go -destination=internal/http-server/handlers/webhook/deliveries/mocks/mock_deliveries.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeliveryLister is a mock of DeliveryLister interface.
+type MockDeliveryLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryListerMockRecorder
+	isgomock struct{}
+}
+
+// MockDeliveryListerMockRecorder is the mock recorder for MockDeliveryLister.
+type MockDeliveryListerMockRecorder struct {
+	mock *MockDeliveryLister
+}
+
+// NewMockDeliveryLister creates a new mock instance.
+func NewMockDeliveryLister(ctrl *gomock.Controller) *MockDeliveryLister {
+	mock := &MockDeliveryLister{ctrl: ctrl}
+	mock.recorder = &MockDeliveryListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryLister) EXPECT() *MockDeliveryListerMockRecorder {
+	return m.recorder
+}
+
+// ListDeliveries mocks base method.
+func (m *MockDeliveryLister) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, subscriptionID, limit)
+	ret0, _ := ret[0].([]*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockDeliveryListerMockRecorder) ListDeliveries(ctx, subscriptionID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockDeliveryLister)(nil).ListDeliveries), ctx, subscriptionID, limit)
+}
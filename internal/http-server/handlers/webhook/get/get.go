@@ -0,0 +1,57 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type WebhookGetter interface {
+	GetSubscription(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error)
+}
+
+type response struct {
+	Status  string                      `json:"status"`
+	Webhook *models.WebhookSubscription `json:"webhook,omitempty"`
+	Error   string                      `json:"error,omitempty"`
+}
+
+func New(log *slog.Logger, wg WebhookGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := helpers.ReadUUIDParam(r, "WEBHOOK_UUID")
+		if err != nil || id == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: webhook_id"))
+			return
+		}
+
+		webhook, err := wg.GetSubscription(r.Context(), id)
+		if err != nil {
+			log.Error(err.Error())
+
+			if errors.Is(err, storage.ErrWebhookNotFound) {
+				handlers.ErrorResponse(w, r, http.StatusNotFound, storage.ErrWebhookNotFound.Error())
+				return
+			}
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusOK,
+			helpers.Envelope{"data": response{Webhook: webhook, Status: "success"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package get
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/webhook/get/mocks"
+	"wallet-service/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(id string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/webhooks/"+id, nil)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("WEBHOOK_UUID", id)
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestGetHandler(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWebhookGetter(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		id := uuid.New()
+
+		mockService.
+			EXPECT().
+			GetSubscription(gomock.Any(), id).
+			Return(&models.WebhookSubscription{ID: id, Active: true}, nil)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(id.String()))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Contains(t, w.Body.String(), `"active":true`)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWebhookGetter(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		id := uuid.New()
+
+		mockService.
+			EXPECT().
+			GetSubscription(gomock.Any(), id).
+			Return(nil, storage.ErrWebhookNotFound)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(id.String()))
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := New(slog.New(slog.NewTextHandler(io.Discard, nil)), mocks.NewMockWebhookGetter(ctrl))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest("not-a-uuid"))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/webhook/get/get.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/webhook/get/get.go -destination=internal/http-server/handlers/webhook/get/mocks/mock_get.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookGetter is a mock of WebhookGetter interface.
+type MockWebhookGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookGetterMockRecorder is the mock recorder for MockWebhookGetter.
+type MockWebhookGetterMockRecorder struct {
+	mock *MockWebhookGetter
+}
+
+// NewMockWebhookGetter creates a new mock instance.
+func NewMockWebhookGetter(ctrl *gomock.Controller) *MockWebhookGetter {
+	mock := &MockWebhookGetter{ctrl: ctrl}
+	mock.recorder = &MockWebhookGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookGetter) EXPECT() *MockWebhookGetterMockRecorder {
+	return m.recorder
+}
+
+// GetSubscription mocks base method.
+func (m *MockWebhookGetter) GetSubscription(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscription", ctx, id)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscription indicates an expected call of GetSubscription.
+func (mr *MockWebhookGetterMockRecorder) GetSubscription(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockWebhookGetter)(nil).GetSubscription), ctx, id)
+}
@@ -0,0 +1,41 @@
+package list
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/pkg/helpers"
+)
+
+type WebhookLister interface {
+	ListSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error)
+}
+
+type response struct {
+	Status   string                        `json:"status"`
+	Webhooks []*models.WebhookSubscription `json:"webhooks"`
+	Error    string                        `json:"error,omitempty"`
+}
+
+func New(log *slog.Logger, wl WebhookLister) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		webhooks, err := wl.ListSubscriptions(r.Context())
+		if err != nil {
+			log.Error(err.Error())
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusOK,
+			helpers.Envelope{"data": response{Webhooks: webhooks, Status: "success"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package list
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/webhook/list/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockWebhookLister(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	id := uuid.New()
+
+	mockService.
+		EXPECT().
+		ListSubscriptions(gomock.Any()).
+		Return([]*models.WebhookSubscription{{ID: id, URL: "https://partner.example/hooks"}}, nil)
+
+	handler := New(logger, mockService)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/webhooks", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), id.String())
+	require.NotContains(t, w.Body.String(), `"secret"`)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/webhook/list/list.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/webhook/list/list.go -destination=internal/http-server/handlers/webhook/list/mocks/mock_list.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookLister is a mock of WebhookLister interface.
+type MockWebhookLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookListerMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookListerMockRecorder is the mock recorder for MockWebhookLister.
+type MockWebhookListerMockRecorder struct {
+	mock *MockWebhookLister
+}
+
+// NewMockWebhookLister creates a new mock instance.
+func NewMockWebhookLister(ctrl *gomock.Controller) *MockWebhookLister {
+	mock := &MockWebhookLister{ctrl: ctrl}
+	mock.recorder = &MockWebhookListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookLister) EXPECT() *MockWebhookListerMockRecorder {
+	return m.recorder
+}
+
+// ListSubscriptions mocks base method.
+func (m *MockWebhookLister) ListSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptions", ctx)
+	ret0, _ := ret[0].([]*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptions indicates an expected call of ListSubscriptions.
+func (mr *MockWebhookListerMockRecorder) ListSubscriptions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptions", reflect.TypeOf((*MockWebhookLister)(nil).ListSubscriptions), ctx)
+}
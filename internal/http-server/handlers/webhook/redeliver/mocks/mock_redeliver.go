@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/webhook/redeliver/redeliver.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/webhook/redeliver/redeliver.go -destination=internal/http-server/handlers/webhook/redeliver/mocks/mock_redeliver.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRedeliverer is a mock of Redeliverer interface.
+type MockRedeliverer struct {
+	ctrl     *gomock.Controller
+	recorder *MockRedelivererMockRecorder
+	isgomock struct{}
+}
+
+// MockRedelivererMockRecorder is the mock recorder for MockRedeliverer.
+type MockRedelivererMockRecorder struct {
+	mock *MockRedeliverer
+}
+
+// NewMockRedeliverer creates a new mock instance.
+func NewMockRedeliverer(ctrl *gomock.Controller) *MockRedeliverer {
+	mock := &MockRedeliverer{ctrl: ctrl}
+	mock.recorder = &MockRedelivererMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRedeliverer) EXPECT() *MockRedelivererMockRecorder {
+	return m.recorder
+}
+
+// Redeliver mocks base method.
+func (m *MockRedeliverer) Redeliver(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeliver", ctx, subscriptionID, deliveryID)
+	ret0, _ := ret[0].(*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeliver indicates an expected call of Redeliver.
+func (mr *MockRedelivererMockRecorder) Redeliver(ctx, subscriptionID, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeliver", reflect.TypeOf((*MockRedeliverer)(nil).Redeliver), ctx, subscriptionID, deliveryID)
+}
@@ -0,0 +1,66 @@
+package redeliver
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type Redeliverer interface {
+	Redeliver(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*models.WebhookDelivery, error)
+}
+
+type response struct {
+	Status   string                  `json:"status"`
+	Delivery *models.WebhookDelivery `json:"delivery,omitempty"`
+	Error    string                  `json:"error,omitempty"`
+}
+
+// New queues a delivery to be sent again; the delivery worker picks it up
+// on its next poll.
+func New(log *slog.Logger, rd Redeliverer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		webhookID, err := helpers.ReadUUIDParam(r, "WEBHOOK_UUID")
+		if err != nil || webhookID == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: webhook_id"))
+			return
+		}
+
+		deliveryID, err := helpers.ReadUUIDParam(r, "DELIVERY_UUID")
+		if err != nil || deliveryID == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: delivery_id"))
+			return
+		}
+
+		delivery, err := rd.Redeliver(r.Context(), webhookID, deliveryID)
+		if err != nil {
+			log.Error(err.Error())
+
+			if errors.Is(err, storage.ErrDeliveryNotFound) {
+				handlers.ErrorResponse(w, r, http.StatusNotFound, storage.ErrDeliveryNotFound.Error())
+				return
+			}
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusAccepted,
+			helpers.Envelope{"data": response{Delivery: delivery, Status: "delivery was queued"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package redeliver
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/webhook/redeliver/mocks"
+	"wallet-service/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(webhookID, deliveryID uuid.UUID) *http.Request {
+	req := httptest.NewRequest(http.MethodPost,
+		"/webhooks/"+webhookID.String()+"/deliveries/"+deliveryID.String()+"/redeliver", nil)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("WEBHOOK_UUID", webhookID.String())
+	rctx.URLParams.Add("DELIVERY_UUID", deliveryID.String())
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestRedeliverHandler(t *testing.T) {
+	t.Run("queued", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockRedeliverer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		webhookID, deliveryID := uuid.New(), uuid.New()
+
+		mockService.
+			EXPECT().
+			Redeliver(gomock.Any(), webhookID, deliveryID).
+			Return(&models.WebhookDelivery{ID: deliveryID, Status: models.DeliveryPending}, nil)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(webhookID, deliveryID))
+
+		require.Equal(t, http.StatusAccepted, w.Code)
+		require.Contains(t, w.Body.String(), `"status":"PENDING"`)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockRedeliverer(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		webhookID, deliveryID := uuid.New(), uuid.New()
+
+		mockService.
+			EXPECT().
+			Redeliver(gomock.Any(), webhookID, deliveryID).
+			Return(nil, storage.ErrDeliveryNotFound)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(webhookID, deliveryID))
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
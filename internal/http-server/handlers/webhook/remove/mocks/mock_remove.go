@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/webhook/remove/remove.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/webhook/remove/remove.go -destination=internal/http-server/handlers/webhook/remove/mocks/mock_remove.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookRemover is a mock of WebhookRemover interface.
+type MockWebhookRemover struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRemoverMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookRemoverMockRecorder is the mock recorder for MockWebhookRemover.
+type MockWebhookRemoverMockRecorder struct {
+	mock *MockWebhookRemover
+}
+
+// NewMockWebhookRemover creates a new mock instance.
+func NewMockWebhookRemover(ctrl *gomock.Controller) *MockWebhookRemover {
+	mock := &MockWebhookRemover{ctrl: ctrl}
+	mock.recorder = &MockWebhookRemoverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRemover) EXPECT() *MockWebhookRemoverMockRecorder {
+	return m.recorder
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockWebhookRemover) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockWebhookRemoverMockRecorder) DeleteSubscription(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockWebhookRemover)(nil).DeleteSubscription), ctx, id)
+}
@@ -0,0 +1,55 @@
+package remove
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type WebhookRemover interface {
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+}
+
+type response struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// New deletes a subscription together with its delivery log.
+func New(log *slog.Logger, wr WebhookRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := helpers.ReadUUIDParam(r, "WEBHOOK_UUID")
+		if err != nil || id == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: webhook_id"))
+			return
+		}
+
+		if err := wr.DeleteSubscription(r.Context(), id); err != nil {
+			log.Error(err.Error())
+
+			if errors.Is(err, storage.ErrWebhookNotFound) {
+				handlers.ErrorResponse(w, r, http.StatusNotFound, storage.ErrWebhookNotFound.Error())
+				return
+			}
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusOK,
+			helpers.Envelope{"data": response{Status: "webhook was deleted"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
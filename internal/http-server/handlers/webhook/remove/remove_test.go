@@ -0,0 +1,61 @@
+package remove
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wallet-service/internal/http-server/handlers/webhook/remove/mocks"
+	"wallet-service/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(id uuid.UUID) *http.Request {
+	req := httptest.NewRequest(http.MethodDelete, "/webhooks/"+id.String(), nil)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("WEBHOOK_UUID", id.String())
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestRemoveHandler(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{name: "deleted", status: http.StatusOK},
+		{name: "not found", err: storage.ErrWebhookNotFound, status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockWebhookRemover(ctrl)
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			id := uuid.New()
+
+			mockService.
+				EXPECT().
+				DeleteSubscription(gomock.Any(), id).
+				Return(tt.err)
+
+			handler := New(logger, mockService)
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, newRequest(id))
+
+			require.Equal(t, tt.status, w.Code)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/webhook/save/save.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/webhook/save/save.go -destination=internal/http-server/handlers/webhook/save/mocks/mock_save.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookCreator is a mock of WebhookCreator interface.
+type MockWebhookCreator struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookCreatorMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookCreatorMockRecorder is the mock recorder for MockWebhookCreator.
+type MockWebhookCreatorMockRecorder struct {
+	mock *MockWebhookCreator
+}
+
+// NewMockWebhookCreator creates a new mock instance.
+func NewMockWebhookCreator(ctrl *gomock.Controller) *MockWebhookCreator {
+	mock := &MockWebhookCreator{ctrl: ctrl}
+	mock.recorder = &MockWebhookCreatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookCreator) EXPECT() *MockWebhookCreatorMockRecorder {
+	return m.recorder
+}
+
+// CreateSubscription mocks base method.
+func (m *MockWebhookCreator) CreateSubscription(ctx context.Context, url string, eventTypes []string, walletIDs []uuid.UUID, secret string) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", ctx, url, eventTypes, walletIDs, secret)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockWebhookCreatorMockRecorder) CreateSubscription(ctx, url, eventTypes, walletIDs, secret any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockWebhookCreator)(nil).CreateSubscription), ctx, url, eventTypes, walletIDs, secret)
+}
@@ -0,0 +1,81 @@
+package save
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type WebhookCreator interface {
+	CreateSubscription(
+		ctx context.Context,
+		url string,
+		eventTypes []string,
+		walletIDs []uuid.UUID,
+		secret string,
+	) (*models.WebhookSubscription, error)
+}
+
+type request struct {
+	URL        string      `json:"url"`
+	EventTypes []string    `json:"event_types"`
+	WalletIDs  []uuid.UUID `json:"wallet_ids"`
+	Secret     string      `json:"secret"`
+}
+
+type response struct {
+	Status  string                      `json:"status"`
+	Webhook *models.WebhookSubscription `json:"webhook,omitempty"`
+	Error   string                      `json:"error,omitempty"`
+}
+
+// New registers a webhook subscription. The response is the only place the
+// signing secret is returned.
+func New(log *slog.Logger, wc WebhookCreator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req request
+
+		err := helpers.ReadJSON(w, r, &req)
+		if err != nil {
+			log.Error("failed to decode request body")
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "ops! decode json")
+			return
+		}
+
+		if req.URL == "" {
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: url"))
+			return
+		}
+
+		webhook, err := wc.CreateSubscription(r.Context(), req.URL, req.EventTypes, req.WalletIDs, req.Secret)
+		if err != nil {
+			log.Error(err.Error())
+
+			switch {
+			case errors.Is(err, services.ErrInvalidWebhookURL),
+				errors.Is(err, services.ErrInvalidEventType):
+				handlers.BadRequestResponse(w, r, err)
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusCreated,
+			helpers.Envelope{"data": response{Webhook: webhook, Status: "webhook was created"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package save
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/webhook/save/mocks"
+	"wallet-service/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSaveHandler(t *testing.T) {
+	t.Run("created", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWebhookCreator(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		walletID := uuid.New()
+
+		mockService.
+			EXPECT().
+			CreateSubscription(gomock.Any(), "https://partner.example/hooks",
+				[]string{models.EventBalanceChanged}, []uuid.UUID{walletID}, "").
+			Return(&models.WebhookSubscription{ID: uuid.New(), Secret: "generated"}, nil)
+
+		handler := New(logger, mockService)
+
+		body := `{"url":"https://partner.example/hooks","event_types":["wallet.balance_changed"],` +
+			`"wallet_ids":["` + walletID.String() + `"]}`
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body)))
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		require.Contains(t, w.Body.String(), `"secret":"generated"`)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWebhookCreator(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		mockService.
+			EXPECT().
+			CreateSubscription(gomock.Any(), "ftp://partner.example", gomock.Any(), gomock.Any(), "").
+			Return(nil, services.ErrInvalidWebhookURL)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhooks",
+			strings.NewReader(`{"url":"ftp://partner.example"}`)))
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
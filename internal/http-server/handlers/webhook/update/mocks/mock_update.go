@@ -0,0 +1,58 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/http-server/handlers/webhook/update/update.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/http-server/handlers/webhook/update/update.go -destination=internal/http-server/handlers/webhook/update/mocks/mock_update.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWebhookUpdater is a mock of WebhookUpdater interface.
+type MockWebhookUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookUpdaterMockRecorder
+	isgomock struct{}
+}
+
+// MockWebhookUpdaterMockRecorder is the mock recorder for MockWebhookUpdater.
+type MockWebhookUpdaterMockRecorder struct {
+	mock *MockWebhookUpdater
+}
+
+// NewMockWebhookUpdater creates a new mock instance.
+func NewMockWebhookUpdater(ctrl *gomock.Controller) *MockWebhookUpdater {
+	mock := &MockWebhookUpdater{ctrl: ctrl}
+	mock.recorder = &MockWebhookUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookUpdater) EXPECT() *MockWebhookUpdaterMockRecorder {
+	return m.recorder
+}
+
+// UpdateSubscription mocks base method.
+func (m *MockWebhookUpdater) UpdateSubscription(ctx context.Context, id uuid.UUID, update *models.WebhookSubscriptionUpdate) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscription", ctx, id, update)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubscription indicates an expected call of UpdateSubscription.
+func (mr *MockWebhookUpdaterMockRecorder) UpdateSubscription(ctx, id, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockWebhookUpdater)(nil).UpdateSubscription), ctx, id, update)
+}
@@ -0,0 +1,76 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	"wallet-service/pkg/helpers"
+
+	"github.com/google/uuid"
+)
+
+type WebhookUpdater interface {
+	UpdateSubscription(
+		ctx context.Context,
+		id uuid.UUID,
+		update *models.WebhookSubscriptionUpdate,
+	) (*models.WebhookSubscription, error)
+}
+
+type response struct {
+	Status  string                      `json:"status"`
+	Webhook *models.WebhookSubscription `json:"webhook,omitempty"`
+	Error   string                      `json:"error,omitempty"`
+}
+
+// New changes the fields present in the body; omitted fields keep their value.
+func New(log *slog.Logger, wu WebhookUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := helpers.ReadUUIDParam(r, "WEBHOOK_UUID")
+		if err != nil || id == uuid.Nil {
+			log.Error("failed to decode request param")
+			handlers.BadRequestResponse(w, r, errors.New("error invalid argument: webhook_id"))
+			return
+		}
+
+		var req models.WebhookSubscriptionUpdate
+
+		err = helpers.ReadJSON(w, r, &req)
+		if err != nil {
+			log.Error("failed to decode request body")
+			handlers.ErrorResponse(w, r, http.StatusInternalServerError, "ops! decode json")
+			return
+		}
+
+		webhook, err := wu.UpdateSubscription(r.Context(), id, &req)
+		if err != nil {
+			log.Error(err.Error())
+
+			switch {
+			case errors.Is(err, services.ErrInvalidWebhookURL),
+				errors.Is(err, services.ErrInvalidEventType):
+				handlers.BadRequestResponse(w, r, err)
+			case errors.Is(err, storage.ErrWebhookNotFound):
+				handlers.ErrorResponse(w, r, http.StatusNotFound, storage.ErrWebhookNotFound.Error())
+			default:
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		err = helpers.WriteJSON(
+			w,
+			http.StatusOK,
+			helpers.Envelope{"data": response{Webhook: webhook, Status: "webhook was updated"}},
+			nil)
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+	}
+}
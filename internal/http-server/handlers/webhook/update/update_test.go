@@ -0,0 +1,82 @@
+package update
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers/webhook/update/mocks"
+	"wallet-service/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(id uuid.UUID, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPatch, "/webhooks/"+id.String(), strings.NewReader(body))
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("WEBHOOK_UUID", id.String())
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestUpdateHandler(t *testing.T) {
+	t.Run("deactivate", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWebhookUpdater(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		id := uuid.New()
+
+		mockService.
+			EXPECT().
+			UpdateSubscription(gomock.Any(), id, gomock.Any()).
+			DoAndReturn(func(
+				_ context.Context,
+				_ uuid.UUID,
+				update *models.WebhookSubscriptionUpdate,
+			) (*models.WebhookSubscription, error) {
+				require.False(t, *update.Active)
+				require.Nil(t, update.URL)
+				return &models.WebhookSubscription{ID: id}, nil
+			})
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(id, `{"active":false}`))
+
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockService := mocks.NewMockWebhookUpdater(ctrl)
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		id := uuid.New()
+
+		mockService.
+			EXPECT().
+			UpdateSubscription(gomock.Any(), id, gomock.Any()).
+			Return(nil, storage.ErrWebhookNotFound)
+
+		handler := New(logger, mockService)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, newRequest(id, `{"active":true}`))
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
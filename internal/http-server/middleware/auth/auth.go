@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/handlers"
+	"wallet-service/internal/services"
+)
+
+// APIKeyHeader carries the key; "Authorization: Bearer <key>" works too.
+const APIKeyHeader = "X-API-Key"
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, secret string) (*models.Principal, error)
+}
+
+type principalKey struct{}
+
+// slot lets middleware that runs before authentication, like the request
+// logger, see the principal once the request has been served.
+type slot struct {
+	principal *models.Principal
+}
+
+type slotKey struct{}
+
+// WithPrincipalSlot returns a context in which the principal authenticated
+// further down the chain is recorded, and a function returning it.
+func WithPrincipalSlot(ctx context.Context) (context.Context, func() *models.Principal) {
+	s := &slot{}
+
+	return context.WithValue(ctx, slotKey{}, s), func() *models.Principal { return s.principal }
+}
+
+// PrincipalFromContext returns the principal of an authenticated request.
+func PrincipalFromContext(ctx context.Context) (*models.Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*models.Principal)
+
+	return principal, ok
+}
+
+// WithPrincipal returns a context carrying principal and records it in the
+// slot of WithPrincipalSlot, if any. The gRPC auth interceptor uses it too,
+// so PrincipalFromContext works for both transports.
+func WithPrincipal(ctx context.Context, principal *models.Principal) context.Context {
+	if s, ok := ctx.Value(slotKey{}).(*slot); ok {
+		s.principal = principal
+	}
+
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// NewAuthMiddleware rejects requests without a valid API key with 401 and
+// puts the principal of the others in the request context.
+func NewAuthMiddleware(log *slog.Logger, a Authenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log := log.With(
+			slog.String("component", "middleware/auth"),
+		)
+		log.Info("auth middleware enabled")
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			secret := apiKey(r)
+			if secret == "" {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="wallet-service"`)
+				handlers.ErrorResponse(w, r, http.StatusUnauthorized, "missing api key")
+				return
+			}
+
+			principal, err := a.Authenticate(r.Context(), secret)
+			if err != nil {
+				if errors.Is(err, services.ErrInvalidAPIKey) {
+					w.Header().Set("WWW-Authenticate", `Bearer realm="wallet-service", error="invalid_token"`)
+					handlers.ErrorResponse(w, r, http.StatusUnauthorized, services.ErrInvalidAPIKey.Error())
+					return
+				}
+
+				log.Error(err.Error())
+				handlers.ErrorResponse(w, r, http.StatusInternalServerError, "internal server error")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// RequireScope answers 403 to principals without scope. It has to run after
+// NewAuthMiddleware.
+func RequireScope(scope models.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := PrincipalFromContext(r.Context())
+			if !ok || !principal.HasScope(scope) {
+				handlers.ErrorResponse(w, r, http.StatusForbidden, "api key lacks scope "+string(scope))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func apiKey(r *http.Request) string {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return key
+	}
+
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if ok && strings.EqualFold(scheme, "Bearer") {
+		return strings.TrimSpace(token)
+	}
+
+	return ""
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/http-server/middleware/auth/mocks"
+	"wallet-service/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	writer := &models.Principal{KeyID: uuid.New(), Name: "billing", Scopes: []models.Scope{models.ScopeWalletsWrite}}
+
+	tests := []struct {
+		name       string
+		header     string
+		value      string
+		secret     string
+		principal  *models.Principal
+		authErr    error
+		scope      models.Scope
+		wantStatus int
+	}{
+		{name: "missing key", scope: models.ScopeWalletsRead, wantStatus: http.StatusUnauthorized},
+		{
+			name:       "invalid key",
+			header:     APIKeyHeader,
+			value:      "wsk_unknown",
+			secret:     "wsk_unknown",
+			authErr:    services.ErrInvalidAPIKey,
+			scope:      models.ScopeWalletsRead,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "store failure",
+			header:     APIKeyHeader,
+			value:      "wsk_valid",
+			secret:     "wsk_valid",
+			authErr:    errors.New("connection refused"),
+			scope:      models.ScopeWalletsRead,
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "bearer key with scope",
+			header:     "Authorization",
+			value:      "Bearer wsk_valid",
+			secret:     "wsk_valid",
+			principal:  writer,
+			scope:      models.ScopeWalletsWrite,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "key without scope",
+			header:     APIKeyHeader,
+			value:      "wsk_valid",
+			secret:     "wsk_valid",
+			principal:  writer,
+			scope:      models.ScopeAdmin,
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAuth := mocks.NewMockAuthenticator(ctrl)
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			if tt.principal != nil || tt.authErr != nil {
+				mockAuth.
+					EXPECT().
+					Authenticate(gomock.Any(), tt.secret).
+					Return(tt.principal, tt.authErr).
+					Times(1)
+			}
+
+			var seen *models.Principal
+			handler := NewAuthMiddleware(logger, mockAuth)(RequireScope(tt.scope)(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					seen, _ = PrincipalFromContext(r.Context())
+				}),
+			))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/wallets", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			ctx, recorded := WithPrincipalSlot(req.Context())
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req.WithContext(ctx))
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				require.Equal(t, tt.principal, seen)
+			}
+			if tt.principal != nil {
+				require.Equal(t, tt.principal, recorded())
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"net/http"
 	"time"
+	"wallet-service/internal/http-server/middleware/auth"
 
 	"github.com/go-chi/chi/v5/middleware"
 )
@@ -24,17 +25,25 @@ func NewLoggerMiddleware(log *slog.Logger) func(http.Handler) http.Handler {
 				slog.String("request_id", middleware.GetReqID(r.Context())),
 			)
 			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			ctx, principal := auth.WithPrincipalSlot(r.Context())
 
 			t1 := time.Now()
 			defer func() {
-				entry.Info("request completed",
+				attrs := []any{
 					slog.Int("status", ww.Status()),
 					slog.Int("bytes", ww.BytesWritten()),
 					slog.String("duration", time.Since(t1).String()),
-				)
+				}
+				if p := principal(); p != nil {
+					attrs = append(attrs,
+						slog.String("principal", p.Name),
+						slog.String("api_key_id", p.KeyID.String()),
+					)
+				}
+				entry.Info("request completed", attrs...)
 			}()
 
-			next.ServeHTTP(ww, r)
+			next.ServeHTTP(ww, r.WithContext(ctx))
 		}
 
 		return http.HandlerFunc(fn)
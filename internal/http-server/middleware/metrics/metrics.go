@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+	libmetrics "wallet-service/internal/lib/metrics"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// unmatchedRoute labels requests that matched no route, so probing random
+// paths does not create a series per path.
+const unmatchedRoute = "unmatched"
+
+// NewMetricsMiddleware counts requests and observes their latency by method,
+// route pattern and status code.
+func NewMetricsMiddleware(log *slog.Logger, m *libmetrics.Metrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log.With(
+			slog.String("component", "middleware/metrics"),
+		).Info("metrics middleware enabled")
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			t1 := time.Now()
+			defer func() {
+				route := unmatchedRoute
+				if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+					route = rctx.RoutePattern()
+				}
+
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+
+				m.ObserveRequest(r.Method, route, status, time.Since(t1))
+			}()
+
+			next.ServeHTTP(ww, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	libmetrics "wallet-service/internal/lib/metrics"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsMiddleware(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	m := libmetrics.New()
+
+	router := chi.NewRouter()
+	router.Use(NewMetricsMiddleware(logger, m))
+	router.Route("/api/v1", func(r chi.Router) {
+		r.Get("/wallets/{WALLET_UUID}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+		r.Get("/wallets/{WALLET_UUID}/operations", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("[]"))
+		})
+	})
+
+	for _, path := range []string{
+		"/api/v1/wallets/0b7e2f6e-4b1e-4c55-9a39-6f0f1ac2a001",
+		"/api/v1/wallets/0b7e2f6e-4b1e-4c55-9a39-6f0f1ac2a002",
+		"/api/v1/wallets/0b7e2f6e-4b1e-4c55-9a39-6f0f1ac2a001/operations",
+		"/favicon.ico",
+	} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	require.Equal(t, 2.0, testutil.ToFloat64(
+		m.HTTPRequests.WithLabelValues(http.MethodGet, "/api/v1/wallets/{WALLET_UUID}", "404")))
+	require.Equal(t, 1.0, testutil.ToFloat64(
+		m.HTTPRequests.WithLabelValues(http.MethodGet, "/api/v1/wallets/{WALLET_UUID}/operations", "200")))
+	require.Equal(t, 1.0, testutil.ToFloat64(
+		m.HTTPRequests.WithLabelValues(http.MethodGet, unmatchedRoute, "404")))
+	require.Equal(t, 3, testutil.CollectAndCount(m.HTTPRequestDuration))
+}
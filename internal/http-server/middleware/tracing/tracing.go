@@ -0,0 +1,67 @@
+package tracing
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "wallet-service/internal/http-server/middleware/tracing"
+
+// NewTracingMiddleware starts a server span per request, continuing the
+// trace of the caller when the request carries a traceparent header. The
+// span is named after the route pattern once the router has matched it.
+func NewTracingMiddleware(log *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		log.With(
+			slog.String("component", "middleware/tracing"),
+		).Info("tracing middleware enabled")
+
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+			ctx, span := otel.Tracer(tracerName).Start(ctx, r.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(r.Method),
+					semconv.URLPath(r.URL.Path),
+					semconv.UserAgentOriginal(r.UserAgent()),
+				),
+			)
+			defer span.End()
+
+			if reqID := middleware.GetReqID(ctx); reqID != "" {
+				span.SetAttributes(attribute.String("http.request.id", reqID))
+			}
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			defer func() {
+				if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+					span.SetName(r.Method + " " + rctx.RoutePattern())
+					span.SetAttributes(semconv.HTTPRoute(rctx.RoutePattern()))
+				}
+
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+				span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+				if status >= http.StatusInternalServerError {
+					span.SetStatus(codes.Error, http.StatusText(status))
+				}
+			}()
+
+			next.ServeHTTP(ww, r.WithContext(ctx))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
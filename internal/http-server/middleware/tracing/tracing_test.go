@@ -0,0 +1,59 @@
+package tracing
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestTracingMiddleware(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	var handlerSpan trace.SpanContext
+	router := chi.NewRouter()
+	router.Use(NewTracingMiddleware(logger))
+	router.Route("/api/v1", func(r chi.Router) {
+		r.Get("/wallets/{WALLET_UUID}", func(w http.ResponseWriter, r *http.Request) {
+			handlerSpan = trace.SpanContextFromContext(r.Context())
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/wallets/0b7e2f6e-4b1e-4c55-9a39-6f0f1ac2a001", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	span := spans[0]
+	require.Equal(t, "GET /api/v1/wallets/{WALLET_UUID}", span.Name())
+	require.Equal(t, trace.SpanKindServer, span.SpanKind())
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+	require.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+	require.Equal(t, span.SpanContext(), handlerSpan)
+	require.Equal(t, codes.Error, span.Status().Code)
+	require.Contains(t, span.Attributes(), attribute.String("http.route", "/api/v1/wallets/{WALLET_UUID}"))
+	require.Contains(t, span.Attributes(), attribute.Int("http.response.status_code", http.StatusInternalServerError))
+}
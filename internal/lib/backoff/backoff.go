@@ -0,0 +1,15 @@
+// Package backoff computes retry delays.
+package backoff
+
+import "time"
+
+// Exponential returns the delay before the next attempt after attempts
+// failures: base doubled per failure after the first, capped at max.
+func Exponential(base, max time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+
+	return min(delay, max)
+}
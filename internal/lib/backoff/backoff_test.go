@@ -0,0 +1,25 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExponential(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 5, want: 10 * time.Second},
+		{attempts: 1000, want: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, Exponential(time.Second, 10*time.Second, tt.attempts))
+	}
+}
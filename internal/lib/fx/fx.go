@@ -0,0 +1,73 @@
+// Package fx provides exchange rate sources for currency conversion.
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+)
+
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// StaticProvider serves a fixed table of rates. A rate for FROM/TO is the
+// price of one major unit of FROM in major units of TO; the inverse pair is
+// derived when it is not listed explicitly.
+type StaticProvider struct {
+	rates map[string]*big.Rat
+}
+
+// NewStaticProvider builds a provider from rates keyed "FROM/TO", e.g.
+// {"EUR/USD": "1.0825"}.
+func NewStaticProvider(rates map[string]string) (*StaticProvider, error) {
+	const op = "fx.NewStaticProvider"
+
+	provider := &StaticProvider{rates: make(map[string]*big.Rat, len(rates))}
+	for pair, value := range rates {
+		from, to, ok := strings.Cut(pair, "/")
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("%s: invalid currency pair %q", op, pair)
+		}
+
+		rate, ok := new(big.Rat).SetString(value)
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("%s: invalid rate %q for %s", op, value, pair)
+		}
+
+		provider.rates[pair] = rate
+	}
+
+	return provider, nil
+}
+
+// LoadFile reads a JSON object of "FROM/TO": "rate" pairs.
+func LoadFile(path string) (*StaticProvider, error) {
+	const op = "fx.LoadFile"
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var rates map[string]string
+	if err := json.Unmarshal(data, &rates); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return NewStaticProvider(rates)
+}
+
+func (p *StaticProvider) Rate(_ context.Context, from, to string) (*big.Rat, error) {
+	if rate, ok := p.rates[from+"/"+to]; ok {
+		return new(big.Rat).Set(rate), nil
+	}
+
+	if rate, ok := p.rates[to+"/"+from]; ok {
+		return new(big.Rat).Inv(rate), nil
+	}
+
+	return nil, ErrRateNotFound
+}
@@ -0,0 +1,53 @@
+package fx
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticProvider_Rate(t *testing.T) {
+	provider, err := NewStaticProvider(map[string]string{"EUR/USD": "1.25"})
+	require.NoError(t, err)
+
+	t.Run("direct pair", func(t *testing.T) {
+		rate, err := provider.Rate(context.Background(), "EUR", "USD")
+		require.NoError(t, err)
+		require.Zero(t, rate.Cmp(big.NewRat(5, 4)))
+	})
+
+	t.Run("inverse pair", func(t *testing.T) {
+		rate, err := provider.Rate(context.Background(), "USD", "EUR")
+		require.NoError(t, err)
+		require.Zero(t, rate.Cmp(big.NewRat(4, 5)))
+	})
+
+	t.Run("unknown pair", func(t *testing.T) {
+		_, err := provider.Rate(context.Background(), "USD", "JPY")
+		require.ErrorIs(t, err, ErrRateNotFound)
+	})
+}
+
+func TestNewStaticProvider_Invalid(t *testing.T) {
+	_, err := NewStaticProvider(map[string]string{"EURUSD": "1.1"})
+	require.Error(t, err)
+
+	_, err = NewStaticProvider(map[string]string{"EUR/USD": "-1"})
+	require.Error(t, err)
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"GBP/USD": "1.27"}`), 0o600))
+
+	provider, err := LoadFile(path)
+	require.NoError(t, err)
+
+	rate, err := provider.Rate(context.Background(), "GBP", "USD")
+	require.NoError(t, err)
+	require.Equal(t, "1.27", rate.FloatString(2))
+}
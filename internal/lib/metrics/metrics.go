@@ -0,0 +1,143 @@
+// Package metrics holds the Prometheus metrics of the service. Every metric
+// is registered on the registry of a Metrics value rather than the global
+// one, so tests can build their own and assert on it. A nil *Metrics records
+// nothing.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "wallet"
+
+// Outcomes of a deposit or withdrawal.
+const (
+	OutcomeSuccess           = "success"
+	OutcomeReplayed          = "replayed"
+	OutcomeInsufficientFunds = "insufficient_funds"
+	OutcomeLimitExceeded     = "limit_exceeded"
+	OutcomeWalletNotFound    = "wallet_not_found"
+	OutcomeWalletInactive    = "wallet_inactive"
+	OutcomeRejected          = "rejected"
+	OutcomeError             = "error"
+)
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	HTTPRequests        *prometheus.CounterVec
+	HTTPRequestDuration *prometheus.HistogramVec
+
+	Operations       *prometheus.CounterVec
+	OperationAmounts *prometheus.CounterVec
+
+	TransactionRetries *prometheus.CounterVec
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		HTTPRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by method, route pattern and status code.",
+		}, []string{"method", "route", "status"}),
+
+		HTTPRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by method, route pattern and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+
+		Operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "operations_total",
+			Help:      "Deposits and withdrawals by type and outcome.",
+		}, []string{"type", "outcome"}),
+
+		OperationAmounts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "operation_amount_total",
+			Help:      "Applied amounts of deposits and withdrawals in minor units, by type, outcome and currency.",
+		}, []string{"type", "outcome", "currency"}),
+
+		TransactionRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "transaction_retries_total",
+			Help:      "Database transactions retried after a serialization failure, deadlock or lost connection.",
+		}, []string{"transaction"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.HTTPRequests,
+		m.HTTPRequestDuration,
+		m.Operations,
+		m.OperationAmounts,
+		m.TransactionRetries,
+	)
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterPool exposes the connection statistics of a pool.
+func (m *Metrics) RegisterPool(pool PoolStater) {
+	m.registry.MustRegister(newPoolCollector(pool))
+}
+
+func (m *Metrics) ObserveRequest(method, route string, status int, duration time.Duration) {
+	if m == nil {
+		return
+	}
+
+	code := strconv.Itoa(status)
+	m.HTTPRequests.WithLabelValues(method, route, code).Inc()
+	m.HTTPRequestDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// ObserveOperation counts an operation and, when currency is known, its
+// amount. Minor units of different currencies do not add up, so an amount
+// without a currency is not counted.
+func (m *Metrics) ObserveOperation(opType, outcome, currency string, amount int64) {
+	if m == nil {
+		return
+	}
+
+	m.Operations.WithLabelValues(opType, outcome).Inc()
+
+	// Rejected requests may carry a negative amount, which a counter refuses.
+	if currency != "" && amount > 0 {
+		m.OperationAmounts.WithLabelValues(opType, outcome, currency).Add(float64(amount))
+	}
+}
+
+func (m *Metrics) TransactionRetried(transaction string) {
+	if m == nil {
+		return
+	}
+
+	m.TransactionRetries.WithLabelValues(transaction).Inc()
+}
+
+// PoolStater is implemented by *pgxpool.Pool and *pgxdriver.Postgres.
+type PoolStater interface {
+	Stat() *pgxpool.Stat
+}
@@ -0,0 +1,116 @@
+// Package publisher provides EventPublisher implementations for the outbox
+// relay that need no broker: newline-delimited JSON written to stdout or a
+// file, an in-memory publisher for tests, and a fan-out to several of them.
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"wallet-service/internal/domain/models"
+)
+
+type Publisher interface {
+	Publish(ctx context.Context, event *models.OutboxEvent) error
+}
+
+// MultiPublisher hands each event to several publishers. When any of them
+// fails the event is retried for all, so each must tolerate duplicates.
+type MultiPublisher struct {
+	publishers []Publisher
+}
+
+func NewMultiPublisher(publishers ...Publisher) *MultiPublisher {
+	return &MultiPublisher{publishers: publishers}
+}
+
+func (p *MultiPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	var errs []error
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// WriterPublisher writes each event as one JSON line.
+type WriterPublisher struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	closer io.Closer
+}
+
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{enc: json.NewEncoder(w)}
+}
+
+// NewFilePublisher appends events to the file at path, creating it if needed.
+func NewFilePublisher(path string) (*WriterPublisher, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WriterPublisher{enc: json.NewEncoder(file), closer: file}, nil
+}
+
+func (p *WriterPublisher) Publish(_ context.Context, event *models.OutboxEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.enc.Encode(event)
+}
+
+// Close closes the underlying file, if the publisher owns one.
+func (p *WriterPublisher) Close() error {
+	if p.closer == nil {
+		return nil
+	}
+
+	return p.closer.Close()
+}
+
+// MemoryPublisher keeps published events in memory.
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []*models.OutboxEvent
+	err    error
+}
+
+func NewMemoryPublisher() *MemoryPublisher {
+	return &MemoryPublisher{}
+}
+
+func (p *MemoryPublisher) Publish(_ context.Context, event *models.OutboxEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.err != nil {
+		return p.err
+	}
+
+	p.events = append(p.events, event)
+	return nil
+}
+
+// FailWith makes subsequent Publish calls return err; nil restores success.
+func (p *MemoryPublisher) FailWith(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.err = err
+}
+
+// Events returns the events published so far, in order.
+func (p *MemoryPublisher) Events() []*models.OutboxEvent {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return slices.Clone(p.events)
+}
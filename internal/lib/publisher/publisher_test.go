@@ -0,0 +1,45 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"wallet-service/internal/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterPublisher_WritesJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	pub := NewWriterPublisher(&buf)
+
+	first := &models.OutboxEvent{ID: uuid.New(), EventType: models.EventBalanceChanged, Payload: []byte(`{"amount":10}`)}
+	second := &models.OutboxEvent{ID: uuid.New(), EventType: models.EventBalanceChanged, Payload: []byte(`{"amount":20}`)}
+
+	require.NoError(t, pub.Publish(context.Background(), first))
+	require.NoError(t, pub.Publish(context.Background(), second))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var decoded models.OutboxEvent
+	require.NoError(t, json.Unmarshal(lines[1], &decoded))
+	require.Equal(t, second.ID, decoded.ID)
+	require.JSONEq(t, `{"amount":20}`, string(decoded.Payload))
+}
+
+func TestMultiPublisher_PublishesToAll(t *testing.T) {
+	healthy := NewMemoryPublisher()
+	failing := NewMemoryPublisher()
+	failing.FailWith(errors.New("down"))
+
+	event := &models.OutboxEvent{ID: uuid.New()}
+
+	err := NewMultiPublisher(failing, healthy).Publish(context.Background(), event)
+
+	require.Error(t, err)
+	require.Equal(t, []*models.OutboxEvent{event}, healthy.Events())
+}
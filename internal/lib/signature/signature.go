@@ -0,0 +1,70 @@
+// Package signature signs webhook payloads with HMAC-SHA256.
+//
+// The signed message is "<unix timestamp>.<body>", sent as
+//
+//	X-Webhook-Timestamp: 1735689600
+//	X-Webhook-Signature: sha256=<hex digest>
+//
+// Binding the timestamp into the digest lets receivers reject replays of an
+// old, validly signed request.
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TimestampHeader = "X-Webhook-Timestamp"
+	SignatureHeader = "X-Webhook-Signature"
+
+	prefix = "sha256="
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrStaleTimestamp   = errors.New("webhook timestamp outside tolerance")
+)
+
+// Sign returns the X-Webhook-Signature value for body sent at timestamp.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	return prefix + hex.EncodeToString(digest(secret, timestamp.Unix(), body))
+}
+
+// Verify checks the signature and timestamp headers of a received webhook.
+// A timestamp further than tolerance from now is rejected.
+func Verify(secret, signature, timestamp string, body []byte, now time.Time, tolerance time.Duration) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if age := now.Sub(time.Unix(unix, 0)); age > tolerance || age < -tolerance {
+		return ErrStaleTimestamp
+	}
+
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, prefix))
+	if err != nil || !strings.HasPrefix(signature, prefix) {
+		return ErrInvalidSignature
+	}
+
+	if !hmac.Equal(got, digest(secret, unix, body)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func digest(secret string, unix int64, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(unix, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return mac.Sum(nil)
+}
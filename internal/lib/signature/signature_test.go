@@ -0,0 +1,42 @@
+package signature
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	now := time.Unix(1735689600, 0)
+	body := []byte(`{"id":"1"}`)
+	sig := Sign("s3cret", now, body)
+	ts := strconv.FormatInt(now.Unix(), 10)
+
+	tests := []struct {
+		name   string
+		secret string
+		sig    string
+		body   []byte
+		now    time.Time
+		err    error
+	}{
+		{name: "valid", secret: "s3cret", sig: sig, body: body, now: now},
+		{name: "wrong secret", secret: "other", sig: sig, body: body, now: now, err: ErrInvalidSignature},
+		{name: "tampered body", secret: "s3cret", sig: sig, body: []byte(`{"id":"2"}`), now: now, err: ErrInvalidSignature},
+		{name: "missing prefix", secret: "s3cret", sig: sig[len(prefix):], body: body, now: now, err: ErrInvalidSignature},
+		{name: "stale", secret: "s3cret", sig: sig, body: body, now: now.Add(10 * time.Minute), err: ErrStaleTimestamp},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.sig, ts, tt.body, tt.now, 5*time.Minute)
+			if tt.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestSetup_Stdout(t *testing.T) {
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	var buf bytes.Buffer
+	shutdown, err := Setup(context.Background(), Config{
+		Exporter:    ExporterStdout,
+		SampleRatio: 1,
+		ServiceName: "wallet-service-test",
+		Writer:      &buf,
+	})
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "test-span")
+	span.End()
+
+	require.NoError(t, shutdown(context.Background()))
+	require.Contains(t, buf.String(), "test-span")
+	require.Contains(t, buf.String(), "wallet-service-test")
+}
+
+func TestSetup_None(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Config{Exporter: ExporterNone})
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	_, err := Setup(context.Background(), Config{Exporter: "zipkin"})
+	require.ErrorIs(t, err, ErrUnknownExporter)
+}
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/services"
+	"wallet-service/internal/services/auth/mocks"
+	"wallet-service/internal/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newService(ctrl *gomock.Controller) (*Service, *mocks.MockKeyStore) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	mockStore := mocks.NewMockKeyStore(ctrl)
+
+	return New(logger, mockStore), mockStore
+}
+
+func TestService_CreateKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockStore := newService(ctrl)
+
+	var storedHash []byte
+	mockStore.
+		EXPECT().
+		CreateAPIKey(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key *models.APIKey, keyHash []byte) (*models.APIKey, error) {
+			require.Equal(t, "billing", key.Name)
+			require.Equal(t, []models.Scope{models.ScopeWalletsRead, models.ScopeWalletsWrite}, key.Scopes)
+			storedHash = keyHash
+			return key, nil
+		})
+
+	key, secret, err := service.CreateKey(context.Background(), " billing ",
+		[]models.Scope{models.ScopeWalletsWrite, models.ScopeWalletsRead, models.ScopeWalletsWrite})
+
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(secret, KeyPrefix))
+	require.True(t, strings.HasPrefix(secret, key.Prefix))
+	require.Equal(t, hashKey(secret), storedHash)
+	require.NotContains(t, string(storedHash), secret)
+}
+
+func TestService_CreateKey_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, _ := newService(ctrl)
+
+	_, _, err := service.CreateKey(context.Background(), "", []models.Scope{models.ScopeAdmin})
+	require.ErrorIs(t, err, services.ErrInvalidAPIKeyName)
+
+	_, _, err = service.CreateKey(context.Background(), "ops", nil)
+	require.ErrorIs(t, err, services.ErrInvalidScope)
+
+	_, _, err = service.CreateKey(context.Background(), "ops", []models.Scope{"wallets:delete"})
+	require.ErrorIs(t, err, services.ErrInvalidScope)
+}
+
+func TestService_Authenticate(t *testing.T) {
+	secret := KeyPrefix + "c2VjcmV0LWtleS1tYXRlcmlhbC1mb3ItdGVzdHM"
+	keyID := uuid.New()
+	recently := time.Now().Add(-10 * time.Second)
+	longAgo := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		secret  string
+		key     *models.APIKey
+		lookup  error
+		touch   bool
+		wantErr error
+	}{
+		{
+			name:   "valid, used recently",
+			secret: secret,
+			key:    &models.APIKey{ID: keyID, Name: "billing", Scopes: []models.Scope{models.ScopeWalletsRead}, LastUsedAt: &recently},
+		},
+		{
+			name:   "valid, not used lately",
+			secret: secret,
+			key:    &models.APIKey{ID: keyID, Name: "billing", Scopes: []models.Scope{models.ScopeWalletsRead}, LastUsedAt: &longAgo},
+			touch:  true,
+		},
+		{
+			name:    "revoked",
+			secret:  secret,
+			key:     &models.APIKey{ID: keyID, RevokedAt: &longAgo},
+			wantErr: services.ErrInvalidAPIKey,
+		},
+		{
+			name:    "unknown",
+			secret:  secret,
+			lookup:  storage.ErrAPIKeyNotFound,
+			wantErr: services.ErrInvalidAPIKey,
+		},
+		{
+			name:    "malformed",
+			secret:  "Bearer something",
+			wantErr: services.ErrInvalidAPIKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			service, mockStore := newService(ctrl)
+
+			if tt.key != nil || tt.lookup != nil {
+				mockStore.
+					EXPECT().
+					GetAPIKeyByHash(gomock.Any(), hashKey(tt.secret)).
+					Return(tt.key, tt.lookup)
+			}
+			if tt.touch {
+				mockStore.
+					EXPECT().
+					TouchAPIKey(gomock.Any(), keyID).
+					Return(nil)
+			}
+
+			principal, err := service.Authenticate(context.Background(), tt.secret)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, keyID, principal.KeyID)
+			require.Equal(t, "billing", principal.Name)
+		})
+	}
+}
+
+func TestPrincipal_HasScope(t *testing.T) {
+	reader := &models.Principal{Scopes: []models.Scope{models.ScopeWalletsRead}}
+	writer := &models.Principal{Scopes: []models.Scope{models.ScopeWalletsWrite}}
+	admin := &models.Principal{Scopes: []models.Scope{models.ScopeAdmin}}
+
+	require.True(t, reader.HasScope(models.ScopeWalletsRead))
+	require.False(t, reader.HasScope(models.ScopeWalletsWrite))
+	require.True(t, writer.HasScope(models.ScopeWalletsRead))
+	require.False(t, writer.HasScope(models.ScopeAdmin))
+	require.True(t, admin.HasScope(models.ScopeWalletsWrite))
+}
@@ -0,0 +1,117 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/auth/auth.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/auth/auth.go -destination=internal/services/auth/mocks/mock_auth.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockKeyStore is a mock of KeyStore interface.
+type MockKeyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockKeyStoreMockRecorder is the mock recorder for MockKeyStore.
+type MockKeyStoreMockRecorder struct {
+	mock *MockKeyStore
+}
+
+// NewMockKeyStore creates a new mock instance.
+func NewMockKeyStore(ctrl *gomock.Controller) *MockKeyStore {
+	mock := &MockKeyStore{ctrl: ctrl}
+	mock.recorder = &MockKeyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockKeyStore) EXPECT() *MockKeyStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIKey mocks base method.
+func (m *MockKeyStore) CreateAPIKey(ctx context.Context, key *models.APIKey, keyHash []byte) (*models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIKey", ctx, key, keyHash)
+	ret0, _ := ret[0].(*models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIKey indicates an expected call of CreateAPIKey.
+func (mr *MockKeyStoreMockRecorder) CreateAPIKey(ctx, key, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIKey", reflect.TypeOf((*MockKeyStore)(nil).CreateAPIKey), ctx, key, keyHash)
+}
+
+// GetAPIKeyByHash mocks base method.
+func (m *MockKeyStore) GetAPIKeyByHash(ctx context.Context, keyHash []byte) (*models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPIKeyByHash", ctx, keyHash)
+	ret0, _ := ret[0].(*models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPIKeyByHash indicates an expected call of GetAPIKeyByHash.
+func (mr *MockKeyStoreMockRecorder) GetAPIKeyByHash(ctx, keyHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPIKeyByHash", reflect.TypeOf((*MockKeyStore)(nil).GetAPIKeyByHash), ctx, keyHash)
+}
+
+// ListAPIKeys mocks base method.
+func (m *MockKeyStore) ListAPIKeys(ctx context.Context) ([]*models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPIKeys", ctx)
+	ret0, _ := ret[0].([]*models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPIKeys indicates an expected call of ListAPIKeys.
+func (mr *MockKeyStoreMockRecorder) ListAPIKeys(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPIKeys", reflect.TypeOf((*MockKeyStore)(nil).ListAPIKeys), ctx)
+}
+
+// RevokeAPIKey mocks base method.
+func (m *MockKeyStore) RevokeAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAPIKey", ctx, id)
+	ret0, _ := ret[0].(*models.APIKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAPIKey indicates an expected call of RevokeAPIKey.
+func (mr *MockKeyStoreMockRecorder) RevokeAPIKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIKey", reflect.TypeOf((*MockKeyStore)(nil).RevokeAPIKey), ctx, id)
+}
+
+// TouchAPIKey mocks base method.
+func (m *MockKeyStore) TouchAPIKey(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAPIKey", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAPIKey indicates an expected call of TouchAPIKey.
+func (mr *MockKeyStoreMockRecorder) TouchAPIKey(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAPIKey", reflect.TypeOf((*MockKeyStore)(nil).TouchAPIKey), ctx, id)
+}
@@ -1,9 +1,104 @@
 package services
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrAmountNegativeValue = errors.New("amount negative value")
+	ErrAmountTooLarge      = errors.New("amount is too large")
 
 	ErrInvalidWalletID = errors.New("invalid argument")
+
+	ErrSameWallet = errors.New("source and destination wallets are the same")
+
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused with a different payload")
+
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	ErrInvalidHoldTTL     = errors.New("invalid hold ttl")
+	ErrHoldNotActive      = errors.New("hold is not active")
+	ErrHoldExpired        = errors.New("hold has expired")
+	ErrCaptureExceedsHold = errors.New("capture amount exceeds held amount")
+
+	ErrUnbalancedEntry = errors.New("journal entry postings do not sum to zero")
+
+	ErrInvalidOperationType = errors.New("invalid operation type")
+
+	ErrUnsupportedCurrency  = errors.New("unsupported currency")
+	ErrCurrencyMismatch     = errors.New("currency does not match the wallet currency")
+	ErrUnexpectedConversion = errors.New("conversion supplied for a same-currency transfer")
+
+	ErrSameCurrency           = errors.New("source and target currencies are the same")
+	ErrRateUnavailable        = errors.New("exchange rate unavailable")
+	ErrQuoteExpired           = errors.New("fx quote has expired")
+	ErrQuoteUsed              = errors.New("fx quote has already been used")
+	ErrInvalidQuoteID         = errors.New("invalid fx quote id")
+	ErrExchangeAmountTooSmall = errors.New("amount is too small to exchange at the quoted rate")
+
+	ErrInvalidOperationID       = errors.New("invalid operation id")
+	ErrOperationNotReversible   = errors.New("only deposits and withdrawals can be reversed")
+	ErrOperationAlreadyReversed = errors.New("operation has already been fully reversed")
+	ErrReversalExceedsAmount    = errors.New("reversal amount exceeds the unreversed amount")
+	ErrInvalidReversalReason    = errors.New("invalid reversal reason")
+
+	ErrInvalidStatusChange     = errors.New("status change requires an actor and a reason")
+	ErrInvalidStatusTransition = errors.New("wallet status transition is not allowed")
+	ErrWalletNotEmpty          = errors.New("wallet must have zero balance to be closed")
+
+	ErrLimitExceeded = errors.New("limit exceeded")
+	ErrInvalidLimits = errors.New("invalid limits")
+
+	ErrInvalidWebhookURL = errors.New("webhook url must be an absolute http or https url")
+	ErrInvalidEventType  = errors.New("unknown event type")
+
+	ErrInvalidBatchMode = errors.New("invalid batch mode")
+	ErrEmptyBatch       = errors.New("batch has no items")
+	ErrBatchTooLarge    = errors.New("batch has too many items")
+
+	ErrInvalidBalanceBuckets = errors.New("invalid number of balance buckets")
+
+	ErrBalanceTimeInFuture = errors.New("balance time is in the future")
+	ErrWalletNotOpenedYet  = errors.New("wallet did not exist at the requested time")
+	ErrInvalidDateRange    = errors.New("invalid date range")
+
+	ErrInvalidReconciliationMode = errors.New("invalid reconciliation mode")
+	ErrReconciliationRunning     = errors.New("a reconciliation run is already in progress")
+	ErrNoReconciliationRunning   = errors.New("no reconciliation run to resume")
+
+	ErrInvalidAPIKey     = errors.New("invalid api key")
+	ErrInvalidAPIKeyName = errors.New("invalid api key name")
+	ErrInvalidScope      = errors.New("invalid scope")
 )
+
+// LimitExceededError names the limit an operation would exceed. It matches
+// ErrLimitExceeded with errors.Is.
+type LimitExceededError struct {
+	Limit     string
+	Max       int64
+	Attempted int64
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%s: %s: %d exceeds %d", ErrLimitExceeded, e.Limit, e.Attempted, e.Max)
+}
+
+func (e *LimitExceededError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// BatchItemError reports the item that made an atomic batch fail. It wraps
+// the error of that item.
+type BatchItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchItemError) Error() string {
+	return fmt.Sprintf("batch item %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,142 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/lib/logger/sl"
+	"wallet-service/internal/storage"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const checkTimeout = 2 * time.Second
+
+const (
+	CheckDatabase = "database"
+	CheckSchema   = "schema"
+)
+
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+type SchemaReader interface {
+	SchemaVersion(ctx context.Context) (uint, bool, error)
+}
+
+type PoolStater interface {
+	Stat() *pgxpool.Stat
+}
+
+// Checker decides whether the instance is ready for traffic: the database
+// answers and is migrated to exactly the schema the binary expects. Pool
+// saturation is reported but does not make the instance unready; a busy pool
+// is not cured by sending the traffic elsewhere.
+type Checker struct {
+	log           *slog.Logger
+	pinger        Pinger
+	schema        SchemaReader
+	pool          PoolStater
+	schemaVersion uint
+
+	draining atomic.Bool
+}
+
+func New(log *slog.Logger, pinger Pinger, schema SchemaReader, pool PoolStater, schemaVersion uint) *Checker {
+	return &Checker{
+		log:           log,
+		pinger:        pinger,
+		schema:        schema,
+		pool:          pool,
+		schemaVersion: schemaVersion,
+	}
+}
+
+// Drain makes every later readiness check fail, so load balancers stop
+// routing to the instance while it finishes the requests in flight.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+func (c *Checker) Ready(ctx context.Context) *models.Readiness {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	readiness := &models.Readiness{
+		Draining:      c.draining.Load(),
+		SchemaVersion: c.schemaVersion,
+		Checks: []models.HealthCheck{
+			result(CheckDatabase, c.checkDatabase(ctx)),
+			result(CheckSchema, c.checkSchema(ctx)),
+		},
+		Pool: c.poolUsage(),
+	}
+
+	readiness.Ready = !readiness.Draining
+	for _, check := range readiness.Checks {
+		readiness.Ready = readiness.Ready && check.OK
+	}
+
+	return readiness
+}
+
+// Errors of the database are logged rather than reported: the response is
+// served to anyone who can reach the port.
+func (c *Checker) checkDatabase(ctx context.Context) error {
+	if err := c.pinger.Ping(ctx); err != nil {
+		c.log.Warn("readiness: database ping failed", sl.Err(err))
+		return errors.New("database is unreachable")
+	}
+
+	return nil
+}
+
+func (c *Checker) checkSchema(ctx context.Context) error {
+	version, dirty, err := c.schema.SchemaVersion(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrSchemaNotMigrated) {
+			return err
+		}
+		c.log.Warn("readiness: failed to read schema version", sl.Err(err))
+		return errors.New("failed to read schema version")
+	}
+
+	if dirty {
+		return fmt.Errorf("migration %d failed and left the schema dirty", version)
+	}
+	if version != c.schemaVersion {
+		return fmt.Errorf("schema version %d, expected %d", version, c.schemaVersion)
+	}
+
+	return nil
+}
+
+func (c *Checker) poolUsage() *models.PoolUsage {
+	stat := c.pool.Stat()
+
+	usage := &models.PoolUsage{
+		AcquiredConns: stat.AcquiredConns(),
+		IdleConns:     stat.IdleConns(),
+		TotalConns:    stat.TotalConns(),
+		MaxConns:      stat.MaxConns(),
+	}
+	if usage.MaxConns > 0 {
+		usage.Saturation = float64(usage.AcquiredConns) / float64(usage.MaxConns)
+	}
+
+	return usage
+}
+
+func result(name string, err error) models.HealthCheck {
+	check := models.HealthCheck{Name: name, OK: err == nil}
+	if err != nil {
+		check.Error = err.Error()
+	}
+
+	return check
+}
@@ -0,0 +1,134 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/health/health.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/health/health.go -destination=internal/services/health/mocks/mock_health.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	pgxpool "github.com/jackc/pgx/v5/pgxpool"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPinger is a mock of Pinger interface.
+type MockPinger struct {
+	ctrl     *gomock.Controller
+	recorder *MockPingerMockRecorder
+	isgomock struct{}
+}
+
+// MockPingerMockRecorder is the mock recorder for MockPinger.
+type MockPingerMockRecorder struct {
+	mock *MockPinger
+}
+
+// NewMockPinger creates a new mock instance.
+func NewMockPinger(ctrl *gomock.Controller) *MockPinger {
+	mock := &MockPinger{ctrl: ctrl}
+	mock.recorder = &MockPingerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPinger) EXPECT() *MockPingerMockRecorder {
+	return m.recorder
+}
+
+// Ping mocks base method.
+func (m *MockPinger) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockPingerMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockPinger)(nil).Ping), ctx)
+}
+
+// MockSchemaReader is a mock of SchemaReader interface.
+type MockSchemaReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockSchemaReaderMockRecorder
+	isgomock struct{}
+}
+
+// MockSchemaReaderMockRecorder is the mock recorder for MockSchemaReader.
+type MockSchemaReaderMockRecorder struct {
+	mock *MockSchemaReader
+}
+
+// NewMockSchemaReader creates a new mock instance.
+func NewMockSchemaReader(ctrl *gomock.Controller) *MockSchemaReader {
+	mock := &MockSchemaReader{ctrl: ctrl}
+	mock.recorder = &MockSchemaReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSchemaReader) EXPECT() *MockSchemaReaderMockRecorder {
+	return m.recorder
+}
+
+// SchemaVersion mocks base method.
+func (m *MockSchemaReader) SchemaVersion(ctx context.Context) (uint, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchemaVersion", ctx)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SchemaVersion indicates an expected call of SchemaVersion.
+func (mr *MockSchemaReaderMockRecorder) SchemaVersion(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchemaVersion", reflect.TypeOf((*MockSchemaReader)(nil).SchemaVersion), ctx)
+}
+
+// MockPoolStater is a mock of PoolStater interface.
+type MockPoolStater struct {
+	ctrl     *gomock.Controller
+	recorder *MockPoolStaterMockRecorder
+	isgomock struct{}
+}
+
+// MockPoolStaterMockRecorder is the mock recorder for MockPoolStater.
+type MockPoolStaterMockRecorder struct {
+	mock *MockPoolStater
+}
+
+// NewMockPoolStater creates a new mock instance.
+func NewMockPoolStater(ctrl *gomock.Controller) *MockPoolStater {
+	mock := &MockPoolStater{ctrl: ctrl}
+	mock.recorder = &MockPoolStaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPoolStater) EXPECT() *MockPoolStaterMockRecorder {
+	return m.recorder
+}
+
+// Stat mocks base method.
+func (m *MockPoolStater) Stat() *pgxpool.Stat {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stat")
+	ret0, _ := ret[0].(*pgxpool.Stat)
+	return ret0
+}
+
+// Stat indicates an expected call of Stat.
+func (mr *MockPoolStaterMockRecorder) Stat() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stat", reflect.TypeOf((*MockPoolStater)(nil).Stat))
+}
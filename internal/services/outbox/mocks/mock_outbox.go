@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/outbox/outbox.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/outbox/outbox.go -destination=internal/services/outbox/mocks/mock_outbox.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	models "wallet-service/internal/domain/models"
+	pgx_driver "wallet-service/pkg/pgx-driver"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventPublisher is a mock of EventPublisher interface.
+type MockEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockEventPublisherMockRecorder is the mock recorder for MockEventPublisher.
+type MockEventPublisherMockRecorder struct {
+	mock *MockEventPublisher
+}
+
+// NewMockEventPublisher creates a new mock instance.
+func NewMockEventPublisher(ctrl *gomock.Controller) *MockEventPublisher {
+	mock := &MockEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventPublisher) EXPECT() *MockEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockEventPublisher) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockEventPublisherMockRecorder) Publish(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockEventPublisher)(nil).Publish), ctx, event)
+}
+
+// MockEventStore is a mock of EventStore interface.
+type MockEventStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockEventStoreMockRecorder is the mock recorder for MockEventStore.
+type MockEventStoreMockRecorder struct {
+	mock *MockEventStore
+}
+
+// NewMockEventStore creates a new mock instance.
+func NewMockEventStore(ctrl *gomock.Controller) *MockEventStore {
+	mock := &MockEventStore{ctrl: ctrl}
+	mock.recorder = &MockEventStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventStore) EXPECT() *MockEventStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteEvents mocks base method.
+func (m *MockEventStore) DeleteEvents(ctx context.Context, tx pgx_driver.QueryExecuter, status models.OutboxStatus, before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEvents", ctx, tx, status, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEvents indicates an expected call of DeleteEvents.
+func (mr *MockEventStoreMockRecorder) DeleteEvents(ctx, tx, status, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEvents", reflect.TypeOf((*MockEventStore)(nil).DeleteEvents), ctx, tx, status, before, limit)
+}
+
+// GetPendingEventsForUpdate mocks base method.
+func (m *MockEventStore) GetPendingEventsForUpdate(ctx context.Context, tx pgx_driver.QueryExecuter, now time.Time, limit int) ([]*models.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingEventsForUpdate", ctx, tx, now, limit)
+	ret0, _ := ret[0].([]*models.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingEventsForUpdate indicates an expected call of GetPendingEventsForUpdate.
+func (mr *MockEventStoreMockRecorder) GetPendingEventsForUpdate(ctx, tx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingEventsForUpdate", reflect.TypeOf((*MockEventStore)(nil).GetPendingEventsForUpdate), ctx, tx, now, limit)
+}
+
+// MarkEventFailed mocks base method.
+func (m *MockEventStore) MarkEventFailed(ctx context.Context, tx pgx_driver.QueryExecuter, id uuid.UUID, status models.OutboxStatus, nextAttemptAt time.Time, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventFailed", ctx, tx, id, status, nextAttemptAt, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventFailed indicates an expected call of MarkEventFailed.
+func (mr *MockEventStoreMockRecorder) MarkEventFailed(ctx, tx, id, status, nextAttemptAt, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventFailed", reflect.TypeOf((*MockEventStore)(nil).MarkEventFailed), ctx, tx, id, status, nextAttemptAt, lastError)
+}
+
+// MarkEventPublished mocks base method.
+func (m *MockEventStore) MarkEventPublished(ctx context.Context, tx pgx_driver.QueryExecuter, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventPublished", ctx, tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventPublished indicates an expected call of MarkEventPublished.
+func (mr *MockEventStoreMockRecorder) MarkEventPublished(ctx, tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventPublished", reflect.TypeOf((*MockEventStore)(nil).MarkEventPublished), ctx, tx, id)
+}
@@ -0,0 +1,192 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/lib/backoff"
+	"wallet-service/internal/lib/logger/sl"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+	"wallet-service/pkg/pgx-driver/transaction"
+
+	"github.com/google/uuid"
+)
+
+const maxLastErrorLength = 1000
+
+// EventPublisher delivers an event to downstream consumers. Delivery is
+// at-least-once: an event can be published again if the relay fails before
+// recording the success, so consumers should deduplicate by event id.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *models.OutboxEvent) error
+}
+
+type EventStore interface {
+	GetPendingEventsForUpdate(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		now time.Time,
+		limit int,
+	) ([]*models.OutboxEvent, error)
+	MarkEventPublished(ctx context.Context, tx pgxdriver.QueryExecuter, id uuid.UUID) error
+	MarkEventFailed(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		id uuid.UUID,
+		status models.OutboxStatus,
+		nextAttemptAt time.Time,
+		lastError string,
+	) error
+	DeleteEvents(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		status models.OutboxStatus,
+		before time.Time,
+		limit int,
+	) (int64, error)
+}
+
+// Relay moves events from the outbox table to an EventPublisher.
+type Relay struct {
+	txManager transaction.Manager
+	log       *slog.Logger
+	store     EventStore
+	publisher EventPublisher
+
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+}
+
+func New(
+	txManager transaction.Manager,
+	log *slog.Logger,
+	store EventStore,
+	publisher EventPublisher,
+	maxAttempts int,
+	baseBackoff time.Duration,
+	maxBackoff time.Duration,
+) *Relay {
+
+	return &Relay{
+		txManager:   txManager,
+		log:         log,
+		store:       store,
+		publisher:   publisher,
+		maxAttempts: maxAttempts,
+		baseBackoff: baseBackoff,
+		maxBackoff:  maxBackoff,
+	}
+}
+
+// RelayBatch publishes up to batchSize due events and returns how many it
+// claimed. The claimed rows stay locked while they are published, so several
+// relays can run side by side without delivering the same event twice
+// concurrently. A failed event is retried with exponential backoff and
+// marked DEAD after maxAttempts.
+func (r *Relay) RelayBatch(ctx context.Context, batchSize int) (int, error) {
+	const op = "services.outbox.RelayBatch"
+
+	var claimed int
+	err := r.txManager.ExecuteInTransaction(ctx, "relay_outbox", func(tx pgxdriver.QueryExecuter) error {
+		now := time.Now()
+
+		events, err := r.store.GetPendingEventsForUpdate(ctx, tx, now, batchSize)
+		if err != nil {
+			return err
+		}
+		claimed = len(events)
+
+		for _, event := range events {
+			publishErr := r.publisher.Publish(ctx, event)
+			if publishErr == nil {
+				if err := r.store.MarkEventPublished(ctx, tx, event.ID); err != nil {
+					return err
+				}
+				continue
+			}
+
+			attempts := event.Attempts + 1
+			status := models.OutboxPending
+			if attempts >= r.maxAttempts {
+				status = models.OutboxDead
+			}
+
+			log := r.log.With(
+				slog.String("event_id", event.ID.String()),
+				slog.Int("attempts", attempts),
+				sl.Err(publishErr),
+			)
+			if status == models.OutboxDead {
+				log.Error("outbox event moved to dead letter")
+			} else {
+				log.Warn("failed to publish outbox event")
+			}
+
+			lastError := publishErr.Error()
+			if len(lastError) > maxLastErrorLength {
+				lastError = lastError[:maxLastErrorLength]
+			}
+
+			nextAttemptAt := now.Add(backoff.Exponential(r.baseBackoff, r.maxBackoff, attempts))
+
+			err := r.store.MarkEventFailed(ctx, tx, event.ID, status, nextAttemptAt, lastError)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return claimed, nil
+}
+
+// PurgeEvents deletes published events older than retention and dead events
+// older than deadRetention, batchSize rows per transaction, and returns how
+// many it deleted. A zero retention keeps the events of that status forever.
+func (r *Relay) PurgeEvents(
+	ctx context.Context,
+	retention time.Duration,
+	deadRetention time.Duration,
+	batchSize int,
+) (int64, error) {
+	const op = "services.outbox.PurgeEvents"
+
+	now := time.Now()
+
+	var purged int64
+	for status, keep := range map[models.OutboxStatus]time.Duration{
+		models.OutboxPublished: retention,
+		models.OutboxDead:      deadRetention,
+	} {
+		if keep <= 0 {
+			continue
+		}
+
+		for {
+			var deleted int64
+			err := r.txManager.ExecuteInTransaction(ctx, "purge_outbox", func(tx pgxdriver.QueryExecuter) error {
+				var err error
+				deleted, err = r.store.DeleteEvents(ctx, tx, status, now.Add(-keep), batchSize)
+				return err
+			})
+			if err != nil {
+				return purged, fmt.Errorf("%s: %w", op, err)
+			}
+
+			purged += deleted
+			if deleted < int64(batchSize) {
+				break
+			}
+		}
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,161 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/lib/publisher"
+	"wallet-service/internal/services/outbox/mocks"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func runInTx(mockTxManager *mocks.MockManager, name string) {
+	mockTxManager.
+		EXPECT().
+		ExecuteInTransaction(gomock.Any(), name, gomock.Any()).
+		DoAndReturn(func(
+			ctx context.Context,
+			name string,
+			fn func(tx pgxdriver.QueryExecuter) error,
+		) error {
+			return fn(nil)
+		})
+}
+
+func newRelay(ctrl *gomock.Controller, store EventStore, pub EventPublisher) *Relay {
+	mockTxManager := mocks.NewMockManager(ctrl)
+	runInTx(mockTxManager, "relay_outbox")
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	return New(mockTxManager, logger, store, pub, 3, time.Second, 10*time.Second)
+}
+
+func TestRelay_RelayBatch_Publishes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mocks.NewMockEventStore(ctrl)
+	pub := publisher.NewMemoryPublisher()
+
+	events := []*models.OutboxEvent{
+		{ID: uuid.New(), EventType: models.EventBalanceChanged, Payload: []byte(`{}`)},
+		{ID: uuid.New(), EventType: models.EventBalanceChanged, Payload: []byte(`{}`)},
+	}
+
+	mockStore.
+		EXPECT().
+		GetPendingEventsForUpdate(gomock.Any(), gomock.Any(), gomock.Any(), 10).
+		Return(events, nil)
+
+	for _, event := range events {
+		mockStore.
+			EXPECT().
+			MarkEventPublished(gomock.Any(), gomock.Any(), event.ID).
+			Return(nil)
+	}
+
+	claimed, err := newRelay(ctrl, mockStore, pub).RelayBatch(context.Background(), 10)
+
+	require.NoError(t, err)
+	require.Equal(t, 2, claimed)
+	require.Equal(t, events, pub.Events())
+}
+
+func TestRelay_RelayBatch_Failures(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		status   models.OutboxStatus
+		delay    time.Duration
+	}{
+		{name: "first failure is retried", attempts: 0, status: models.OutboxPending, delay: time.Second},
+		{name: "backoff doubles", attempts: 1, status: models.OutboxPending, delay: 2 * time.Second},
+		{name: "last attempt goes to dead letter", attempts: 2, status: models.OutboxDead, delay: 4 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mocks.NewMockEventStore(ctrl)
+			pub := publisher.NewMemoryPublisher()
+			pub.FailWith(errors.New("broker unavailable"))
+
+			event := &models.OutboxEvent{ID: uuid.New(), Attempts: tt.attempts}
+
+			var claimedAt time.Time
+			mockStore.
+				EXPECT().
+				GetPendingEventsForUpdate(gomock.Any(), gomock.Any(), gomock.Any(), 10).
+				DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, now time.Time, _ int) ([]*models.OutboxEvent, error) {
+					claimedAt = now
+					return []*models.OutboxEvent{event}, nil
+				})
+
+			mockStore.
+				EXPECT().
+				MarkEventFailed(gomock.Any(), gomock.Any(), event.ID, tt.status, gomock.Any(), "broker unavailable").
+				DoAndReturn(func(
+					_ context.Context,
+					_ pgxdriver.QueryExecuter,
+					_ uuid.UUID,
+					_ models.OutboxStatus,
+					next time.Time,
+					_ string,
+				) error {
+					require.Equal(t, tt.delay, next.Sub(claimedAt))
+					return nil
+				})
+
+			claimed, err := newRelay(ctrl, mockStore, pub).RelayBatch(context.Background(), 10)
+
+			require.NoError(t, err)
+			require.Equal(t, 1, claimed)
+			require.Empty(t, pub.Events())
+		})
+	}
+}
+
+func TestRelay_PurgeEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockStore := mocks.NewMockEventStore(ctrl)
+
+	// Two full batches of published events and a short one, then the dead
+	// events are left alone: their retention is zero.
+	for range 3 {
+		runInTx(mockTxManager, "purge_outbox")
+	}
+
+	gomock.InOrder(
+		mockStore.EXPECT().DeleteEvents(gomock.Any(), gomock.Any(), models.OutboxPublished, gomock.Any(), 2).Return(int64(2), nil),
+		mockStore.EXPECT().DeleteEvents(gomock.Any(), gomock.Any(), models.OutboxPublished, gomock.Any(), 2).Return(int64(2), nil),
+		mockStore.
+			EXPECT().
+			DeleteEvents(gomock.Any(), gomock.Any(), models.OutboxPublished, gomock.Any(), 2).
+			DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, _ models.OutboxStatus, before time.Time, _ int) (int64, error) {
+				require.WithinDuration(t, time.Now().Add(-24*time.Hour), before, time.Second)
+				return 1, nil
+			}),
+	)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	relay := New(mockTxManager, logger, mockStore, publisher.NewMemoryPublisher(), 3, time.Second, 10*time.Second)
+
+	purged, err := relay.PurgeEvents(context.Background(), 24*time.Hour, 0, 2)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(5), purged)
+}
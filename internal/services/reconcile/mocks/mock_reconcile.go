@@ -0,0 +1,148 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/reconcile/reconcile.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/reconcile/reconcile.go -destination=internal/services/reconcile/mocks/mock_reconcile.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	models "wallet-service/internal/domain/models"
+	pgx_driver "wallet-service/pkg/pgx-driver"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRunStore is a mock of RunStore interface.
+type MockRunStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockRunStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockRunStoreMockRecorder is the mock recorder for MockRunStore.
+type MockRunStoreMockRecorder struct {
+	mock *MockRunStore
+}
+
+// NewMockRunStore creates a new mock instance.
+func NewMockRunStore(ctrl *gomock.Controller) *MockRunStore {
+	mock := &MockRunStore{ctrl: ctrl}
+	mock.recorder = &MockRunStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRunStore) EXPECT() *MockRunStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateRun mocks base method.
+func (m *MockRunStore) CreateRun(ctx context.Context, tx pgx_driver.QueryExecuter, run *models.ReconciliationRun) (*models.ReconciliationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRun", ctx, tx, run)
+	ret0, _ := ret[0].(*models.ReconciliationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRun indicates an expected call of CreateRun.
+func (mr *MockRunStoreMockRecorder) CreateRun(ctx, tx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRun", reflect.TypeOf((*MockRunStore)(nil).CreateRun), ctx, tx, run)
+}
+
+// GetLastCompletedRun mocks base method.
+func (m *MockRunStore) GetLastCompletedRun(ctx context.Context, tx pgx_driver.QueryExecuter) (*models.ReconciliationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastCompletedRun", ctx, tx)
+	ret0, _ := ret[0].(*models.ReconciliationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastCompletedRun indicates an expected call of GetLastCompletedRun.
+func (mr *MockRunStoreMockRecorder) GetLastCompletedRun(ctx, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastCompletedRun", reflect.TypeOf((*MockRunStore)(nil).GetLastCompletedRun), ctx, tx)
+}
+
+// GetRunForUpdate mocks base method.
+func (m *MockRunStore) GetRunForUpdate(ctx context.Context, tx pgx_driver.QueryExecuter, id uuid.UUID) (*models.ReconciliationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunForUpdate", ctx, tx, id)
+	ret0, _ := ret[0].(*models.ReconciliationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunForUpdate indicates an expected call of GetRunForUpdate.
+func (mr *MockRunStoreMockRecorder) GetRunForUpdate(ctx, tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunForUpdate", reflect.TypeOf((*MockRunStore)(nil).GetRunForUpdate), ctx, tx, id)
+}
+
+// GetRunningRun mocks base method.
+func (m *MockRunStore) GetRunningRun(ctx context.Context, tx pgx_driver.QueryExecuter) (*models.ReconciliationRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRunningRun", ctx, tx)
+	ret0, _ := ret[0].(*models.ReconciliationRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRunningRun indicates an expected call of GetRunningRun.
+func (mr *MockRunStoreMockRecorder) GetRunningRun(ctx, tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRunningRun", reflect.TypeOf((*MockRunStore)(nil).GetRunningRun), ctx, tx)
+}
+
+// ReconcileWallets mocks base method.
+func (m *MockRunStore) ReconcileWallets(ctx context.Context, tx pgx_driver.QueryExecuter, after uuid.UUID, since *time.Time, limit int) ([]*models.WalletReconciliation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReconcileWallets", ctx, tx, after, since, limit)
+	ret0, _ := ret[0].([]*models.WalletReconciliation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReconcileWallets indicates an expected call of ReconcileWallets.
+func (mr *MockRunStoreMockRecorder) ReconcileWallets(ctx, tx, after, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReconcileWallets", reflect.TypeOf((*MockRunStore)(nil).ReconcileWallets), ctx, tx, after, since, limit)
+}
+
+// SaveMismatches mocks base method.
+func (m *MockRunStore) SaveMismatches(ctx context.Context, tx pgx_driver.QueryExecuter, runID uuid.UUID, mismatches []*models.WalletReconciliation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveMismatches", ctx, tx, runID, mismatches)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveMismatches indicates an expected call of SaveMismatches.
+func (mr *MockRunStoreMockRecorder) SaveMismatches(ctx, tx, runID, mismatches any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMismatches", reflect.TypeOf((*MockRunStore)(nil).SaveMismatches), ctx, tx, runID, mismatches)
+}
+
+// UpdateRun mocks base method.
+func (m *MockRunStore) UpdateRun(ctx context.Context, tx pgx_driver.QueryExecuter, run *models.ReconciliationRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRun", ctx, tx, run)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRun indicates an expected call of UpdateRun.
+func (mr *MockRunStoreMockRecorder) UpdateRun(ctx, tx, run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRun", reflect.TypeOf((*MockRunStore)(nil).UpdateRun), ctx, tx, run)
+}
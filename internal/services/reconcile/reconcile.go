@@ -0,0 +1,286 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/lib/logger/sl"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+	"wallet-service/pkg/pgx-driver/transaction"
+
+	"github.com/google/uuid"
+)
+
+const maxLastErrorLength = 1000
+
+// incrementalOverlap moves the start of an incremental run back before the
+// start of the previous one: an operation stamped before that run started
+// may have committed only after the run had passed its wallet.
+const incrementalOverlap = time.Minute
+
+type RunStore interface {
+	CreateRun(ctx context.Context, tx pgxdriver.QueryExecuter, run *models.ReconciliationRun) (*models.ReconciliationRun, error)
+	GetRunningRun(ctx context.Context, tx pgxdriver.QueryExecuter) (*models.ReconciliationRun, error)
+	GetLastCompletedRun(ctx context.Context, tx pgxdriver.QueryExecuter) (*models.ReconciliationRun, error)
+	GetRunForUpdate(ctx context.Context, tx pgxdriver.QueryExecuter, id uuid.UUID) (*models.ReconciliationRun, error)
+	UpdateRun(ctx context.Context, tx pgxdriver.QueryExecuter, run *models.ReconciliationRun) error
+	ReconcileWallets(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		after uuid.UUID,
+		since *time.Time,
+		limit int,
+	) ([]*models.WalletReconciliation, error)
+	SaveMismatches(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		runID uuid.UUID,
+		mismatches []*models.WalletReconciliation,
+	) error
+}
+
+// ReportFunc is called with every mismatch once the page it was found in is
+// committed.
+type ReportFunc func(mismatch *models.WalletReconciliation)
+
+// Reconciler checks that the balance of every wallet equals its opening
+// balance plus the amounts of its operations and the balance of its ledger
+// account. Wallets are reconciled in pages of batchSize, each committed
+// together with the run checkpoint and the mismatches found in it, so a run
+// interrupted at any point is resumed where it stopped.
+type Reconciler struct {
+	txManager transaction.Manager
+	log       *slog.Logger
+	store     RunStore
+	batchSize int
+}
+
+func New(txManager transaction.Manager, log *slog.Logger, store RunStore, batchSize int) *Reconciler {
+	return &Reconciler{
+		txManager: txManager,
+		log:       log,
+		store:     store,
+		batchSize: batchSize,
+	}
+}
+
+// Run starts a new run and reconciles until it completes. An incremental run
+// only checks the wallets with operations since the previous completed run;
+// without one it checks every wallet. Only one run can be in progress at a
+// time: an unfinished run has to be resumed first.
+func (r *Reconciler) Run(
+	ctx context.Context,
+	mode models.ReconciliationMode,
+	report ReportFunc,
+) (*models.ReconciliationRun, error) {
+
+	const op = "services.reconcile.Run"
+
+	if mode != models.ReconcileFull && mode != models.ReconcileIncremental {
+		return nil, services.ErrInvalidReconciliationMode
+	}
+
+	var run *models.ReconciliationRun
+	err := r.txManager.ExecuteInTransaction(ctx, "reconcile_start", func(tx pgxdriver.QueryExecuter) error {
+		run = &models.ReconciliationRun{ID: uuid.New(), Mode: mode}
+
+		if mode == models.ReconcileIncremental {
+			last, err := r.store.GetLastCompletedRun(ctx, tx)
+			if err != nil && !errors.Is(err, storage.ErrReconciliationRunNotFound) {
+				return err
+			}
+			if last != nil {
+				since := last.StartedAt.Add(-incrementalOverlap)
+				run.Since = &since
+			}
+		}
+
+		var err error
+		run, err = r.store.CreateRun(ctx, tx, run)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, transaction.ErrConflictingData) {
+			return nil, services.ErrReconciliationRunning
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	r.log.Info("reconciliation started",
+		slog.String("run_id", run.ID.String()),
+		slog.String("mode", string(run.Mode)),
+	)
+
+	return r.process(ctx, run.ID, report)
+}
+
+// Resume continues the run in progress from its checkpoint until it
+// completes. Processes resuming the same run share its remaining pages.
+func (r *Reconciler) Resume(ctx context.Context, report ReportFunc) (*models.ReconciliationRun, error) {
+	const op = "services.reconcile.Resume"
+
+	var run *models.ReconciliationRun
+	err := r.txManager.ExecuteInTransaction(ctx, "reconcile_resume", func(tx pgxdriver.QueryExecuter) error {
+		var err error
+		run, err = r.store.GetRunningRun(ctx, tx)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrReconciliationRunNotFound) {
+			return nil, services.ErrNoReconciliationRunning
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	r.log.Info("reconciliation resumed",
+		slog.String("run_id", run.ID.String()),
+		slog.Int64("wallets_checked", run.WalletsChecked),
+	)
+
+	return r.process(ctx, run.ID, report)
+}
+
+// process reconciles the pages of a run one transaction at a time until none
+// is left.
+func (r *Reconciler) process(ctx context.Context, runID uuid.UUID, report ReportFunc) (*models.ReconciliationRun, error) {
+	const op = "services.reconcile.process"
+
+	for {
+		run, mismatches, err := r.reconcilePage(ctx, runID)
+		if err != nil {
+			r.recordError(ctx, runID, err)
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		for _, mismatch := range mismatches {
+			attrs := []any{
+				slog.String("run_id", runID.String()),
+				slog.String("wallet_id", mismatch.WalletID.String()),
+				slog.Int64("balance", mismatch.Balance),
+				slog.Int64("computed_balance", mismatch.ComputedBalance),
+				slog.Int64("operations", mismatch.Operations),
+			}
+			if mismatch.LedgerBalance != nil {
+				attrs = append(attrs, slog.Int64("ledger_balance", *mismatch.LedgerBalance))
+			}
+			r.log.Warn("wallet balance mismatch", attrs...)
+
+			if report != nil {
+				report(mismatch)
+			}
+		}
+
+		if run.Status == models.ReconciliationCompleted {
+			r.log.Info("reconciliation completed",
+				slog.String("run_id", run.ID.String()),
+				slog.Int64("wallets_checked", run.WalletsChecked),
+				slog.Int64("mismatches", run.Mismatches),
+			)
+			return run, nil
+		}
+	}
+}
+
+// reconcilePage reconciles the page after the checkpoint of the run and
+// moves the checkpoint past it. A short page completes the run.
+func (r *Reconciler) reconcilePage(
+	ctx context.Context,
+	runID uuid.UUID,
+) (*models.ReconciliationRun, []*models.WalletReconciliation, error) {
+
+	var (
+		run        *models.ReconciliationRun
+		mismatches []*models.WalletReconciliation
+	)
+	err := r.txManager.ExecuteInTransaction(ctx, "reconcile_page", func(tx pgxdriver.QueryExecuter) error {
+		mismatches = nil
+
+		var err error
+		run, err = r.store.GetRunForUpdate(ctx, tx, runID)
+		if err != nil {
+			return err
+		}
+
+		// Another process has finished the run.
+		if run.Status != models.ReconciliationRunning {
+			return nil
+		}
+
+		after := uuid.Nil
+		if run.Checkpoint != nil {
+			after = *run.Checkpoint
+		}
+
+		results, err := r.store.ReconcileWallets(ctx, tx, after, run.Since, r.batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, result := range results {
+			if !result.Matches() {
+				mismatches = append(mismatches, result)
+			}
+		}
+
+		if err := r.store.SaveMismatches(ctx, tx, run.ID, mismatches); err != nil {
+			return err
+		}
+
+		if len(results) > 0 {
+			checkpoint := results[len(results)-1].WalletID
+			run.Checkpoint = &checkpoint
+		}
+		run.WalletsChecked += int64(len(results))
+		run.Mismatches += int64(len(mismatches))
+		run.LastError = ""
+
+		if len(results) < r.batchSize {
+			finishedAt := time.Now()
+			run.Status = models.ReconciliationCompleted
+			run.FinishedAt = &finishedAt
+		}
+
+		return r.store.UpdateRun(ctx, tx, run)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return run, mismatches, nil
+}
+
+// recordError keeps the error that stopped a run on it; the run stays in
+// progress so it can be resumed.
+func (r *Reconciler) recordError(ctx context.Context, runID uuid.UUID, runErr error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	lastError := runErr.Error()
+	if len(lastError) > maxLastErrorLength {
+		lastError = lastError[:maxLastErrorLength]
+	}
+
+	err := r.txManager.ExecuteInTransaction(ctx, "reconcile_error", func(tx pgxdriver.QueryExecuter) error {
+		run, err := r.store.GetRunForUpdate(ctx, tx, runID)
+		if err != nil {
+			return err
+		}
+
+		run.LastError = lastError
+		return r.store.UpdateRun(ctx, tx, run)
+	})
+	if err != nil {
+		r.log.Error("failed to record reconciliation error",
+			slog.String("run_id", runID.String()),
+			sl.Err(err),
+		)
+	}
+}
@@ -0,0 +1,332 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/services"
+	"wallet-service/internal/services/reconcile/mocks"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+	"wallet-service/pkg/pgx-driver/transaction"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func runInTx(mockTxManager *mocks.MockManager, name string) *gomock.Call {
+	return mockTxManager.
+		EXPECT().
+		ExecuteInTransaction(gomock.Any(), name, gomock.Any()).
+		DoAndReturn(func(
+			ctx context.Context,
+			name string,
+			fn func(tx pgxdriver.QueryExecuter) error,
+		) error {
+			return fn(nil)
+		})
+}
+
+func newReconciler(mockTxManager *mocks.MockManager, store RunStore, batchSize int) *Reconciler {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	return New(mockTxManager, logger, store, batchSize)
+}
+
+func ptr(v int64) *int64 {
+	return &v
+}
+
+func matching(balance int64) *models.WalletReconciliation {
+	return &models.WalletReconciliation{
+		WalletID:        uuid.New(),
+		Balance:         balance,
+		ComputedBalance: balance,
+		LedgerBalance:   ptr(balance),
+		Operations:      1,
+	}
+}
+
+func TestReconciler_Run_Full(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockStore := mocks.NewMockRunStore(ctrl)
+
+	runID := uuid.New()
+	run := &models.ReconciliationRun{ID: runID, Mode: models.ReconcileFull, Status: models.ReconciliationRunning}
+
+	first := []*models.WalletReconciliation{matching(100), matching(200)}
+	drifted := &models.WalletReconciliation{
+		WalletID:        uuid.New(),
+		Balance:         500,
+		ComputedBalance: 450,
+		LedgerBalance:   ptr(500),
+		Operations:      3,
+	}
+	second := []*models.WalletReconciliation{drifted}
+
+	runInTx(mockTxManager, "reconcile_start")
+	mockStore.
+		EXPECT().
+		CreateRun(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, tx pgxdriver.QueryExecuter, r *models.ReconciliationRun) (*models.ReconciliationRun, error) {
+			require.Equal(t, models.ReconcileFull, r.Mode)
+			require.Nil(t, r.Since)
+			run.ID = r.ID
+			return run, nil
+		})
+
+	runInTx(mockTxManager, "reconcile_page").Times(2)
+	mockStore.
+		EXPECT().
+		GetRunForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(run, nil).
+		Times(2)
+
+	gomock.InOrder(
+		mockStore.
+			EXPECT().
+			ReconcileWallets(gomock.Any(), gomock.Any(), uuid.Nil, nil, 2).
+			Return(first, nil),
+		mockStore.
+			EXPECT().
+			ReconcileWallets(gomock.Any(), gomock.Any(), first[1].WalletID, nil, 2).
+			Return(second, nil),
+	)
+
+	gomock.InOrder(
+		mockStore.
+			EXPECT().
+			SaveMismatches(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Nil()).
+			Return(nil),
+		mockStore.
+			EXPECT().
+			SaveMismatches(gomock.Any(), gomock.Any(), gomock.Any(), second).
+			Return(nil),
+	)
+
+	mockStore.
+		EXPECT().
+		UpdateRun(gomock.Any(), gomock.Any(), run).
+		Return(nil).
+		Times(2)
+
+	var reported []*models.WalletReconciliation
+	result, err := newReconciler(mockTxManager, mockStore, 2).Run(
+		context.Background(),
+		models.ReconcileFull,
+		func(mismatch *models.WalletReconciliation) {
+			reported = append(reported, mismatch)
+		},
+	)
+
+	require.NoError(t, err)
+	require.Equal(t, models.ReconciliationCompleted, result.Status)
+	require.Equal(t, drifted.WalletID, *result.Checkpoint)
+	require.Equal(t, int64(3), result.WalletsChecked)
+	require.Equal(t, int64(1), result.Mismatches)
+	require.NotNil(t, result.FinishedAt)
+	require.Equal(t, []*models.WalletReconciliation{drifted}, reported)
+}
+
+func TestReconciler_Run_Incremental(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockStore := mocks.NewMockRunStore(ctrl)
+
+	lastStarted := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	since := lastStarted.Add(-incrementalOverlap)
+
+	runInTx(mockTxManager, "reconcile_start")
+	mockStore.
+		EXPECT().
+		GetLastCompletedRun(gomock.Any(), gomock.Any()).
+		Return(&models.ReconciliationRun{StartedAt: lastStarted, Status: models.ReconciliationCompleted}, nil)
+
+	var run *models.ReconciliationRun
+	mockStore.
+		EXPECT().
+		CreateRun(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, tx pgxdriver.QueryExecuter, r *models.ReconciliationRun) (*models.ReconciliationRun, error) {
+			require.Equal(t, models.ReconcileIncremental, r.Mode)
+			require.Equal(t, since, *r.Since)
+			created := *r
+			created.Status = models.ReconciliationRunning
+			run = &created
+			return run, nil
+		})
+
+	runInTx(mockTxManager, "reconcile_page")
+	mockStore.
+		EXPECT().
+		GetRunForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, tx pgxdriver.QueryExecuter, id uuid.UUID) (*models.ReconciliationRun, error) {
+			return run, nil
+		})
+	mockStore.
+		EXPECT().
+		ReconcileWallets(gomock.Any(), gomock.Any(), uuid.Nil, &since, 10).
+		Return(nil, nil)
+	mockStore.
+		EXPECT().
+		SaveMismatches(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Nil()).
+		Return(nil)
+	mockStore.
+		EXPECT().
+		UpdateRun(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	result, err := newReconciler(mockTxManager, mockStore, 10).Run(context.Background(), models.ReconcileIncremental, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, models.ReconciliationCompleted, result.Status)
+	require.Nil(t, result.Checkpoint)
+	require.Zero(t, result.WalletsChecked)
+}
+
+func TestReconciler_Run_AlreadyRunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockStore := mocks.NewMockRunStore(ctrl)
+
+	runInTx(mockTxManager, "reconcile_start")
+	mockStore.
+		EXPECT().
+		CreateRun(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, transaction.ErrConflictingData)
+
+	_, err := newReconciler(mockTxManager, mockStore, 10).Run(context.Background(), models.ReconcileFull, nil)
+
+	require.ErrorIs(t, err, services.ErrReconciliationRunning)
+}
+
+func TestReconciler_Run_InvalidMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	_, err := newReconciler(mocks.NewMockManager(ctrl), mocks.NewMockRunStore(ctrl), 10).
+		Run(context.Background(), "PARTIAL", nil)
+
+	require.ErrorIs(t, err, services.ErrInvalidReconciliationMode)
+}
+
+func TestReconciler_Resume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockStore := mocks.NewMockRunStore(ctrl)
+
+	checkpoint := uuid.New()
+	run := &models.ReconciliationRun{
+		ID:             uuid.New(),
+		Mode:           models.ReconcileFull,
+		Status:         models.ReconciliationRunning,
+		Checkpoint:     &checkpoint,
+		WalletsChecked: 1000,
+		Mismatches:     2,
+		LastError:      "connection reset",
+	}
+
+	runInTx(mockTxManager, "reconcile_resume")
+	mockStore.
+		EXPECT().
+		GetRunningRun(gomock.Any(), gomock.Any()).
+		Return(run, nil)
+
+	runInTx(mockTxManager, "reconcile_page")
+	mockStore.
+		EXPECT().
+		GetRunForUpdate(gomock.Any(), gomock.Any(), run.ID).
+		Return(run, nil)
+	mockStore.
+		EXPECT().
+		ReconcileWallets(gomock.Any(), gomock.Any(), checkpoint, nil, 10).
+		Return([]*models.WalletReconciliation{matching(10)}, nil)
+	mockStore.
+		EXPECT().
+		SaveMismatches(gomock.Any(), gomock.Any(), run.ID, gomock.Nil()).
+		Return(nil)
+	mockStore.
+		EXPECT().
+		UpdateRun(gomock.Any(), gomock.Any(), run).
+		Return(nil)
+
+	result, err := newReconciler(mockTxManager, mockStore, 10).Resume(context.Background(), nil)
+
+	require.NoError(t, err)
+	require.Equal(t, models.ReconciliationCompleted, result.Status)
+	require.Equal(t, int64(1001), result.WalletsChecked)
+	require.Equal(t, int64(2), result.Mismatches)
+	require.Empty(t, result.LastError)
+}
+
+func TestReconciler_Resume_NothingRunning(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockStore := mocks.NewMockRunStore(ctrl)
+
+	runInTx(mockTxManager, "reconcile_resume")
+	mockStore.
+		EXPECT().
+		GetRunningRun(gomock.Any(), gomock.Any()).
+		Return(nil, storage.ErrReconciliationRunNotFound)
+
+	_, err := newReconciler(mockTxManager, mockStore, 10).Resume(context.Background(), nil)
+
+	require.ErrorIs(t, err, services.ErrNoReconciliationRunning)
+}
+
+func TestReconciler_Run_RecordsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockStore := mocks.NewMockRunStore(ctrl)
+
+	run := &models.ReconciliationRun{ID: uuid.New(), Mode: models.ReconcileFull, Status: models.ReconciliationRunning}
+	queryErr := errors.New("statement timeout")
+
+	runInTx(mockTxManager, "reconcile_start")
+	mockStore.
+		EXPECT().
+		CreateRun(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(run, nil)
+
+	runInTx(mockTxManager, "reconcile_page")
+	runInTx(mockTxManager, "reconcile_error")
+	mockStore.
+		EXPECT().
+		GetRunForUpdate(gomock.Any(), gomock.Any(), run.ID).
+		Return(run, nil).
+		Times(2)
+	mockStore.
+		EXPECT().
+		ReconcileWallets(gomock.Any(), gomock.Any(), uuid.Nil, nil, 10).
+		Return(nil, queryErr)
+	mockStore.
+		EXPECT().
+		UpdateRun(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, tx pgxdriver.QueryExecuter, r *models.ReconciliationRun) error {
+			require.Equal(t, models.ReconciliationRunning, r.Status)
+			require.Equal(t, queryErr.Error(), r.LastError)
+			return nil
+		})
+
+	_, err := newReconciler(mockTxManager, mockStore, 10).Run(context.Background(), models.ReconcileFull, nil)
+
+	require.ErrorIs(t, err, queryErr)
+}
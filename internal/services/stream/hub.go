@@ -0,0 +1,116 @@
+package stream
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Listener delivers the payloads of database notifications on a channel.
+// An empty payload means notifications may have been missed.
+type Listener interface {
+	Listen(ctx context.Context, channel string, handle func(payload string)) error
+}
+
+// Hub fans operation notifications out to the streams watching a wallet.
+// A signal only says that something changed: subscribers re-read the
+// operations table from their own cursor, so coalesced signals lose nothing.
+type Hub struct {
+	log *slog.Logger
+
+	mu          sync.Mutex
+	subscribers map[uuid.UUID]map[chan struct{}]struct{}
+	closed      bool
+}
+
+func NewHub(log *slog.Logger) *Hub {
+	return &Hub{
+		log:         log,
+		subscribers: make(map[uuid.UUID]map[chan struct{}]struct{}),
+	}
+}
+
+// Subscribe registers interest in walletID. The returned channel receives a
+// signal after operations on the wallet commit; unsubscribe must be called
+// once the caller stops reading. The channel is closed when the hub is.
+func (h *Hub) Subscribe(walletID uuid.UUID) (signals <-chan struct{}, unsubscribe func()) {
+	ch := make(chan struct{}, 1)
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		close(ch)
+		return ch, func() {}
+	}
+	if h.subscribers[walletID] == nil {
+		h.subscribers[walletID] = make(map[chan struct{}]struct{})
+	}
+	h.subscribers[walletID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		if _, ok := h.subscribers[walletID][ch]; !ok {
+			return
+		}
+		delete(h.subscribers[walletID], ch)
+		if len(h.subscribers[walletID]) == 0 {
+			delete(h.subscribers, walletID)
+		}
+	}
+}
+
+// Notify signals the subscribers of the wallet named by payload, or every
+// subscriber when the payload is empty.
+func (h *Hub) Notify(payload string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if payload == "" {
+		for _, subscribers := range h.subscribers {
+			signal(subscribers)
+		}
+		return
+	}
+
+	walletID, err := uuid.Parse(payload)
+	if err != nil {
+		h.log.Warn("ignoring malformed operation notification", slog.String("payload", payload))
+		return
+	}
+
+	signal(h.subscribers[walletID])
+}
+
+// Close ends every subscription by closing its channel, so open streams
+// return and let a graceful shutdown finish.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, subscribers := range h.subscribers {
+		for ch := range subscribers {
+			close(ch)
+		}
+	}
+	h.subscribers = make(map[uuid.UUID]map[chan struct{}]struct{})
+	h.closed = true
+}
+
+// Run feeds the hub from channel until ctx is canceled.
+func (h *Hub) Run(ctx context.Context, listener Listener, channel string) error {
+	return listener.Listen(ctx, channel, h.Notify)
+}
+
+func signal(subscribers map[chan struct{}]struct{}) {
+	for ch := range subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
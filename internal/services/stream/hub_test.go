@@ -0,0 +1,80 @@
+package stream
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func received(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestHub(t *testing.T) {
+	t.Run("signals only the notified wallet", func(t *testing.T) {
+		hub := NewHub(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		walletID := uuid.New()
+		watched, unsubscribeWatched := hub.Subscribe(walletID)
+		defer unsubscribeWatched()
+		other, unsubscribeOther := hub.Subscribe(uuid.New())
+		defer unsubscribeOther()
+
+		hub.Notify(walletID.String())
+		hub.Notify(walletID.String())
+
+		require.True(t, received(watched))
+		require.False(t, received(watched), "signals must coalesce")
+		require.False(t, received(other))
+	})
+
+	t.Run("empty payload signals everyone", func(t *testing.T) {
+		hub := NewHub(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		first, unsubscribeFirst := hub.Subscribe(uuid.New())
+		defer unsubscribeFirst()
+		second, unsubscribeSecond := hub.Subscribe(uuid.New())
+		defer unsubscribeSecond()
+
+		hub.Notify("")
+
+		require.True(t, received(first))
+		require.True(t, received(second))
+	})
+
+	t.Run("unsubscribe and malformed payload", func(t *testing.T) {
+		hub := NewHub(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		walletID := uuid.New()
+		signals, unsubscribe := hub.Subscribe(walletID)
+		unsubscribe()
+
+		hub.Notify("not-a-uuid")
+		hub.Notify(walletID.String())
+
+		require.False(t, received(signals))
+		require.Empty(t, hub.subscribers)
+	})
+	t.Run("close ends subscriptions", func(t *testing.T) {
+		hub := NewHub(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+		open, unsubscribe := hub.Subscribe(uuid.New())
+		hub.Close()
+		unsubscribe()
+
+		_, ok := <-open
+		require.False(t, ok)
+
+		late, _ := hub.Subscribe(uuid.New())
+		_, ok = <-late
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,142 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+const maxBalanceDays = 366
+
+type BalanceHistory interface {
+	GetBalancesAt(ctx context.Context, walletID uuid.UUID, at []time.Time) ([]models.BalancePoint, error)
+}
+
+// BalanceAt returns the balance the wallet had at the given moment: its
+// opening balance plus the operations created at or before it.
+func (ws *ServiceWallet) BalanceAt(ctx context.Context, walletID uuid.UUID, at time.Time) (*models.WalletBalance, error) {
+	const op = "services.wallet.BalanceAt"
+
+	ctx, span := startSpan(ctx, "BalanceAt", uuidAttr("wallet.id", walletID))
+	defer span.End()
+
+	if walletID == uuid.Nil {
+		return nil, services.ErrInvalidWalletID
+	}
+
+	if at.After(time.Now()) {
+		return nil, services.ErrBalanceTimeInFuture
+	}
+
+	wallet, err := ws.walletGetter.GetWallet(ctx, walletID)
+	if err != nil {
+		if errors.Is(err, storage.ErrWalletNotFound) {
+			return nil, storage.ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if at.Before(wallet.CreatedAt) {
+		return nil, services.ErrWalletNotOpenedYet
+	}
+
+	points, err := ws.balanceHistory.GetBalancesAt(ctx, walletID, []time.Time{at})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(points) == 0 {
+		return nil, storage.ErrWalletNotFound
+	}
+
+	return &models.WalletBalance{
+		WalletID: walletID,
+		Currency: wallet.Currency,
+		At:       at,
+		Balance:  points[0].Balance,
+	}, nil
+}
+
+// DailyBalances returns the end-of-day balance of the wallet for every day
+// from the date of from to the date of to, both included, in loc. A day ends
+// at 23:59:59.999999; the current day reports the balance so far.
+func (ws *ServiceWallet) DailyBalances(
+	ctx context.Context,
+	walletID uuid.UUID,
+	from time.Time,
+	to time.Time,
+	loc *time.Location,
+) (*models.BalanceSeries, error) {
+
+	const op = "services.wallet.DailyBalances"
+
+	ctx, span := startSpan(ctx, "DailyBalances", uuidAttr("wallet.id", walletID))
+	defer span.End()
+
+	if walletID == uuid.Nil {
+		return nil, services.ErrInvalidWalletID
+	}
+
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	last := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, loc)
+	if last.Before(first) || first.AddDate(0, 0, maxBalanceDays-1).Before(last) {
+		return nil, services.ErrInvalidDateRange
+	}
+
+	now := time.Now()
+	if last.After(now) {
+		return nil, services.ErrBalanceTimeInFuture
+	}
+
+	wallet, err := ws.walletGetter.GetWallet(ctx, walletID)
+	if err != nil {
+		if errors.Is(err, storage.ErrWalletNotFound) {
+			return nil, storage.ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	series := &models.BalanceSeries{
+		WalletID: walletID,
+		Currency: wallet.Currency,
+		TimeZone: loc.String(),
+		Days:     []models.DailyBalance{},
+	}
+
+	var at []time.Time
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		end := day.AddDate(0, 0, 1).Add(-time.Microsecond)
+		if end.Before(wallet.CreatedAt) {
+			continue
+		}
+		if end.After(now) {
+			end = now
+		}
+
+		series.Days = append(series.Days, models.DailyBalance{Date: day.Format(time.DateOnly), At: end})
+		at = append(at, end)
+	}
+
+	if len(at) == 0 {
+		return series, nil
+	}
+
+	points, err := ws.balanceHistory.GetBalancesAt(ctx, walletID, at)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if len(points) != len(at) {
+		return nil, storage.ErrWalletNotFound
+	}
+
+	for i, point := range points {
+		series.Days[i].Balance = point.Balance
+	}
+
+	return series, nil
+}
@@ -0,0 +1,191 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const maxBatchItems = 1000
+
+// ApplyBatch applies a list of deposits and withdrawals in one transaction,
+// in the given order. Idempotency keys of the items are ignored.
+//
+// In atomic mode the first failing item aborts the whole batch with a
+// *services.BatchItemError. In best-effort mode each item runs under its own
+// savepoint: an item rejected by the wallet rules (missing or inactive
+// wallet, insufficient funds, limits, currency) is reported in its result
+// while the others still apply; any other failure aborts the batch.
+//
+// The wallets of the batch are locked up front in id order, so concurrent
+// batches cannot deadlock, and the writes of each item are pipelined into a
+// single round trip.
+func (ws *ServiceWallet) ApplyBatch(
+	ctx context.Context,
+	mode models.BatchMode,
+	items []models.OperationRequest,
+) ([]models.BatchItemResult, error) {
+
+	const op = "services.wallet.ApplyBatch"
+
+	ctx, span := startSpan(ctx, "ApplyBatch",
+		attribute.String("wallet.batch.mode", string(mode)),
+		attribute.Int("wallet.batch.size", len(items)),
+	)
+	defer span.End()
+
+	if mode != models.BatchAtomic && mode != models.BatchBestEffort {
+		return nil, services.ErrInvalidBatchMode
+	}
+	if len(items) == 0 {
+		return nil, services.ErrEmptyBatch
+	}
+	if len(items) > maxBatchItems {
+		return nil, services.ErrBatchTooLarge
+	}
+
+	atomic := mode == models.BatchAtomic
+
+	var (
+		rejected  = make([]error, len(items))
+		walletIDs = make([]uuid.UUID, 0, len(items))
+	)
+	for i, item := range items {
+		err := validateOperationRequest(item)
+		if err == nil && item.WalletID == uuid.Nil {
+			err = services.ErrInvalidWalletID
+		}
+		if err != nil {
+			if atomic {
+				ws.observeOperation(item, nil, false, err)
+				return nil, &services.BatchItemError{Index: i, Err: err}
+			}
+			rejected[i] = err
+			continue
+		}
+
+		walletIDs = append(walletIDs, item.WalletID)
+	}
+
+	var results []models.BatchItemResult
+	err := ws.txManager.ExecuteInTransaction(ctx, "batch_operations", func(tx pgxdriver.QueryExecuter) error {
+		results = make([]models.BatchItemResult, len(items))
+
+		exists := make(map[uuid.UUID]bool, len(walletIDs))
+		if len(walletIDs) > 0 {
+			locked, err := ws.walletLocker.LockExistingWallets(ctx, tx, walletIDs...)
+			if err != nil {
+				return err
+			}
+			for _, wallet := range locked {
+				exists[wallet.ID] = true
+			}
+		}
+
+		pipe := pgxdriver.NewPipeline(tx)
+		for i, item := range items {
+			if rejected[i] != nil {
+				results[i].Err = rejected[i]
+				continue
+			}
+
+			var (
+				wallet *models.Wallet
+				err    error
+			)
+			switch {
+			case !exists[item.WalletID]:
+				err = storage.ErrWalletNotFound
+			case atomic:
+				wallet, _, err = ws.applyOperationTx(ctx, pipe, item)
+			default:
+				wallet, err = ws.applyBatchItemTx(ctx, pipe, item)
+			}
+
+			if err != nil {
+				if atomic || !isRejectedItem(err) {
+					return &services.BatchItemError{Index: i, Err: err}
+				}
+				results[i].Err = err
+				continue
+			}
+
+			results[i].Wallet = wallet
+		}
+
+		return pipe.Flush(ctx)
+	})
+
+	if err != nil {
+		var itemErr *services.BatchItemError
+		if errors.As(err, &itemErr) && isRejectedItem(itemErr.Err) {
+			ws.observeOperation(items[itemErr.Index], nil, false, itemErr.Err)
+			return nil, itemErr
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for i, result := range results {
+		ws.observeOperation(items[i], result.Wallet, false, result.Err)
+	}
+
+	return results, nil
+}
+
+// applyBatchItemTx applies one best-effort item under a savepoint, so a
+// rejected item leaves no trace while the transaction goes on. The writes of
+// the item are flushed before it is reported as applied.
+func (ws *ServiceWallet) applyBatchItemTx(
+	ctx context.Context,
+	pipe *pgxdriver.Pipeline,
+	req models.OperationRequest,
+) (*models.Wallet, error) {
+
+	// Errors of queued statements surface from the next flush.
+	_, _ = pipe.Exec(ctx, "SAVEPOINT batch_item")
+
+	wallet, _, err := ws.applyOperationTx(ctx, pipe, req)
+	if err == nil {
+		_, _ = pipe.Exec(ctx, "RELEASE SAVEPOINT batch_item")
+
+		if err = pipe.Flush(ctx); err == nil {
+			return wallet, nil
+		}
+	}
+
+	_, _ = pipe.Exec(ctx, "ROLLBACK TO SAVEPOINT batch_item")
+	_, _ = pipe.Exec(ctx, "RELEASE SAVEPOINT batch_item")
+
+	return nil, err
+}
+
+// isRejectedItem reports whether err rejects a single item on the merits of
+// its request or wallet, as opposed to a failure of the whole batch.
+func isRejectedItem(err error) bool {
+	for _, known := range []error{
+		storage.ErrWalletNotFound,
+		storage.ErrWalletFrozen,
+		storage.ErrWalletClosed,
+		storage.ErrInsufficientFunds,
+		services.ErrCurrencyMismatch,
+		services.ErrLimitExceeded,
+		services.ErrInvalidOperationType,
+		services.ErrAmountNegativeValue,
+		services.ErrUnsupportedCurrency,
+		services.ErrInvalidWalletID,
+	} {
+		if errors.Is(err, known) {
+			return true
+		}
+	}
+
+	return false
+}
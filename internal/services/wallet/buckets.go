@@ -0,0 +1,222 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const maxBalanceBuckets = 64
+
+type BucketBalanceUpdater interface {
+	IncreaseBucketBalance(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		walletID uuid.UUID,
+		amount int64,
+	) (*models.Wallet, error)
+	DecreaseBucketBalance(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		walletID uuid.UUID,
+		amount int64,
+	) (*models.Wallet, error)
+	ConsolidateBalance(ctx context.Context, tx pgxdriver.QueryExecuter, walletID uuid.UUID) (*models.Wallet, error)
+	SetBalanceBuckets(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		walletID uuid.UUID,
+		buckets int,
+	) (*models.Wallet, error)
+}
+
+// SetBalanceBuckets shards the balance of a wallet into the given number of
+// buckets, so concurrent deposits to it no longer queue on a single row;
+// zero turns sharding off. The balance of a sharded wallet is the sum of its
+// buckets and row.
+func (ws *ServiceWallet) SetBalanceBuckets(ctx context.Context, walletID uuid.UUID, buckets int) (*models.Wallet, error) {
+	const op = "services.wallet.SetBalanceBuckets"
+
+	ctx, span := startSpan(ctx, "SetBalanceBuckets",
+		uuidAttr("wallet.id", walletID),
+		attribute.Int("wallet.buckets", buckets),
+	)
+	defer span.End()
+
+	if walletID == uuid.Nil {
+		return nil, services.ErrInvalidWalletID
+	}
+
+	if buckets < 0 || buckets > maxBalanceBuckets {
+		return nil, services.ErrInvalidBalanceBuckets
+	}
+
+	var result *models.Wallet
+	err := ws.txManager.ExecuteInTransaction(ctx, "set_balance_buckets", func(tx pgxdriver.QueryExecuter) error {
+		if _, err := ws.walletLocker.LockWallets(ctx, tx, walletID); err != nil {
+			return err
+		}
+
+		var err error
+		result, err = ws.bucketBalanceUpdater.SetBalanceBuckets(ctx, tx, walletID, buckets)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrWalletNotFound) {
+			return nil, storage.ErrWalletNotFound
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// increaseBalanceTx credits a wallet on its row, or in a bucket when it is
+// sharded.
+func (ws *ServiceWallet) increaseBalanceTx(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+	amount int64,
+) (*models.Wallet, error) {
+
+	wallet, err := ws.walletBalanceUpdater.IncreaseBalance(ctx, tx, walletID, amount)
+	if errors.Is(err, storage.ErrWalletSharded) {
+		return ws.increaseShardedTx(ctx, tx, walletID, amount, false)
+	}
+
+	return wallet, err
+}
+
+// decreaseBalanceTx debits a wallet on its row, falling back to the buckets
+// of a sharded wallet.
+func (ws *ServiceWallet) decreaseBalanceTx(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+	amount int64,
+) (*models.Wallet, error) {
+
+	wallet, err := ws.walletBalanceUpdater.DecreaseBalance(ctx, tx, walletID, amount)
+	if errors.Is(err, storage.ErrWalletSharded) {
+		return ws.decreaseShardedTx(ctx, tx, walletID, amount, false)
+	}
+
+	return wallet, err
+}
+
+// holdBalanceTx reserves funds on the wallet row; a sharded wallet short of
+// them there is consolidated first.
+func (ws *ServiceWallet) holdBalanceTx(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+	amount int64,
+) (*models.Wallet, error) {
+
+	wallet, err := ws.holdBalanceUpdater.HoldBalance(ctx, tx, walletID, amount)
+	if !errors.Is(err, storage.ErrWalletSharded) {
+		return wallet, err
+	}
+
+	if _, err := ws.bucketBalanceUpdater.ConsolidateBalance(ctx, tx, walletID); err != nil {
+		return nil, err
+	}
+
+	wallet, err = ws.holdBalanceUpdater.HoldBalance(ctx, tx, walletID, amount)
+	if errors.Is(err, storage.ErrWalletSharded) {
+		return nil, storage.ErrInsufficientFunds
+	}
+
+	return wallet, err
+}
+
+// applyShardedTx moves the money of req on a sharded wallet. An operation
+// whose limits rely on the wallet row lock (see checkLimitsTx) takes it by
+// consolidating the wallet, so it is serialized like on a plain wallet.
+func (ws *ServiceWallet) applyShardedTx(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	req models.OperationRequest,
+) (*models.Wallet, error) {
+
+	overrides, err := ws.limitStore.GetWalletLimits(ctx, tx, req.WalletID)
+	if err != nil {
+		return nil, err
+	}
+	serialized := limitsNeedRowLock(overrides.Resolve(ws.limits), req.Type)
+
+	if req.Type == models.Withdraw {
+		return ws.decreaseShardedTx(ctx, tx, req.WalletID, req.Amount, serialized)
+	}
+
+	return ws.increaseShardedTx(ctx, tx, req.WalletID, req.Amount, serialized)
+}
+
+func (ws *ServiceWallet) increaseShardedTx(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+	amount int64,
+	serialized bool,
+) (*models.Wallet, error) {
+
+	if serialized {
+		if _, err := ws.bucketBalanceUpdater.ConsolidateBalance(ctx, tx, walletID); err != nil {
+			return nil, err
+		}
+	}
+
+	return ws.bucketBalanceUpdater.IncreaseBucketBalance(ctx, tx, walletID, amount)
+}
+
+// decreaseShardedTx draws the amount from a single bucket, or else checks it
+// against the whole balance consolidated onto the wallet row.
+func (ws *ServiceWallet) decreaseShardedTx(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+	amount int64,
+	serialized bool,
+) (*models.Wallet, error) {
+
+	if !serialized {
+		wallet, err := ws.bucketBalanceUpdater.DecreaseBucketBalance(ctx, tx, walletID, amount)
+		if !errors.Is(err, storage.ErrInsufficientFunds) {
+			return wallet, err
+		}
+	}
+
+	if _, err := ws.bucketBalanceUpdater.ConsolidateBalance(ctx, tx, walletID); err != nil {
+		return nil, err
+	}
+
+	wallet, err := ws.walletBalanceUpdater.DecreaseBalance(ctx, tx, walletID, amount)
+	if errors.Is(err, storage.ErrWalletSharded) {
+		return nil, storage.ErrInsufficientFunds
+	}
+
+	return wallet, err
+}
+
+// limitsNeedRowLock reports whether checking the limits of an operation
+// relies on concurrent operations of the wallet being serialized.
+func limitsNeedRowLock(limits models.Limits, opType models.OperationType) bool {
+	if limits.MaxOperations > 0 {
+		return true
+	}
+
+	if opType == models.Withdraw {
+		return limits.DailyWithdrawal > 0 || limits.MonthlyWithdrawal > 0
+	}
+
+	return limits.MaxBalance > 0
+}
@@ -0,0 +1,349 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+
+	"github.com/google/uuid"
+)
+
+// DepositCoalescer collects concurrent deposits to the same wallet over a
+// short window and applies them in one transaction: a single balance update
+// for their sum plus one operation, journal entry and event per deposit.
+// Each caller still gets the balance its own deposit left.
+//
+// Only plain deposits are coalesced; withdrawals and idempotent requests go
+// straight to the wallet service. When a coalesced transaction fails, its
+// deposits are retried one by one, so every caller sees the outcome and the
+// error its deposit would have had on its own.
+type DepositCoalescer struct {
+	ws       *ServiceWallet
+	log      *slog.Logger
+	window   time.Duration
+	maxBatch int
+
+	mu      sync.Mutex
+	pending map[uuid.UUID]*depositBatch
+	closed  bool
+	flushes sync.WaitGroup
+}
+
+type depositBatch struct {
+	walletID uuid.UUID
+	items    []*pendingDeposit
+	timer    *time.Timer
+	taken    bool
+}
+
+type pendingDeposit struct {
+	ctx   context.Context
+	req   models.OperationRequest
+	done  chan depositResult
+	batch *depositBatch
+}
+
+type depositResult struct {
+	wallet *models.Wallet
+	err    error
+}
+
+// NewDepositCoalescer returns a coalescer that holds the first deposit to a
+// wallet for window, or until maxBatch deposits to it are collected.
+func NewDepositCoalescer(ws *ServiceWallet, log *slog.Logger, window time.Duration, maxBatch int) *DepositCoalescer {
+	return &DepositCoalescer{
+		ws:       ws,
+		log:      log,
+		window:   window,
+		maxBatch: maxBatch,
+		pending:  make(map[uuid.UUID]*depositBatch),
+	}
+}
+
+func (c *DepositCoalescer) Deposit(ctx context.Context, walletID uuid.UUID, amount int64) (*models.Wallet, error) {
+	return c.Apply(ctx, models.OperationRequest{WalletID: walletID, Type: models.Deposit, Amount: amount})
+}
+
+func (c *DepositCoalescer) Withdraw(ctx context.Context, walletID uuid.UUID, amount int64) (*models.Wallet, error) {
+	return c.ws.Withdraw(ctx, walletID, amount)
+}
+
+// Apply coalesces req when it is a deposit without an idempotency key and
+// passes it through to the wallet service otherwise. A coalesced deposit whose
+// ctx ends before its batch is flushed is dropped; once the flush has started,
+// Apply waits for the batch and returns its outcome.
+func (c *DepositCoalescer) Apply(ctx context.Context, req models.OperationRequest) (*models.Wallet, error) {
+	if req.Type != models.Deposit || req.IdempotencyKey != "" {
+		return c.ws.Apply(ctx, req)
+	}
+
+	if err := validateOperationRequest(req); err != nil {
+		return nil, err
+	}
+
+	item := &pendingDeposit{ctx: ctx, req: req, done: make(chan depositResult, 1)}
+	if !c.enqueue(item) {
+		return c.ws.Apply(ctx, req)
+	}
+
+	select {
+	case result := <-item.done:
+		return result.wallet, result.err
+	case <-ctx.Done():
+		if c.withdraw(item) {
+			return nil, ctx.Err()
+		}
+	}
+
+	// The batch is already being applied and may commit the deposit: report
+	// its outcome rather than an error for a deposit that went through.
+	result := <-item.done
+	return result.wallet, result.err
+}
+
+// Close applies the deposits collected so far and waits until every batch
+// has completed. Deposits arriving afterwards are applied on their own.
+func (c *DepositCoalescer) Close() {
+	c.mu.Lock()
+	c.closed = true
+
+	batches := make([]*depositBatch, 0, len(c.pending))
+	for _, batch := range c.pending {
+		batch.timer.Stop()
+		batch.taken = true
+		batches = append(batches, batch)
+	}
+	clear(c.pending)
+	c.mu.Unlock()
+
+	for _, batch := range batches {
+		go c.flush(batch)
+	}
+
+	c.flushes.Wait()
+}
+
+// enqueue adds item to the open batch of its wallet, opening one if needed.
+// It reports false once the coalescer is closed.
+func (c *DepositCoalescer) enqueue(item *pendingDeposit) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return false
+	}
+
+	walletID := item.req.WalletID
+
+	batch, ok := c.pending[walletID]
+	if !ok {
+		batch = &depositBatch{walletID: walletID}
+		batch.timer = time.AfterFunc(c.window, func() {
+			if c.take(batch) {
+				c.flush(batch)
+			}
+		})
+
+		c.pending[walletID] = batch
+		c.flushes.Add(1)
+	}
+
+	item.batch = batch
+	batch.items = append(batch.items, item)
+
+	if len(batch.items) >= c.maxBatch && batch.timer.Stop() {
+		batch.taken = true
+		delete(c.pending, walletID)
+		go c.flush(batch)
+	}
+
+	return true
+}
+
+// withdraw removes item from its batch unless the batch is already being
+// flushed. It reports whether the item was removed.
+func (c *DepositCoalescer) withdraw(item *pendingDeposit) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	batch := item.batch
+	if batch.taken {
+		return false
+	}
+
+	batch.items = slices.DeleteFunc(batch.items, func(other *pendingDeposit) bool {
+		return other == item
+	})
+
+	return true
+}
+
+// take claims batch for flushing and closes it to new deposits. It reports
+// false when the batch has already been claimed.
+func (c *DepositCoalescer) take(batch *depositBatch) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if batch.taken {
+		return false
+	}
+
+	batch.taken = true
+	if c.pending[batch.walletID] == batch {
+		delete(c.pending, batch.walletID)
+	}
+
+	return true
+}
+
+func (c *DepositCoalescer) flush(batch *depositBatch) {
+	defer c.flushes.Done()
+
+	// Callers that gave up before the flush are left out of the batch.
+	items := make([]*pendingDeposit, 0, len(batch.items))
+	for _, item := range batch.items {
+		if err := item.ctx.Err(); err != nil {
+			item.done <- depositResult{err: err}
+			continue
+		}
+		items = append(items, item)
+	}
+
+	switch len(items) {
+	case 0:
+		return
+	case 1:
+		c.applyEach(items)
+		return
+	}
+
+	reqs := make([]models.OperationRequest, len(items))
+	for i, item := range items {
+		reqs[i] = item.req
+	}
+
+	// The shared transaction must outlive any single caller.
+	ctx := context.WithoutCancel(items[0].ctx)
+
+	wallets, err := c.ws.applyDeposits(ctx, batch.walletID, reqs)
+	if err != nil {
+		c.log.Debug("coalesced deposit failed, applying deposits one by one",
+			"wallet_id", batch.walletID,
+			"deposits", len(items),
+			"error", err,
+		)
+		c.applyEach(items)
+		return
+	}
+
+	for i, item := range items {
+		c.ws.observeOperation(item.req, wallets[i], false, nil)
+		item.done <- depositResult{wallet: wallets[i]}
+	}
+}
+
+// applyEach applies items as independent deposits.
+func (c *DepositCoalescer) applyEach(items []*pendingDeposit) {
+	var wg sync.WaitGroup
+	for _, item := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			wallet, err := c.ws.Apply(item.ctx, item.req)
+			item.done <- depositResult{wallet: wallet, err: err}
+		}()
+	}
+	wg.Wait()
+}
+
+// applyDeposits applies validated deposits to one wallet in a single
+// transaction, crediting their sum at once. It returns the wallet as left by
+// each deposit, in order.
+func (ws *ServiceWallet) applyDeposits(
+	ctx context.Context,
+	walletID uuid.UUID,
+	reqs []models.OperationRequest,
+) ([]*models.Wallet, error) {
+
+	var total int64
+	for _, req := range reqs {
+		total += req.Amount
+	}
+	combined := models.OperationRequest{WalletID: walletID, Type: models.Deposit, Amount: total}
+
+	var results []*models.Wallet
+	err := ws.txManager.ExecuteInTransaction(ctx, "coalesced_deposit", func(tx pgxdriver.QueryExecuter) error {
+		wallet, err := ws.walletBalanceUpdater.IncreaseBalance(ctx, tx, walletID, total)
+		if errors.Is(err, storage.ErrWalletSharded) {
+			wallet, err = ws.applyShardedTx(ctx, tx, combined)
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, req := range reqs {
+			if req.Currency != "" && req.Currency != wallet.Currency {
+				return services.ErrCurrencyMismatch
+			}
+		}
+
+		if err := ws.checkLimitsTx(ctx, tx, combined, wallet, int64(len(reqs))); err != nil {
+			return err
+		}
+
+		results = make([]*models.Wallet, len(reqs))
+		operations := make([]*models.Operation, len(reqs))
+
+		balance := wallet.Balance - total
+		for i, req := range reqs {
+			balance += req.Amount
+
+			after := *wallet
+			after.Balance = balance
+			after.AvailableBalance = balance - wallet.HeldBalance
+			results[i] = &after
+
+			operations[i] = &models.Operation{
+				ID:       uuid.New(),
+				WalletID: walletID,
+				Type:     models.Deposit,
+				Amount:   req.Amount,
+			}
+		}
+
+		pipe := pgxdriver.NewPipeline(tx)
+
+		if err := ws.operationSaver.CreateOperations(ctx, pipe, operations); err != nil {
+			return err
+		}
+
+		for i, operation := range operations {
+			err = ws.postEntryTx(ctx, pipe, models.EntryDeposit, operation.ID,
+				models.Posting{AccountID: walletID, Amount: operation.Amount},
+				models.Posting{AccountID: models.ExternalAccountFor(wallet.Currency), Amount: -operation.Amount},
+			)
+			if err != nil {
+				return err
+			}
+
+			if err := ws.enqueueBalanceChangedTx(ctx, pipe, operation, results[i]); err != nil {
+				return err
+			}
+		}
+
+		return pipe.Flush(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
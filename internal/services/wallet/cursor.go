@@ -0,0 +1,59 @@
+package wallet
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"wallet-service/internal/domain/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultOperationsLimit = 50
+	maxOperationsLimit     = 500
+)
+
+var errMalformedCursor = errors.New("malformed cursor")
+
+// encodeOperationCursor packs the keyset position into an opaque, URL-safe token.
+func encodeOperationCursor(cursor *models.OperationCursor) string {
+	raw := strconv.FormatInt(cursor.CreatedAt.UnixNano(), 10) + "_" + cursor.ID.String()
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeOperationCursor is the inverse of encodeOperationCursor.
+// An empty token means the first page and yields a nil cursor.
+func decodeOperationCursor(token string) (*models.OperationCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errMalformedCursor
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), "_")
+	if !ok {
+		return nil, errMalformedCursor
+	}
+
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, errMalformedCursor
+	}
+
+	operationID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, errMalformedCursor
+	}
+
+	return &models.OperationCursor{
+		CreatedAt: time.Unix(0, unixNano).UTC(),
+		ID:        operationID,
+	}, nil
+}
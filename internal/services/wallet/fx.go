@@ -0,0 +1,326 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/lib/fx"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	defaultQuoteTTL = 30 * time.Second
+
+	// rateScale is the number of decimal places a quoted rate is kept with;
+	// it matches the scale of the rate columns.
+	rateScale = 12
+)
+
+type FXRateProvider interface {
+	Rate(ctx context.Context, from, to string) (*big.Rat, error)
+}
+
+type QuoteStore interface {
+	CreateQuote(ctx context.Context, quote *models.FXQuote) (*models.FXQuote, error)
+	GetQuoteForUpdate(ctx context.Context, tx pgxdriver.QueryExecuter, id uuid.UUID) (*models.FXQuote, error)
+	MarkQuoteUsed(ctx context.Context, tx pgxdriver.QueryExecuter, id uuid.UUID) error
+}
+
+// Quote locks the current rate between two currencies for the quote TTL.
+func (ws *ServiceWallet) Quote(ctx context.Context, fromCurrency, toCurrency string) (*models.FXQuote, error) {
+	const op = "services.wallet.Quote"
+
+	ctx, span := startSpan(ctx, "Quote",
+		attribute.String("fx.from_currency", fromCurrency),
+		attribute.String("fx.to_currency", toCurrency),
+	)
+	defer span.End()
+
+	if _, ok := models.LookupCurrency(fromCurrency); !ok {
+		return nil, services.ErrUnsupportedCurrency
+	}
+	if _, ok := models.LookupCurrency(toCurrency); !ok {
+		return nil, services.ErrUnsupportedCurrency
+	}
+	if fromCurrency == toCurrency {
+		return nil, services.ErrSameCurrency
+	}
+
+	rate, err := ws.fxRates.Rate(ctx, fromCurrency, toCurrency)
+	if err != nil {
+		if errors.Is(err, fx.ErrRateNotFound) {
+			return nil, services.ErrRateUnavailable
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	ttl := ws.quoteTTL
+	if ttl <= 0 {
+		ttl = defaultQuoteTTL
+	}
+
+	quote, err := ws.quoteStore.CreateQuote(ctx, &models.FXQuote{
+		ID:           uuid.New(),
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		Rate:         formatRate(rate),
+		ExpiresAt:    time.Now().Add(ttl),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return quote, nil
+}
+
+// Exchange debits amount from one wallet and credits its converted value to
+// a wallet in another currency at the rate locked by quoteID. The quote is
+// consumed; both operations record the rate used.
+func (ws *ServiceWallet) Exchange(
+	ctx context.Context,
+	quoteID uuid.UUID,
+	fromWalletID uuid.UUID,
+	toWalletID uuid.UUID,
+	amount int64,
+) (*models.Exchange, error) {
+	const op = "services.wallet.Exchange"
+
+	ctx, span := startSpan(ctx, "Exchange",
+		uuidAttr("fx.quote_id", quoteID),
+		uuidAttr("wallet.from_id", fromWalletID),
+		uuidAttr("wallet.to_id", toWalletID),
+	)
+	defer span.End()
+
+	if amount <= 0 {
+		return nil, services.ErrAmountNegativeValue
+	}
+
+	if quoteID == uuid.Nil {
+		return nil, services.ErrInvalidQuoteID
+	}
+
+	if fromWalletID == uuid.Nil || toWalletID == uuid.Nil {
+		return nil, services.ErrInvalidWalletID
+	}
+
+	if fromWalletID == toWalletID {
+		return nil, services.ErrSameWallet
+	}
+
+	var result *models.Exchange
+	err := ws.txManager.ExecuteInTransaction(ctx, "exchange", func(tx pgxdriver.QueryExecuter) error {
+		quote, err := ws.usableQuoteTx(ctx, tx, quoteID)
+		if err != nil {
+			return err
+		}
+
+		locked, err := ws.walletLocker.LockWallets(ctx, tx, fromWalletID, toWalletID)
+		if err != nil {
+			return err
+		}
+		for _, wallet := range locked {
+			expected := quote.ToCurrency
+			if wallet.ID == fromWalletID {
+				expected = quote.FromCurrency
+			}
+			if wallet.Currency != expected {
+				return services.ErrCurrencyMismatch
+			}
+		}
+
+		credited, err := convertAmount(amount, quote)
+		if err != nil {
+			return err
+		}
+
+		fromWallet, err := ws.decreaseBalanceTx(ctx, tx, fromWalletID, amount)
+		if err != nil {
+			if errors.Is(err, storage.ErrInsufficientFunds) {
+				return storage.ErrInsufficientFunds
+			}
+			return err
+		}
+
+		toWallet, err := ws.increaseBalanceTx(ctx, tx, toWalletID, credited)
+		if err != nil {
+			return err
+		}
+
+		err = ws.checkLimitsTx(ctx, tx, models.OperationRequest{
+			WalletID: fromWalletID,
+			Type:     models.ExchangeOut,
+			Amount:   amount,
+		}, fromWallet, 1)
+		if err != nil {
+			return err
+		}
+
+		err = ws.checkLimitsTx(ctx, tx, models.OperationRequest{
+			WalletID: toWalletID,
+			Type:     models.ExchangeIn,
+			Amount:   credited,
+		}, toWallet, 1)
+		if err != nil {
+			return err
+		}
+
+		exchangeID := uuid.New()
+
+		operations := []*models.Operation{
+			{
+				ID:         uuid.New(),
+				WalletID:   fromWalletID,
+				Type:       models.ExchangeOut,
+				Amount:     amount,
+				TransferID: &exchangeID,
+				FXRate:     &quote.Rate,
+			},
+			{
+				ID:         uuid.New(),
+				WalletID:   toWalletID,
+				Type:       models.ExchangeIn,
+				Amount:     credited,
+				TransferID: &exchangeID,
+				FXRate:     &quote.Rate,
+			},
+		}
+
+		for i, wallet := range []*models.Wallet{fromWallet, toWallet} {
+			if err := ws.saveOperationTx(ctx, tx, operations[i], wallet); err != nil {
+				return err
+			}
+		}
+
+		postings, err := ws.conversionPostingsTx(ctx, tx, []models.Posting{
+			{AccountID: fromWalletID, Amount: -amount},
+			{AccountID: toWalletID, Amount: credited},
+		}, quote.FromCurrency, quote.ToCurrency, amount, credited)
+		if err != nil {
+			return err
+		}
+
+		if err := ws.postEntryTx(ctx, tx, models.EntryExchange, exchangeID, postings...); err != nil {
+			return err
+		}
+
+		if err := ws.quoteStore.MarkQuoteUsed(ctx, tx, quote.ID); err != nil {
+			return err
+		}
+
+		result = &models.Exchange{
+			ID:             exchangeID,
+			QuoteID:        quote.ID,
+			Rate:           quote.Rate,
+			From:           fromWallet,
+			To:             toWallet,
+			DebitedAmount:  amount,
+			CreditedAmount: credited,
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		for _, known := range []error{
+			storage.ErrInsufficientFunds,
+			storage.ErrQuoteNotFound,
+			services.ErrQuoteUsed,
+			services.ErrQuoteExpired,
+			services.ErrCurrencyMismatch,
+			services.ErrExchangeAmountTooSmall,
+		} {
+			if errors.Is(err, known) {
+				return nil, known
+			}
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// usableQuoteTx locks the quote and checks that it is neither used nor
+// expired. The caller marks it used in the same transaction.
+func (ws *ServiceWallet) usableQuoteTx(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	quoteID uuid.UUID,
+) (*models.FXQuote, error) {
+
+	quote, err := ws.quoteStore.GetQuoteForUpdate(ctx, tx, quoteID)
+	if err != nil {
+		return nil, err
+	}
+	if quote.UsedAt != nil {
+		return nil, services.ErrQuoteUsed
+	}
+	if !time.Now().Before(quote.ExpiresAt) {
+		return nil, services.ErrQuoteExpired
+	}
+
+	return quote, nil
+}
+
+// convertAmount converts minor units of the quote's source currency into
+// minor units of its target currency, rounding down so the service never
+// credits more than the rate allows.
+func convertAmount(amount int64, quote *models.FXQuote) (int64, error) {
+	from, ok := models.LookupCurrency(quote.FromCurrency)
+	if !ok {
+		return 0, services.ErrUnsupportedCurrency
+	}
+	to, ok := models.LookupCurrency(quote.ToCurrency)
+	if !ok {
+		return 0, services.ErrUnsupportedCurrency
+	}
+
+	rate, ok := new(big.Rat).SetString(quote.Rate)
+	if !ok {
+		return 0, fmt.Errorf("malformed quote rate %q", quote.Rate)
+	}
+
+	value := new(big.Rat).Mul(big.NewRat(amount, 1), rate)
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(to.Exponent-from.Exponent))), nil)
+	if to.Exponent >= from.Exponent {
+		value.Mul(value, new(big.Rat).SetInt(scale))
+	} else {
+		value.Quo(value, new(big.Rat).SetInt(scale))
+	}
+
+	credited := new(big.Int).Quo(value.Num(), value.Denom())
+	if !credited.IsInt64() {
+		return 0, services.ErrAmountTooLarge
+	}
+	if credited.Sign() <= 0 {
+		return 0, services.ErrExchangeAmountTooSmall
+	}
+
+	return credited.Int64(), nil
+}
+
+// formatRate renders a rate with rateScale decimals and no trailing zeros.
+func formatRate(rate *big.Rat) string {
+	s := rate.FloatString(rateScale)
+	s = strings.TrimRight(s, "0")
+
+	return strings.TrimSuffix(s, ".")
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
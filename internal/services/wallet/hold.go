@@ -0,0 +1,300 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultHoldTTL = 15 * time.Minute
+	maxHoldTTL     = 7 * 24 * time.Hour
+)
+
+type HoldStore interface {
+	CreateHold(ctx context.Context, tx pgxdriver.QueryExecuter, hold *models.Hold) (*models.Hold, error)
+	GetHoldForUpdate(ctx context.Context, tx pgxdriver.QueryExecuter, id uuid.UUID) (*models.Hold, error)
+	UpdateHoldStatus(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		id uuid.UUID,
+		status models.HoldStatus,
+		capturedAmount int64,
+	) (*models.Hold, error)
+	GetExpiredHoldsForUpdate(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		now time.Time,
+		limit int,
+	) ([]*models.Hold, error)
+}
+
+type HoldBalanceUpdater interface {
+	HoldBalance(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		walletID uuid.UUID,
+		amount int64,
+	) (*models.Wallet, error)
+	SettleHeldBalance(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		walletID uuid.UUID,
+		held int64,
+	) (*models.Wallet, error)
+	CaptureHeldBalance(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		walletID uuid.UUID,
+		held int64,
+		captured int64,
+	) (*models.Wallet, error)
+}
+
+// Authorize reserves amount on the wallet for ttl (defaultHoldTTL when zero).
+// Reserved funds stay in the balance but are no longer available to withdraw.
+func (ws *ServiceWallet) Authorize(
+	ctx context.Context,
+	walletID uuid.UUID,
+	amount int64,
+	ttl time.Duration,
+) (*models.HoldResult, error) {
+	const op = "services.wallet.Authorize"
+
+	ctx, span := startSpan(ctx, "Authorize", uuidAttr("wallet.id", walletID))
+	defer span.End()
+
+	if amount <= 0 {
+		return nil, services.ErrAmountNegativeValue
+	}
+
+	if walletID == uuid.Nil {
+		return nil, services.ErrInvalidWalletID
+	}
+
+	if ttl == 0 {
+		ttl = defaultHoldTTL
+	}
+	if ttl < 0 || ttl > maxHoldTTL {
+		return nil, services.ErrInvalidHoldTTL
+	}
+
+	var result *models.HoldResult
+	err := ws.txManager.ExecuteInTransaction(ctx, "authorize", func(tx pgxdriver.QueryExecuter) error {
+		wallet, err := ws.holdBalanceTx(ctx, tx, walletID, amount)
+		if err != nil {
+			return err
+		}
+
+		hold, err := ws.holdStore.CreateHold(ctx, tx, &models.Hold{
+			ID:        uuid.New(),
+			WalletID:  walletID,
+			Amount:    amount,
+			Status:    models.HoldActive,
+			ExpiresAt: time.Now().Add(ttl),
+		})
+		if err != nil {
+			return err
+		}
+
+		result = &models.HoldResult{Hold: hold, Wallet: wallet}
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// Capture debits amount (the whole hold when zero) from the wallet and
+// releases the rest of the reservation.
+func (ws *ServiceWallet) Capture(ctx context.Context, holdID uuid.UUID, amount int64) (*models.HoldResult, error) {
+	const op = "services.wallet.Capture"
+
+	ctx, span := startSpan(ctx, "Capture", uuidAttr("hold.id", holdID))
+	defer span.End()
+
+	if amount < 0 {
+		return nil, services.ErrAmountNegativeValue
+	}
+
+	var result *models.HoldResult
+	err := ws.txManager.ExecuteInTransaction(ctx, "capture", func(tx pgxdriver.QueryExecuter) error {
+		hold, err := ws.activeHoldTx(ctx, tx, holdID)
+		if err != nil {
+			return err
+		}
+
+		captured := amount
+		if captured == 0 {
+			captured = hold.Amount
+		}
+		if captured > hold.Amount {
+			return services.ErrCaptureExceedsHold
+		}
+
+		wallet, err := ws.holdBalanceUpdater.CaptureHeldBalance(ctx, tx, hold.WalletID, hold.Amount, captured)
+		if err != nil {
+			return err
+		}
+
+		err = ws.checkLimitsTx(ctx, tx, models.OperationRequest{
+			WalletID: hold.WalletID,
+			Type:     models.Capture,
+			Amount:   captured,
+		}, wallet, 1)
+		if err != nil {
+			return err
+		}
+
+		hold, err = ws.holdStore.UpdateHoldStatus(ctx, tx, hold.ID, models.HoldCaptured, captured)
+		if err != nil {
+			return err
+		}
+
+		operation := &models.Operation{
+			ID:       uuid.New(),
+			WalletID: hold.WalletID,
+			Type:     models.Capture,
+			Amount:   captured,
+			HoldID:   &hold.ID,
+		}
+		if err := ws.saveOperationTx(ctx, tx, operation, wallet); err != nil {
+			return err
+		}
+
+		err = ws.postEntryTx(ctx, tx, models.EntryCapture, operation.ID,
+			models.Posting{AccountID: hold.WalletID, Amount: -captured},
+			models.Posting{AccountID: models.ExternalAccountFor(wallet.Currency), Amount: captured},
+		)
+		if err != nil {
+			return err
+		}
+
+		result = &models.HoldResult{Hold: hold, Wallet: wallet}
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// Void releases an active hold without debiting the wallet.
+func (ws *ServiceWallet) Void(ctx context.Context, holdID uuid.UUID) (*models.HoldResult, error) {
+	const op = "services.wallet.Void"
+
+	ctx, span := startSpan(ctx, "Void", uuidAttr("hold.id", holdID))
+	defer span.End()
+
+	var result *models.HoldResult
+	err := ws.txManager.ExecuteInTransaction(ctx, "void", func(tx pgxdriver.QueryExecuter) error {
+		hold, err := ws.holdStore.GetHoldForUpdate(ctx, tx, holdID)
+		if err != nil {
+			return err
+		}
+
+		if hold.Status != models.HoldActive {
+			return services.ErrHoldNotActive
+		}
+
+		result, err = ws.releaseHoldTx(ctx, tx, hold, models.HoldVoided)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
+
+// ExpireHolds releases up to batchSize active holds whose ttl has passed
+// and reports how many were released.
+func (ws *ServiceWallet) ExpireHolds(ctx context.Context, batchSize int) (int, error) {
+	const op = "services.wallet.ExpireHolds"
+
+	ctx, span := startSpan(ctx, "ExpireHolds")
+	defer span.End()
+
+	var expired int
+	err := ws.txManager.ExecuteInTransaction(ctx, "expire_holds", func(tx pgxdriver.QueryExecuter) error {
+		expired = 0
+
+		holds, err := ws.holdStore.GetExpiredHoldsForUpdate(ctx, tx, time.Now(), batchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, hold := range holds {
+			if _, err := ws.releaseHoldTx(ctx, tx, hold, models.HoldExpired); err != nil {
+				return err
+			}
+			expired++
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return expired, nil
+}
+
+func (ws *ServiceWallet) activeHoldTx(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	holdID uuid.UUID,
+) (*models.Hold, error) {
+
+	hold, err := ws.holdStore.GetHoldForUpdate(ctx, tx, holdID)
+	if err != nil {
+		if errors.Is(err, storage.ErrHoldNotFound) {
+			return nil, storage.ErrHoldNotFound
+		}
+		return nil, err
+	}
+
+	if hold.Status != models.HoldActive {
+		return nil, services.ErrHoldNotActive
+	}
+
+	if !time.Now().Before(hold.ExpiresAt) {
+		return nil, services.ErrHoldExpired
+	}
+
+	return hold, nil
+}
+
+func (ws *ServiceWallet) releaseHoldTx(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	hold *models.Hold,
+	status models.HoldStatus,
+) (*models.HoldResult, error) {
+
+	wallet, err := ws.holdBalanceUpdater.SettleHeldBalance(ctx, tx, hold.WalletID, hold.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	hold, err = ws.holdStore.UpdateHoldStatus(ctx, tx, hold.ID, status, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.HoldResult{Hold: hold, Wallet: wallet}, nil
+}
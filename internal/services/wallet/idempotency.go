@@ -0,0 +1,122 @@
+package wallet
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+)
+
+// hashOperationRequest fingerprints the payload bound to an idempotency key,
+// so a replay with the same key but a different payload can be rejected.
+// An empty currency is left out, keeping hashes of older keys stable.
+func hashOperationRequest(req models.OperationRequest) string {
+	h := sha256.New()
+	h.Write(req.WalletID[:])
+	h.Write([]byte(req.Type))
+	h.Write([]byte(strconv.FormatInt(req.Amount, 10)))
+	h.Write([]byte(req.Currency))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// lookupIdempotencyTx returns the stored result for key, or nil if the key
+// has not been used yet.
+func (ws *ServiceWallet) lookupIdempotencyTx(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	key string,
+	requestHash string,
+) (*models.Wallet, error) {
+
+	if key == "" {
+		return nil, nil
+	}
+
+	record, err := ws.idempotencyStore.GetIdempotencyRecord(ctx, tx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrIdempotencyKeyNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if record.RequestHash != requestHash {
+		return nil, services.ErrIdempotencyKeyReused
+	}
+
+	return &models.Wallet{
+		ID:               record.WalletID,
+		Balance:          record.Balance,
+		HeldBalance:      record.HeldBalance,
+		AvailableBalance: record.Balance - record.HeldBalance,
+		Currency:         record.Currency,
+		UpdatedAt:        record.WalletUpdatedAt,
+	}, nil
+}
+
+func (ws *ServiceWallet) saveIdempotencyTx(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	key string,
+	requestHash string,
+	operation *models.Operation,
+	wallet *models.Wallet,
+) error {
+
+	if key == "" {
+		return nil
+	}
+
+	return ws.idempotencyStore.SaveIdempotencyRecord(ctx, tx, &models.IdempotencyRecord{
+		Key:             key,
+		WalletID:        operation.WalletID,
+		RequestHash:     requestHash,
+		OperationID:     operation.ID,
+		Balance:         wallet.Balance,
+		HeldBalance:     wallet.HeldBalance,
+		Currency:        wallet.Currency,
+		WalletUpdatedAt: wallet.UpdatedAt,
+	})
+}
+
+// replayIdempotency is used after a concurrent request with the same key won
+// the race to insert it: the losing transaction has been rolled back, so the
+// stored result of the winner is returned instead.
+func (ws *ServiceWallet) replayIdempotency(
+	ctx context.Context,
+	op string,
+	key string,
+	requestHash string,
+) (*models.Wallet, error) {
+
+	var result *models.Wallet
+	err := ws.txManager.ExecuteInTransaction(ctx, "idempotency_replay", func(tx pgxdriver.QueryExecuter) error {
+		replayed, err := ws.lookupIdempotencyTx(ctx, tx, key, requestHash)
+		if err != nil {
+			return err
+		}
+		if replayed == nil {
+			return storage.ErrIdempotencyKeyNotFound
+		}
+
+		result = replayed
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, services.ErrIdempotencyKeyReused) {
+			return nil, services.ErrIdempotencyKeyReused
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,175 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+
+	"github.com/google/uuid"
+)
+
+const defaultOperationWindow = time.Hour
+
+// outflowTypes are the operations checked against the withdrawal limits,
+// the same ones the limit store sums into the withdrawal usage.
+var outflowTypes = []models.OperationType{
+	models.Withdraw,
+	models.TransferOut,
+	models.Capture,
+	models.ExchangeOut,
+}
+
+type LimitStore interface {
+	GetWalletLimits(ctx context.Context, tx pgxdriver.QueryExecuter, walletID uuid.UUID) (*models.LimitOverrides, error)
+	SetWalletLimits(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		walletID uuid.UUID,
+		overrides *models.LimitOverrides,
+	) error
+	GetLimitUsage(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		walletID uuid.UUID,
+		dayStart time.Time,
+		monthStart time.Time,
+		windowStart time.Time,
+	) (*models.LimitUsage, error)
+}
+
+// SetLimits replaces the limit overrides of a wallet and returns the limits
+// now in effect for it. Fields left nil fall back to the global defaults.
+func (ws *ServiceWallet) SetLimits(
+	ctx context.Context,
+	walletID uuid.UUID,
+	overrides *models.LimitOverrides,
+) (*models.Limits, error) {
+
+	const op = "services.wallet.SetLimits"
+
+	ctx, span := startSpan(ctx, "SetLimits", uuidAttr("wallet.id", walletID))
+	defer span.End()
+
+	if walletID == uuid.Nil {
+		return nil, services.ErrInvalidWalletID
+	}
+
+	if !validOverrides(overrides) {
+		return nil, services.ErrInvalidLimits
+	}
+
+	err := ws.txManager.ExecuteInTransaction(ctx, "set_wallet_limits", func(tx pgxdriver.QueryExecuter) error {
+		if _, err := ws.walletLocker.LockWallets(ctx, tx, walletID); err != nil {
+			return err
+		}
+
+		return ws.limitStore.SetWalletLimits(ctx, tx, walletID, overrides)
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrWalletNotFound) {
+			return nil, storage.ErrWalletNotFound
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	limits := overrides.Resolve(ws.limits)
+
+	return &limits, nil
+}
+
+func validOverrides(overrides *models.LimitOverrides) bool {
+	if overrides == nil {
+		return false
+	}
+
+	for _, value := range []*int64{
+		overrides.MaxSingleWithdrawal,
+		overrides.DailyWithdrawal,
+		overrides.MonthlyWithdrawal,
+		overrides.MaxBalance,
+		overrides.MaxOperations,
+	} {
+		if value != nil && *value < 0 {
+			return false
+		}
+	}
+
+	return overrides.OperationWindowSeconds == nil || *overrides.OperationWindowSeconds > 0
+}
+
+// checkLimitsTx verifies that the operation described by req, already applied
+// to wallet, stays within the limits of the wallet. It must run after the
+// balance update: the updated wallet row stays locked until the transaction
+// ends, so concurrent operations on the same wallet see each other's usage
+// and cannot race past a limit together. req may stand for several
+// operations applied together, operations gives their number.
+func (ws *ServiceWallet) checkLimitsTx(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	req models.OperationRequest,
+	wallet *models.Wallet,
+	operations int64,
+) error {
+
+	overrides, err := ws.limitStore.GetWalletLimits(ctx, tx, req.WalletID)
+	if err != nil {
+		return err
+	}
+	limits := overrides.Resolve(ws.limits)
+
+	withdrawal := slices.Contains(outflowTypes, req.Type)
+
+	if !withdrawal && limits.MaxBalance > 0 && wallet.Balance > limits.MaxBalance {
+		return limitExceeded(models.LimitMaxBalance, limits.MaxBalance, wallet.Balance)
+	}
+
+	if withdrawal && limits.MaxSingleWithdrawal > 0 && req.Amount > limits.MaxSingleWithdrawal {
+		return limitExceeded(models.LimitMaxSingleWithdrawal, limits.MaxSingleWithdrawal, req.Amount)
+	}
+
+	needsTotals := withdrawal && (limits.DailyWithdrawal > 0 || limits.MonthlyWithdrawal > 0)
+	if !needsTotals && limits.MaxOperations == 0 {
+		return nil
+	}
+
+	window := limits.OperationWindow
+	if window <= 0 {
+		window = defaultOperationWindow
+	}
+
+	now := time.Now().UTC()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	usage, err := ws.limitStore.GetLimitUsage(ctx, tx, req.WalletID, dayStart, monthStart, now.Add(-window))
+	if err != nil {
+		return err
+	}
+
+	// The usage does not include the current operation yet: it is recorded
+	// only after the limits pass.
+	if withdrawal && limits.DailyWithdrawal > 0 && usage.WithdrawnToday+req.Amount > limits.DailyWithdrawal {
+		return limitExceeded(models.LimitDailyWithdrawal, limits.DailyWithdrawal, usage.WithdrawnToday+req.Amount)
+	}
+
+	if withdrawal && limits.MonthlyWithdrawal > 0 && usage.WithdrawnThisMonth+req.Amount > limits.MonthlyWithdrawal {
+		return limitExceeded(models.LimitMonthlyWithdrawal, limits.MonthlyWithdrawal, usage.WithdrawnThisMonth+req.Amount)
+	}
+
+	if limits.MaxOperations > 0 && usage.OperationsInWindow+operations > limits.MaxOperations {
+		return limitExceeded(models.LimitMaxOperations, limits.MaxOperations, usage.OperationsInWindow+operations)
+	}
+
+	return nil
+}
+
+func limitExceeded(limit string, max, attempted int64) error {
+	return &services.LimitExceededError{Limit: limit, Max: max, Attempted: attempted}
+}
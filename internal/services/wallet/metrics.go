@@ -0,0 +1,57 @@
+package wallet
+
+import (
+	"errors"
+	"strings"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/lib/metrics"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+)
+
+// observeOperation counts a deposit or withdrawal and its amount by outcome.
+// wallet is the result of the operation; the amount is counted in its
+// currency, so operations that did not reach a wallet count without one.
+func (ws *ServiceWallet) observeOperation(req models.OperationRequest, wallet *models.Wallet, replayed bool, err error) {
+	// The type comes from the client; anything else would add a series per value.
+	opType := "unknown"
+	if req.Type == models.Deposit || req.Type == models.Withdraw {
+		opType = strings.ToLower(string(req.Type))
+	}
+
+	outcome := operationOutcome(err)
+	if err == nil && replayed {
+		outcome = metrics.OutcomeReplayed
+	}
+
+	var currency string
+	if wallet != nil {
+		currency = wallet.Currency
+	}
+
+	ws.metrics.ObserveOperation(opType, outcome, currency, req.Amount)
+}
+
+func operationOutcome(err error) string {
+	switch {
+	case err == nil:
+		return metrics.OutcomeSuccess
+	case errors.Is(err, storage.ErrInsufficientFunds):
+		return metrics.OutcomeInsufficientFunds
+	case errors.Is(err, services.ErrLimitExceeded):
+		return metrics.OutcomeLimitExceeded
+	case errors.Is(err, storage.ErrWalletNotFound):
+		return metrics.OutcomeWalletNotFound
+	case errors.Is(err, storage.ErrWalletFrozen), errors.Is(err, storage.ErrWalletClosed):
+		return metrics.OutcomeWalletInactive
+	case errors.Is(err, services.ErrAmountNegativeValue),
+		errors.Is(err, services.ErrInvalidOperationType),
+		errors.Is(err, services.ErrInvalidWalletID),
+		errors.Is(err, services.ErrUnsupportedCurrency),
+		errors.Is(err, services.ErrCurrencyMismatch),
+		errors.Is(err, services.ErrIdempotencyKeyReused):
+		return metrics.OutcomeRejected
+	default:
+		return metrics.OutcomeError
+	}
+}
@@ -0,0 +1,59 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/wallet/balance.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/wallet/balance.go -destination=internal/services/wallet/mocks/mock_balance.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	models "wallet-service/internal/domain/models"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBalanceHistory is a mock of BalanceHistory interface.
+type MockBalanceHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockBalanceHistoryMockRecorder
+	isgomock struct{}
+}
+
+// MockBalanceHistoryMockRecorder is the mock recorder for MockBalanceHistory.
+type MockBalanceHistoryMockRecorder struct {
+	mock *MockBalanceHistory
+}
+
+// NewMockBalanceHistory creates a new mock instance.
+func NewMockBalanceHistory(ctrl *gomock.Controller) *MockBalanceHistory {
+	mock := &MockBalanceHistory{ctrl: ctrl}
+	mock.recorder = &MockBalanceHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBalanceHistory) EXPECT() *MockBalanceHistoryMockRecorder {
+	return m.recorder
+}
+
+// GetBalancesAt mocks base method.
+func (m *MockBalanceHistory) GetBalancesAt(ctx context.Context, walletID uuid.UUID, at []time.Time) ([]models.BalancePoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalancesAt", ctx, walletID, at)
+	ret0, _ := ret[0].([]models.BalancePoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalancesAt indicates an expected call of GetBalancesAt.
+func (mr *MockBalanceHistoryMockRecorder) GetBalancesAt(ctx, walletID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalancesAt", reflect.TypeOf((*MockBalanceHistory)(nil).GetBalancesAt), ctx, walletID, at)
+}
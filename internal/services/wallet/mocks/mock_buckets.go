@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/wallet/buckets.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/wallet/buckets.go -destination=internal/services/wallet/mocks/mock_buckets.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+	pgx_driver "wallet-service/pkg/pgx-driver"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBucketBalanceUpdater is a mock of BucketBalanceUpdater interface.
+type MockBucketBalanceUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockBucketBalanceUpdaterMockRecorder
+	isgomock struct{}
+}
+
+// MockBucketBalanceUpdaterMockRecorder is the mock recorder for MockBucketBalanceUpdater.
+type MockBucketBalanceUpdaterMockRecorder struct {
+	mock *MockBucketBalanceUpdater
+}
+
+// NewMockBucketBalanceUpdater creates a new mock instance.
+func NewMockBucketBalanceUpdater(ctrl *gomock.Controller) *MockBucketBalanceUpdater {
+	mock := &MockBucketBalanceUpdater{ctrl: ctrl}
+	mock.recorder = &MockBucketBalanceUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBucketBalanceUpdater) EXPECT() *MockBucketBalanceUpdaterMockRecorder {
+	return m.recorder
+}
+
+// ConsolidateBalance mocks base method.
+func (m *MockBucketBalanceUpdater) ConsolidateBalance(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsolidateBalance", ctx, tx, walletID)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsolidateBalance indicates an expected call of ConsolidateBalance.
+func (mr *MockBucketBalanceUpdaterMockRecorder) ConsolidateBalance(ctx, tx, walletID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsolidateBalance", reflect.TypeOf((*MockBucketBalanceUpdater)(nil).ConsolidateBalance), ctx, tx, walletID)
+}
+
+// DecreaseBucketBalance mocks base method.
+func (m *MockBucketBalanceUpdater) DecreaseBucketBalance(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID, amount int64) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecreaseBucketBalance", ctx, tx, walletID, amount)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecreaseBucketBalance indicates an expected call of DecreaseBucketBalance.
+func (mr *MockBucketBalanceUpdaterMockRecorder) DecreaseBucketBalance(ctx, tx, walletID, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecreaseBucketBalance", reflect.TypeOf((*MockBucketBalanceUpdater)(nil).DecreaseBucketBalance), ctx, tx, walletID, amount)
+}
+
+// IncreaseBucketBalance mocks base method.
+func (m *MockBucketBalanceUpdater) IncreaseBucketBalance(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID, amount int64) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncreaseBucketBalance", ctx, tx, walletID, amount)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncreaseBucketBalance indicates an expected call of IncreaseBucketBalance.
+func (mr *MockBucketBalanceUpdaterMockRecorder) IncreaseBucketBalance(ctx, tx, walletID, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseBucketBalance", reflect.TypeOf((*MockBucketBalanceUpdater)(nil).IncreaseBucketBalance), ctx, tx, walletID, amount)
+}
+
+// SetBalanceBuckets mocks base method.
+func (m *MockBucketBalanceUpdater) SetBalanceBuckets(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID, buckets int) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBalanceBuckets", ctx, tx, walletID, buckets)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBalanceBuckets indicates an expected call of SetBalanceBuckets.
+func (mr *MockBucketBalanceUpdaterMockRecorder) SetBalanceBuckets(ctx, tx, walletID, buckets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBalanceBuckets", reflect.TypeOf((*MockBucketBalanceUpdater)(nil).SetBalanceBuckets), ctx, tx, walletID, buckets)
+}
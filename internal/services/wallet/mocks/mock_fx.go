@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/wallet/fx.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/wallet/fx.go -destination=internal/services/wallet/mocks/mock_fx.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+	pgx_driver "wallet-service/pkg/pgx-driver"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFXRateProvider is a mock of FXRateProvider interface.
+type MockFXRateProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockFXRateProviderMockRecorder
+	isgomock struct{}
+}
+
+// MockFXRateProviderMockRecorder is the mock recorder for MockFXRateProvider.
+type MockFXRateProviderMockRecorder struct {
+	mock *MockFXRateProvider
+}
+
+// NewMockFXRateProvider creates a new mock instance.
+func NewMockFXRateProvider(ctrl *gomock.Controller) *MockFXRateProvider {
+	mock := &MockFXRateProvider{ctrl: ctrl}
+	mock.recorder = &MockFXRateProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFXRateProvider) EXPECT() *MockFXRateProviderMockRecorder {
+	return m.recorder
+}
+
+// Rate mocks base method.
+func (m *MockFXRateProvider) Rate(ctx context.Context, from, to string) (*big.Rat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rate", ctx, from, to)
+	ret0, _ := ret[0].(*big.Rat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockFXRateProviderMockRecorder) Rate(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockFXRateProvider)(nil).Rate), ctx, from, to)
+}
+
+// MockQuoteStore is a mock of QuoteStore interface.
+type MockQuoteStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuoteStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockQuoteStoreMockRecorder is the mock recorder for MockQuoteStore.
+type MockQuoteStoreMockRecorder struct {
+	mock *MockQuoteStore
+}
+
+// NewMockQuoteStore creates a new mock instance.
+func NewMockQuoteStore(ctrl *gomock.Controller) *MockQuoteStore {
+	mock := &MockQuoteStore{ctrl: ctrl}
+	mock.recorder = &MockQuoteStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQuoteStore) EXPECT() *MockQuoteStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateQuote mocks base method.
+func (m *MockQuoteStore) CreateQuote(ctx context.Context, quote *models.FXQuote) (*models.FXQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateQuote", ctx, quote)
+	ret0, _ := ret[0].(*models.FXQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateQuote indicates an expected call of CreateQuote.
+func (mr *MockQuoteStoreMockRecorder) CreateQuote(ctx, quote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateQuote", reflect.TypeOf((*MockQuoteStore)(nil).CreateQuote), ctx, quote)
+}
+
+// GetQuoteForUpdate mocks base method.
+func (m *MockQuoteStore) GetQuoteForUpdate(ctx context.Context, tx pgx_driver.QueryExecuter, id uuid.UUID) (*models.FXQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuoteForUpdate", ctx, tx, id)
+	ret0, _ := ret[0].(*models.FXQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuoteForUpdate indicates an expected call of GetQuoteForUpdate.
+func (mr *MockQuoteStoreMockRecorder) GetQuoteForUpdate(ctx, tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuoteForUpdate", reflect.TypeOf((*MockQuoteStore)(nil).GetQuoteForUpdate), ctx, tx, id)
+}
+
+// MarkQuoteUsed mocks base method.
+func (m *MockQuoteStore) MarkQuoteUsed(ctx context.Context, tx pgx_driver.QueryExecuter, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkQuoteUsed", ctx, tx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkQuoteUsed indicates an expected call of MarkQuoteUsed.
+func (mr *MockQuoteStoreMockRecorder) MarkQuoteUsed(ctx, tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkQuoteUsed", reflect.TypeOf((*MockQuoteStore)(nil).MarkQuoteUsed), ctx, tx, id)
+}
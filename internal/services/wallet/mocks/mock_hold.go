@@ -0,0 +1,174 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/wallet/hold.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/wallet/hold.go -destination=internal/services/wallet/mocks/mock_hold.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	models "wallet-service/internal/domain/models"
+	pgx_driver "wallet-service/pkg/pgx-driver"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHoldStore is a mock of HoldStore interface.
+type MockHoldStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockHoldStoreMockRecorder is the mock recorder for MockHoldStore.
+type MockHoldStoreMockRecorder struct {
+	mock *MockHoldStore
+}
+
+// NewMockHoldStore creates a new mock instance.
+func NewMockHoldStore(ctrl *gomock.Controller) *MockHoldStore {
+	mock := &MockHoldStore{ctrl: ctrl}
+	mock.recorder = &MockHoldStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldStore) EXPECT() *MockHoldStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateHold mocks base method.
+func (m *MockHoldStore) CreateHold(ctx context.Context, tx pgx_driver.QueryExecuter, hold *models.Hold) (*models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHold", ctx, tx, hold)
+	ret0, _ := ret[0].(*models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHold indicates an expected call of CreateHold.
+func (mr *MockHoldStoreMockRecorder) CreateHold(ctx, tx, hold any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHold", reflect.TypeOf((*MockHoldStore)(nil).CreateHold), ctx, tx, hold)
+}
+
+// GetExpiredHoldsForUpdate mocks base method.
+func (m *MockHoldStore) GetExpiredHoldsForUpdate(ctx context.Context, tx pgx_driver.QueryExecuter, now time.Time, limit int) ([]*models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpiredHoldsForUpdate", ctx, tx, now, limit)
+	ret0, _ := ret[0].([]*models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpiredHoldsForUpdate indicates an expected call of GetExpiredHoldsForUpdate.
+func (mr *MockHoldStoreMockRecorder) GetExpiredHoldsForUpdate(ctx, tx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpiredHoldsForUpdate", reflect.TypeOf((*MockHoldStore)(nil).GetExpiredHoldsForUpdate), ctx, tx, now, limit)
+}
+
+// GetHoldForUpdate mocks base method.
+func (m *MockHoldStore) GetHoldForUpdate(ctx context.Context, tx pgx_driver.QueryExecuter, id uuid.UUID) (*models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHoldForUpdate", ctx, tx, id)
+	ret0, _ := ret[0].(*models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHoldForUpdate indicates an expected call of GetHoldForUpdate.
+func (mr *MockHoldStoreMockRecorder) GetHoldForUpdate(ctx, tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHoldForUpdate", reflect.TypeOf((*MockHoldStore)(nil).GetHoldForUpdate), ctx, tx, id)
+}
+
+// UpdateHoldStatus mocks base method.
+func (m *MockHoldStore) UpdateHoldStatus(ctx context.Context, tx pgx_driver.QueryExecuter, id uuid.UUID, status models.HoldStatus, capturedAmount int64) (*models.Hold, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHoldStatus", ctx, tx, id, status, capturedAmount)
+	ret0, _ := ret[0].(*models.Hold)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHoldStatus indicates an expected call of UpdateHoldStatus.
+func (mr *MockHoldStoreMockRecorder) UpdateHoldStatus(ctx, tx, id, status, capturedAmount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHoldStatus", reflect.TypeOf((*MockHoldStore)(nil).UpdateHoldStatus), ctx, tx, id, status, capturedAmount)
+}
+
+// MockHoldBalanceUpdater is a mock of HoldBalanceUpdater interface.
+type MockHoldBalanceUpdater struct {
+	ctrl     *gomock.Controller
+	recorder *MockHoldBalanceUpdaterMockRecorder
+	isgomock struct{}
+}
+
+// MockHoldBalanceUpdaterMockRecorder is the mock recorder for MockHoldBalanceUpdater.
+type MockHoldBalanceUpdaterMockRecorder struct {
+	mock *MockHoldBalanceUpdater
+}
+
+// NewMockHoldBalanceUpdater creates a new mock instance.
+func NewMockHoldBalanceUpdater(ctrl *gomock.Controller) *MockHoldBalanceUpdater {
+	mock := &MockHoldBalanceUpdater{ctrl: ctrl}
+	mock.recorder = &MockHoldBalanceUpdaterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHoldBalanceUpdater) EXPECT() *MockHoldBalanceUpdaterMockRecorder {
+	return m.recorder
+}
+
+// CaptureHeldBalance mocks base method.
+func (m *MockHoldBalanceUpdater) CaptureHeldBalance(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID, held, captured int64) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CaptureHeldBalance", ctx, tx, walletID, held, captured)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CaptureHeldBalance indicates an expected call of CaptureHeldBalance.
+func (mr *MockHoldBalanceUpdaterMockRecorder) CaptureHeldBalance(ctx, tx, walletID, held, captured any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CaptureHeldBalance", reflect.TypeOf((*MockHoldBalanceUpdater)(nil).CaptureHeldBalance), ctx, tx, walletID, held, captured)
+}
+
+// HoldBalance mocks base method.
+func (m *MockHoldBalanceUpdater) HoldBalance(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID, amount int64) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HoldBalance", ctx, tx, walletID, amount)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HoldBalance indicates an expected call of HoldBalance.
+func (mr *MockHoldBalanceUpdaterMockRecorder) HoldBalance(ctx, tx, walletID, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HoldBalance", reflect.TypeOf((*MockHoldBalanceUpdater)(nil).HoldBalance), ctx, tx, walletID, amount)
+}
+
+// SettleHeldBalance mocks base method.
+func (m *MockHoldBalanceUpdater) SettleHeldBalance(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID, held int64) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleHeldBalance", ctx, tx, walletID, held)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SettleHeldBalance indicates an expected call of SettleHeldBalance.
+func (mr *MockHoldBalanceUpdaterMockRecorder) SettleHeldBalance(ctx, tx, walletID, held any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleHeldBalance", reflect.TypeOf((*MockHoldBalanceUpdater)(nil).SettleHeldBalance), ctx, tx, walletID, held)
+}
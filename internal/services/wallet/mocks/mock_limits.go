@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/wallet/limits.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/wallet/limits.go -destination=internal/services/wallet/mocks/mock_limits.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	models "wallet-service/internal/domain/models"
+	pgx_driver "wallet-service/pkg/pgx-driver"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLimitStore is a mock of LimitStore interface.
+type MockLimitStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimitStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockLimitStoreMockRecorder is the mock recorder for MockLimitStore.
+type MockLimitStoreMockRecorder struct {
+	mock *MockLimitStore
+}
+
+// NewMockLimitStore creates a new mock instance.
+func NewMockLimitStore(ctrl *gomock.Controller) *MockLimitStore {
+	mock := &MockLimitStore{ctrl: ctrl}
+	mock.recorder = &MockLimitStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimitStore) EXPECT() *MockLimitStoreMockRecorder {
+	return m.recorder
+}
+
+// GetLimitUsage mocks base method.
+func (m *MockLimitStore) GetLimitUsage(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID, dayStart, monthStart, windowStart time.Time) (*models.LimitUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLimitUsage", ctx, tx, walletID, dayStart, monthStart, windowStart)
+	ret0, _ := ret[0].(*models.LimitUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLimitUsage indicates an expected call of GetLimitUsage.
+func (mr *MockLimitStoreMockRecorder) GetLimitUsage(ctx, tx, walletID, dayStart, monthStart, windowStart any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLimitUsage", reflect.TypeOf((*MockLimitStore)(nil).GetLimitUsage), ctx, tx, walletID, dayStart, monthStart, windowStart)
+}
+
+// GetWalletLimits mocks base method.
+func (m *MockLimitStore) GetWalletLimits(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID) (*models.LimitOverrides, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWalletLimits", ctx, tx, walletID)
+	ret0, _ := ret[0].(*models.LimitOverrides)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWalletLimits indicates an expected call of GetWalletLimits.
+func (mr *MockLimitStoreMockRecorder) GetWalletLimits(ctx, tx, walletID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWalletLimits", reflect.TypeOf((*MockLimitStore)(nil).GetWalletLimits), ctx, tx, walletID)
+}
+
+// SetWalletLimits mocks base method.
+func (m *MockLimitStore) SetWalletLimits(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID, overrides *models.LimitOverrides) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWalletLimits", ctx, tx, walletID, overrides)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWalletLimits indicates an expected call of SetWalletLimits.
+func (mr *MockLimitStoreMockRecorder) SetWalletLimits(ctx, tx, walletID, overrides any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWalletLimits", reflect.TypeOf((*MockLimitStore)(nil).SetWalletLimits), ctx, tx, walletID, overrides)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/wallet/outbox.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/wallet/outbox.go -destination=internal/services/wallet/mocks/mock_outbox.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+	pgx_driver "wallet-service/pkg/pgx-driver"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOutboxWriter is a mock of OutboxWriter interface.
+type MockOutboxWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxWriterMockRecorder
+	isgomock struct{}
+}
+
+// MockOutboxWriterMockRecorder is the mock recorder for MockOutboxWriter.
+type MockOutboxWriterMockRecorder struct {
+	mock *MockOutboxWriter
+}
+
+// NewMockOutboxWriter creates a new mock instance.
+func NewMockOutboxWriter(ctrl *gomock.Controller) *MockOutboxWriter {
+	mock := &MockOutboxWriter{ctrl: ctrl}
+	mock.recorder = &MockOutboxWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOutboxWriter) EXPECT() *MockOutboxWriterMockRecorder {
+	return m.recorder
+}
+
+// EnqueueEvent mocks base method.
+func (m *MockOutboxWriter) EnqueueEvent(ctx context.Context, tx pgx_driver.QueryExecuter, event *models.OutboxEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueEvent", ctx, tx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnqueueEvent indicates an expected call of EnqueueEvent.
+func (mr *MockOutboxWriterMockRecorder) EnqueueEvent(ctx, tx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueEvent", reflect.TypeOf((*MockOutboxWriter)(nil).EnqueueEvent), ctx, tx, event)
+}
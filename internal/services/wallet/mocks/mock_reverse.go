@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/wallet/reverse.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/wallet/reverse.go -destination=internal/services/wallet/mocks/mock_reverse.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+	pgx_driver "wallet-service/pkg/pgx-driver"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOperationReverser is a mock of OperationReverser interface.
+type MockOperationReverser struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationReverserMockRecorder
+	isgomock struct{}
+}
+
+// MockOperationReverserMockRecorder is the mock recorder for MockOperationReverser.
+type MockOperationReverserMockRecorder struct {
+	mock *MockOperationReverser
+}
+
+// NewMockOperationReverser creates a new mock instance.
+func NewMockOperationReverser(ctrl *gomock.Controller) *MockOperationReverser {
+	mock := &MockOperationReverser{ctrl: ctrl}
+	mock.recorder = &MockOperationReverserMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationReverser) EXPECT() *MockOperationReverserMockRecorder {
+	return m.recorder
+}
+
+// AddReversedAmount mocks base method.
+func (m *MockOperationReverser) AddReversedAmount(ctx context.Context, tx pgx_driver.QueryExecuter, id uuid.UUID, amount int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReversedAmount", ctx, tx, id, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReversedAmount indicates an expected call of AddReversedAmount.
+func (mr *MockOperationReverserMockRecorder) AddReversedAmount(ctx, tx, id, amount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReversedAmount", reflect.TypeOf((*MockOperationReverser)(nil).AddReversedAmount), ctx, tx, id, amount)
+}
+
+// GetOperationForUpdate mocks base method.
+func (m *MockOperationReverser) GetOperationForUpdate(ctx context.Context, tx pgx_driver.QueryExecuter, id uuid.UUID) (*models.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOperationForUpdate", ctx, tx, id)
+	ret0, _ := ret[0].(*models.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOperationForUpdate indicates an expected call of GetOperationForUpdate.
+func (mr *MockOperationReverserMockRecorder) GetOperationForUpdate(ctx, tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperationForUpdate", reflect.TypeOf((*MockOperationReverser)(nil).GetOperationForUpdate), ctx, tx, id)
+}
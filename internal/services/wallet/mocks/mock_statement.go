@@ -0,0 +1,74 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/wallet/statement.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/wallet/statement.go -destination=internal/services/wallet/mocks/mock_statement.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	models "wallet-service/internal/domain/models"
+	pgx_driver "wallet-service/pkg/pgx-driver"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatementReader is a mock of StatementReader interface.
+type MockStatementReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementReaderMockRecorder
+	isgomock struct{}
+}
+
+// MockStatementReaderMockRecorder is the mock recorder for MockStatementReader.
+type MockStatementReaderMockRecorder struct {
+	mock *MockStatementReader
+}
+
+// NewMockStatementReader creates a new mock instance.
+func NewMockStatementReader(ctrl *gomock.Controller) *MockStatementReader {
+	mock := &MockStatementReader{ctrl: ctrl}
+	mock.recorder = &MockStatementReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatementReader) EXPECT() *MockStatementReaderMockRecorder {
+	return m.recorder
+}
+
+// OpenStatement mocks base method.
+func (m *MockStatementReader) OpenStatement(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID, from time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenStatement", ctx, tx, walletID, from)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenStatement indicates an expected call of OpenStatement.
+func (mr *MockStatementReaderMockRecorder) OpenStatement(ctx, tx, walletID, from any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenStatement", reflect.TypeOf((*MockStatementReader)(nil).OpenStatement), ctx, tx, walletID, from)
+}
+
+// WalkStatement mocks base method.
+func (m *MockStatementReader) WalkStatement(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID, from, to time.Time, opening int64, visit func(*models.Operation) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalkStatement", ctx, tx, walletID, from, to, opening, visit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WalkStatement indicates an expected call of WalkStatement.
+func (mr *MockStatementReaderMockRecorder) WalkStatement(ctx, tx, walletID, from, to, opening, visit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalkStatement", reflect.TypeOf((*MockStatementReader)(nil).WalkStatement), ctx, tx, walletID, from, to, opening, visit)
+}
@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/wallet/status.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/wallet/status.go -destination=internal/services/wallet/mocks/mock_status.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	models "wallet-service/internal/domain/models"
+	pgx_driver "wallet-service/pkg/pgx-driver"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatusUpdaterWallet is a mock of StatusUpdaterWallet interface.
+type MockStatusUpdaterWallet struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatusUpdaterWalletMockRecorder
+	isgomock struct{}
+}
+
+// MockStatusUpdaterWalletMockRecorder is the mock recorder for MockStatusUpdaterWallet.
+type MockStatusUpdaterWalletMockRecorder struct {
+	mock *MockStatusUpdaterWallet
+}
+
+// NewMockStatusUpdaterWallet creates a new mock instance.
+func NewMockStatusUpdaterWallet(ctrl *gomock.Controller) *MockStatusUpdaterWallet {
+	mock := &MockStatusUpdaterWallet{ctrl: ctrl}
+	mock.recorder = &MockStatusUpdaterWalletMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatusUpdaterWallet) EXPECT() *MockStatusUpdaterWalletMockRecorder {
+	return m.recorder
+}
+
+// RecordStatusChange mocks base method.
+func (m *MockStatusUpdaterWallet) RecordStatusChange(ctx context.Context, tx pgx_driver.QueryExecuter, change *models.WalletStatusChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordStatusChange", ctx, tx, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordStatusChange indicates an expected call of RecordStatusChange.
+func (mr *MockStatusUpdaterWalletMockRecorder) RecordStatusChange(ctx, tx, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordStatusChange", reflect.TypeOf((*MockStatusUpdaterWallet)(nil).RecordStatusChange), ctx, tx, change)
+}
+
+// UpdateWalletStatus mocks base method.
+func (m *MockStatusUpdaterWallet) UpdateWalletStatus(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID, status models.WalletStatus) (*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWalletStatus", ctx, tx, walletID, status)
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateWalletStatus indicates an expected call of UpdateWalletStatus.
+func (mr *MockStatusUpdaterWalletMockRecorder) UpdateWalletStatus(ctx, tx, walletID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWalletStatus", reflect.TypeOf((*MockStatusUpdaterWallet)(nil).UpdateWalletStatus), ctx, tx, walletID, status)
+}
@@ -12,7 +12,6 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
-	time "time"
 	models "wallet-service/internal/domain/models"
 	pgx_driver "wallet-service/pkg/pgx-driver"
 
@@ -45,18 +44,18 @@ func (m *MockSaverWallet) EXPECT() *MockSaverWalletMockRecorder {
 }
 
 // CreateWallet mocks base method.
-func (m *MockSaverWallet) CreateWallet(ctx context.Context, id uuid.UUID, balance int64) (*models.Wallet, error) {
+func (m *MockSaverWallet) CreateWallet(ctx context.Context, tx pgx_driver.QueryExecuter, id uuid.UUID, balance int64, currency string) (*models.Wallet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateWallet", ctx, id, balance)
+	ret := m.ctrl.Call(m, "CreateWallet", ctx, tx, id, balance, currency)
 	ret0, _ := ret[0].(*models.Wallet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateWallet indicates an expected call of CreateWallet.
-func (mr *MockSaverWalletMockRecorder) CreateWallet(ctx, id, balance any) *gomock.Call {
+func (mr *MockSaverWalletMockRecorder) CreateWallet(ctx, tx, id, balance, currency any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallet", reflect.TypeOf((*MockSaverWallet)(nil).CreateWallet), ctx, id, balance)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWallet", reflect.TypeOf((*MockSaverWallet)(nil).CreateWallet), ctx, tx, id, balance, currency)
 }
 
 // MockGetterWallet is a mock of GetterWallet interface.
@@ -136,6 +135,89 @@ func (mr *MockOperationSaverMockRecorder) CreateOperation(ctx, tx, operation any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOperation", reflect.TypeOf((*MockOperationSaver)(nil).CreateOperation), ctx, tx, operation)
 }
 
+// CreateOperations mocks base method.
+func (m *MockOperationSaver) CreateOperations(ctx context.Context, tx pgx_driver.QueryExecuter, operations []*models.Operation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOperations", ctx, tx, operations)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOperations indicates an expected call of CreateOperations.
+func (mr *MockOperationSaverMockRecorder) CreateOperations(ctx, tx, operations any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOperations", reflect.TypeOf((*MockOperationSaver)(nil).CreateOperations), ctx, tx, operations)
+}
+
+// MockOperationGetter is a mock of OperationGetter interface.
+type MockOperationGetter struct {
+	ctrl     *gomock.Controller
+	recorder *MockOperationGetterMockRecorder
+	isgomock struct{}
+}
+
+// MockOperationGetterMockRecorder is the mock recorder for MockOperationGetter.
+type MockOperationGetterMockRecorder struct {
+	mock *MockOperationGetter
+}
+
+// NewMockOperationGetter creates a new mock instance.
+func NewMockOperationGetter(ctrl *gomock.Controller) *MockOperationGetter {
+	mock := &MockOperationGetter{ctrl: ctrl}
+	mock.recorder = &MockOperationGetterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOperationGetter) EXPECT() *MockOperationGetterMockRecorder {
+	return m.recorder
+}
+
+// GetOperationsByWallet mocks base method.
+func (m *MockOperationGetter) GetOperationsByWallet(ctx context.Context, walletID uuid.UUID, filter models.OperationFilter, after *models.OperationCursor, limit int) ([]*models.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOperationsByWallet", ctx, walletID, filter, after, limit)
+	ret0, _ := ret[0].([]*models.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOperationsByWallet indicates an expected call of GetOperationsByWallet.
+func (mr *MockOperationGetterMockRecorder) GetOperationsByWallet(ctx, walletID, filter, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperationsByWallet", reflect.TypeOf((*MockOperationGetter)(nil).GetOperationsByWallet), ctx, walletID, filter, after, limit)
+}
+
+// GetOperationsSince mocks base method.
+func (m *MockOperationGetter) GetOperationsSince(ctx context.Context, walletID uuid.UUID, after int64, limit int) ([]*models.Operation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOperationsSince", ctx, walletID, after, limit)
+	ret0, _ := ret[0].([]*models.Operation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOperationsSince indicates an expected call of GetOperationsSince.
+func (mr *MockOperationGetterMockRecorder) GetOperationsSince(ctx, walletID, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperationsSince", reflect.TypeOf((*MockOperationGetter)(nil).GetOperationsSince), ctx, walletID, after, limit)
+}
+
+// SequenceOperations mocks base method.
+func (m *MockOperationGetter) SequenceOperations(ctx context.Context, walletID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SequenceOperations", ctx, walletID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SequenceOperations indicates an expected call of SequenceOperations.
+func (mr *MockOperationGetterMockRecorder) SequenceOperations(ctx, walletID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SequenceOperations", reflect.TypeOf((*MockOperationGetter)(nil).SequenceOperations), ctx, walletID)
+}
+
 // MockBalanceUpdaterWallet is a mock of BalanceUpdaterWallet interface.
 type MockBalanceUpdaterWallet struct {
 	ctrl     *gomock.Controller
@@ -161,13 +243,12 @@ func (m *MockBalanceUpdaterWallet) EXPECT() *MockBalanceUpdaterWalletMockRecorde
 }
 
 // DecreaseBalance mocks base method.
-func (m *MockBalanceUpdaterWallet) DecreaseBalance(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID, amount int64) (int64, time.Time, error) {
+func (m *MockBalanceUpdaterWallet) DecreaseBalance(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID, amount int64) (*models.Wallet, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "DecreaseBalance", ctx, tx, walletID, amount)
-	ret0, _ := ret[0].(int64)
-	ret1, _ := ret[1].(time.Time)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // DecreaseBalance indicates an expected call of DecreaseBalance.
@@ -177,13 +258,12 @@ func (mr *MockBalanceUpdaterWalletMockRecorder) DecreaseBalance(ctx, tx, walletI
 }
 
 // IncreaseBalance mocks base method.
-func (m *MockBalanceUpdaterWallet) IncreaseBalance(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID, amount int64) (int64, time.Time, error) {
+func (m *MockBalanceUpdaterWallet) IncreaseBalance(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID, amount int64) (*models.Wallet, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "IncreaseBalance", ctx, tx, walletID, amount)
-	ret0, _ := ret[0].(int64)
-	ret1, _ := ret[1].(time.Time)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret0, _ := ret[0].(*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // IncreaseBalance indicates an expected call of IncreaseBalance.
@@ -191,3 +271,186 @@ func (mr *MockBalanceUpdaterWalletMockRecorder) IncreaseBalance(ctx, tx, walletI
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncreaseBalance", reflect.TypeOf((*MockBalanceUpdaterWallet)(nil).IncreaseBalance), ctx, tx, walletID, amount)
 }
+
+// MockLockerWallet is a mock of LockerWallet interface.
+type MockLockerWallet struct {
+	ctrl     *gomock.Controller
+	recorder *MockLockerWalletMockRecorder
+	isgomock struct{}
+}
+
+// MockLockerWalletMockRecorder is the mock recorder for MockLockerWallet.
+type MockLockerWalletMockRecorder struct {
+	mock *MockLockerWallet
+}
+
+// NewMockLockerWallet creates a new mock instance.
+func NewMockLockerWallet(ctrl *gomock.Controller) *MockLockerWallet {
+	mock := &MockLockerWallet{ctrl: ctrl}
+	mock.recorder = &MockLockerWalletMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLockerWallet) EXPECT() *MockLockerWalletMockRecorder {
+	return m.recorder
+}
+
+// LockExistingWallets mocks base method.
+func (m *MockLockerWallet) LockExistingWallets(ctx context.Context, tx pgx_driver.QueryExecuter, walletIDs ...uuid.UUID) ([]*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, tx}
+	for _, a := range walletIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LockExistingWallets", varargs...)
+	ret0, _ := ret[0].([]*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockExistingWallets indicates an expected call of LockExistingWallets.
+func (mr *MockLockerWalletMockRecorder) LockExistingWallets(ctx, tx any, walletIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, tx}, walletIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockExistingWallets", reflect.TypeOf((*MockLockerWallet)(nil).LockExistingWallets), varargs...)
+}
+
+// LockWallets mocks base method.
+func (m *MockLockerWallet) LockWallets(ctx context.Context, tx pgx_driver.QueryExecuter, walletIDs ...uuid.UUID) ([]*models.Wallet, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, tx}
+	for _, a := range walletIDs {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LockWallets", varargs...)
+	ret0, _ := ret[0].([]*models.Wallet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockWallets indicates an expected call of LockWallets.
+func (mr *MockLockerWalletMockRecorder) LockWallets(ctx, tx any, walletIDs ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, tx}, walletIDs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockWallets", reflect.TypeOf((*MockLockerWallet)(nil).LockWallets), varargs...)
+}
+
+// MockIdempotencyStore is a mock of IdempotencyStore interface.
+type MockIdempotencyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockIdempotencyStoreMockRecorder is the mock recorder for MockIdempotencyStore.
+type MockIdempotencyStoreMockRecorder struct {
+	mock *MockIdempotencyStore
+}
+
+// NewMockIdempotencyStore creates a new mock instance.
+func NewMockIdempotencyStore(ctrl *gomock.Controller) *MockIdempotencyStore {
+	mock := &MockIdempotencyStore{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIdempotencyStore) EXPECT() *MockIdempotencyStoreMockRecorder {
+	return m.recorder
+}
+
+// GetIdempotencyRecord mocks base method.
+func (m *MockIdempotencyStore) GetIdempotencyRecord(ctx context.Context, tx pgx_driver.QueryExecuter, key string) (*models.IdempotencyRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyRecord", ctx, tx, key)
+	ret0, _ := ret[0].(*models.IdempotencyRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyRecord indicates an expected call of GetIdempotencyRecord.
+func (mr *MockIdempotencyStoreMockRecorder) GetIdempotencyRecord(ctx, tx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyRecord", reflect.TypeOf((*MockIdempotencyStore)(nil).GetIdempotencyRecord), ctx, tx, key)
+}
+
+// SaveIdempotencyRecord mocks base method.
+func (m *MockIdempotencyStore) SaveIdempotencyRecord(ctx context.Context, tx pgx_driver.QueryExecuter, record *models.IdempotencyRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveIdempotencyRecord", ctx, tx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveIdempotencyRecord indicates an expected call of SaveIdempotencyRecord.
+func (mr *MockIdempotencyStoreMockRecorder) SaveIdempotencyRecord(ctx, tx, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIdempotencyRecord", reflect.TypeOf((*MockIdempotencyStore)(nil).SaveIdempotencyRecord), ctx, tx, record)
+}
+
+// MockLedgerWriter is a mock of LedgerWriter interface.
+type MockLedgerWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerWriterMockRecorder
+	isgomock struct{}
+}
+
+// MockLedgerWriterMockRecorder is the mock recorder for MockLedgerWriter.
+type MockLedgerWriterMockRecorder struct {
+	mock *MockLedgerWriter
+}
+
+// NewMockLedgerWriter creates a new mock instance.
+func NewMockLedgerWriter(ctrl *gomock.Controller) *MockLedgerWriter {
+	mock := &MockLedgerWriter{ctrl: ctrl}
+	mock.recorder = &MockLedgerWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgerWriter) EXPECT() *MockLedgerWriterMockRecorder {
+	return m.recorder
+}
+
+// CreateWalletAccount mocks base method.
+func (m *MockLedgerWriter) CreateWalletAccount(ctx context.Context, tx pgx_driver.QueryExecuter, walletID uuid.UUID, currency string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWalletAccount", ctx, tx, walletID, currency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWalletAccount indicates an expected call of CreateWalletAccount.
+func (mr *MockLedgerWriterMockRecorder) CreateWalletAccount(ctx, tx, walletID, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWalletAccount", reflect.TypeOf((*MockLedgerWriter)(nil).CreateWalletAccount), ctx, tx, walletID, currency)
+}
+
+// EnsureSystemAccount mocks base method.
+func (m *MockLedgerWriter) EnsureSystemAccount(ctx context.Context, tx pgx_driver.QueryExecuter, accountID uuid.UUID, kind models.LedgerAccountKind, currency string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureSystemAccount", ctx, tx, accountID, kind, currency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsureSystemAccount indicates an expected call of EnsureSystemAccount.
+func (mr *MockLedgerWriterMockRecorder) EnsureSystemAccount(ctx, tx, accountID, kind, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureSystemAccount", reflect.TypeOf((*MockLedgerWriter)(nil).EnsureSystemAccount), ctx, tx, accountID, kind, currency)
+}
+
+// PostEntry mocks base method.
+func (m *MockLedgerWriter) PostEntry(ctx context.Context, tx pgx_driver.QueryExecuter, entry *models.JournalEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostEntry", ctx, tx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostEntry indicates an expected call of PostEntry.
+func (mr *MockLedgerWriterMockRecorder) PostEntry(ctx, tx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostEntry", reflect.TypeOf((*MockLedgerWriter)(nil).PostEntry), ctx, tx, entry)
+}
@@ -0,0 +1,115 @@
+package wallet
+
+import (
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/lib/metrics"
+)
+
+// WalletStorage is everything the service reads and writes on wallets.
+type WalletStorage interface {
+	SaverWallet
+	GetterWallet
+	BalanceUpdaterWallet
+	LockerWallet
+	BucketBalanceUpdater
+	HoldBalanceUpdater
+	StatusUpdaterWallet
+}
+
+// OperationStorage is everything the service reads and writes on operations.
+type OperationStorage interface {
+	OperationSaver
+	OperationGetter
+	OperationReverser
+	BalanceHistory
+	StatementReader
+}
+
+type Option func(*ServiceWallet)
+
+func Wallets(store WalletStorage) Option {
+	return func(ws *ServiceWallet) {
+		ws.walletSaver = store
+		ws.walletGetter = store
+		ws.walletBalanceUpdater = store
+		ws.walletLocker = store
+		ws.bucketBalanceUpdater = store
+		ws.holdBalanceUpdater = store
+		ws.walletStatusUpdater = store
+	}
+}
+
+func Operations(store OperationStorage) Option {
+	return func(ws *ServiceWallet) {
+		ws.operationSaver = store
+		ws.operationGetter = store
+		ws.operationReverser = store
+		ws.balanceHistory = store
+		ws.statementReader = store
+	}
+}
+
+func Idempotency(store IdempotencyStore) Option {
+	return func(ws *ServiceWallet) {
+		ws.idempotencyStore = store
+	}
+}
+
+func Holds(store HoldStore) Option {
+	return func(ws *ServiceWallet) {
+		ws.holdStore = store
+	}
+}
+
+func Ledger(ledger LedgerWriter) Option {
+	return func(ws *ServiceWallet) {
+		ws.ledger = ledger
+	}
+}
+
+func FXRates(provider FXRateProvider) Option {
+	return func(ws *ServiceWallet) {
+		ws.fxRates = provider
+	}
+}
+
+func Quotes(store QuoteStore) Option {
+	return func(ws *ServiceWallet) {
+		ws.quoteStore = store
+	}
+}
+
+// QuoteTTL sets how long a quoted rate can be exchanged at; defaultQuoteTTL
+// when not positive.
+func QuoteTTL(ttl time.Duration) Option {
+	return func(ws *ServiceWallet) {
+		ws.quoteTTL = ttl
+	}
+}
+
+func Limits(store LimitStore) Option {
+	return func(ws *ServiceWallet) {
+		ws.limitStore = store
+	}
+}
+
+// DefaultLimits sets the limits of wallets without overrides.
+func DefaultLimits(limits models.Limits) Option {
+	return func(ws *ServiceWallet) {
+		ws.limits = limits
+	}
+}
+
+func Outbox(outbox OutboxWriter) Option {
+	return func(ws *ServiceWallet) {
+		ws.outbox = outbox
+	}
+}
+
+// Metrics sets where operations are counted; nothing is recorded without it.
+func Metrics(m *metrics.Metrics) Option {
+	return func(ws *ServiceWallet) {
+		ws.metrics = m
+	}
+}
@@ -0,0 +1,66 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+	"wallet-service/internal/domain/models"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+
+	"github.com/google/uuid"
+)
+
+type OutboxWriter interface {
+	EnqueueEvent(ctx context.Context, tx pgxdriver.QueryExecuter, event *models.OutboxEvent) error
+}
+
+// saveOperationTx records operation together with the balance-change event
+// describing it. Both are written in the caller's transaction, so the event
+// is relayed if and only if the operation commits. wallet is the state of the
+// wallet after the operation was applied.
+func (ws *ServiceWallet) saveOperationTx(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	operation *models.Operation,
+	wallet *models.Wallet,
+) error {
+
+	if err := ws.operationSaver.CreateOperation(ctx, tx, operation); err != nil {
+		return err
+	}
+
+	return ws.enqueueBalanceChangedTx(ctx, tx, operation, wallet)
+}
+
+// enqueueBalanceChangedTx writes the balance-change event of an operation
+// recorded in tx.
+func (ws *ServiceWallet) enqueueBalanceChangedTx(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	operation *models.Operation,
+	wallet *models.Wallet,
+) error {
+
+	payload, err := json.Marshal(models.BalanceChanged{
+		OperationID: operation.ID,
+		WalletID:    operation.WalletID,
+		Type:        operation.Type,
+		Amount:      operation.Amount,
+		Currency:    wallet.Currency,
+		Balance:     wallet.Balance,
+		HeldBalance: wallet.HeldBalance,
+		TransferID:  operation.TransferID,
+		HoldID:      operation.HoldID,
+		OccurredAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return ws.outbox.EnqueueEvent(ctx, tx, &models.OutboxEvent{
+		ID:          uuid.New(),
+		AggregateID: operation.WalletID,
+		EventType:   models.EventBalanceChanged,
+		Payload:     payload,
+	})
+}
@@ -0,0 +1,152 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+
+	"github.com/google/uuid"
+)
+
+const maxReversalReasonLength = 500
+
+type OperationReverser interface {
+	GetOperationForUpdate(ctx context.Context, tx pgxdriver.QueryExecuter, id uuid.UUID) (*models.Operation, error)
+	AddReversedAmount(ctx context.Context, tx pgxdriver.QueryExecuter, id uuid.UUID, amount int64) error
+}
+
+// Reverse compensates a deposit or withdrawal with a REVERSAL operation that
+// references it. amount 0 reverses whatever has not been reversed yet; a
+// smaller amount is a partial refund. The reversed total never exceeds the
+// original amount, so an operation cannot be reversed twice. Reversing a
+// withdrawal credits the wallet and is checked against its limits like any
+// other credit.
+func (ws *ServiceWallet) Reverse(
+	ctx context.Context,
+	operationID uuid.UUID,
+	amount int64,
+	reason string,
+) (*models.ReversalResult, error) {
+	const op = "services.wallet.Reverse"
+
+	ctx, span := startSpan(ctx, "Reverse", uuidAttr("operation.id", operationID))
+	defer span.End()
+
+	if operationID == uuid.Nil {
+		return nil, services.ErrInvalidOperationID
+	}
+
+	if amount < 0 {
+		return nil, services.ErrAmountNegativeValue
+	}
+
+	if reason == "" || len(reason) > maxReversalReasonLength {
+		return nil, services.ErrInvalidReversalReason
+	}
+
+	var result *models.ReversalResult
+	err := ws.txManager.ExecuteInTransaction(ctx, "reverse", func(tx pgxdriver.QueryExecuter) error {
+		original, err := ws.operationReverser.GetOperationForUpdate(ctx, tx, operationID)
+		if err != nil {
+			return err
+		}
+
+		if original.Type != models.Deposit && original.Type != models.Withdraw {
+			return services.ErrOperationNotReversible
+		}
+
+		remaining := original.Amount - original.ReversedAmount
+		if remaining == 0 {
+			return services.ErrOperationAlreadyReversed
+		}
+
+		reversed := amount
+		if reversed == 0 {
+			reversed = remaining
+		}
+		if reversed > remaining {
+			return services.ErrReversalExceedsAmount
+		}
+
+		// A deposit is reversed by taking the money back, which fails with
+		// ErrInsufficientFunds once it has been spent.
+		var (
+			wallet *models.Wallet
+			sign   int64
+		)
+		if original.Type == models.Deposit {
+			wallet, err = ws.decreaseBalanceTx(ctx, tx, original.WalletID, reversed)
+			sign = -1
+		} else {
+			wallet, err = ws.increaseBalanceTx(ctx, tx, original.WalletID, reversed)
+			sign = 1
+		}
+		if err != nil {
+			if errors.Is(err, storage.ErrInsufficientFunds) {
+				return storage.ErrInsufficientFunds
+			}
+			return err
+		}
+
+		if sign > 0 {
+			err = ws.checkLimitsTx(ctx, tx, models.OperationRequest{
+				WalletID: original.WalletID,
+				Type:     models.Reversal,
+				Amount:   reversed,
+			}, wallet, 1)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := ws.operationReverser.AddReversedAmount(ctx, tx, original.ID, reversed); err != nil {
+			return err
+		}
+		original.ReversedAmount += reversed
+
+		operation := &models.Operation{
+			ID:                  uuid.New(),
+			WalletID:            original.WalletID,
+			Type:                models.Reversal,
+			Amount:              reversed,
+			ReversedOperationID: &original.ID,
+			Reason:              reason,
+		}
+		if err := ws.saveOperationTx(ctx, tx, operation, wallet); err != nil {
+			return err
+		}
+
+		err = ws.postEntryTx(ctx, tx, models.EntryReversal, operation.ID,
+			models.Posting{AccountID: original.WalletID, Amount: sign * reversed},
+			models.Posting{AccountID: models.ExternalAccountFor(wallet.Currency), Amount: -sign * reversed},
+		)
+		if err != nil {
+			return err
+		}
+
+		result = &models.ReversalResult{Operation: operation, Original: original, Wallet: wallet}
+		return nil
+	})
+
+	if err != nil {
+		for _, known := range []error{
+			storage.ErrInsufficientFunds,
+			storage.ErrOperationNotFound,
+			services.ErrOperationNotReversible,
+			services.ErrOperationAlreadyReversed,
+			services.ErrReversalExceedsAmount,
+		} {
+			if errors.Is(err, known) {
+				return nil, known
+			}
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
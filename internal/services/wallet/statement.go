@@ -0,0 +1,114 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+
+	"github.com/google/uuid"
+)
+
+// errStatementInterrupted stops the transaction manager from retrying a
+// statement once part of it has been written out.
+var errStatementInterrupted = errors.New("statement interrupted after it was started")
+
+type StatementReader interface {
+	OpenStatement(ctx context.Context, tx pgxdriver.QueryExecuter, walletID uuid.UUID, from time.Time) (int64, error)
+	WalkStatement(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		walletID uuid.UUID,
+		from time.Time,
+		to time.Time,
+		opening int64,
+		visit func(operation *models.Operation) error,
+	) error
+}
+
+// Statement streams the statement of the wallet for [from, to) into sw. A
+// zero from starts at the opening of the wallet; a zero or future to ends
+// now. Nothing is written when the statement cannot be started.
+func (ws *ServiceWallet) Statement(
+	ctx context.Context,
+	walletID uuid.UUID,
+	from time.Time,
+	to time.Time,
+	sw models.StatementWriter,
+) error {
+
+	const op = "services.wallet.Statement"
+
+	ctx, span := startSpan(ctx, "Statement", uuidAttr("wallet.id", walletID))
+	defer span.End()
+
+	if walletID == uuid.Nil {
+		return services.ErrInvalidWalletID
+	}
+
+	if now := time.Now(); to.IsZero() || to.After(now) {
+		to = now
+	}
+
+	wallet, err := ws.walletGetter.GetWallet(ctx, walletID)
+	if err != nil {
+		if errors.Is(err, storage.ErrWalletNotFound) {
+			return storage.ErrWalletNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if from.IsZero() {
+		from = wallet.CreatedAt
+	}
+	if from.After(to) {
+		return services.ErrInvalidDateRange
+	}
+
+	started := false
+	err = ws.txManager.ExecuteInTransaction(ctx, "statement", func(tx pgxdriver.QueryExecuter) error {
+		if started {
+			return errStatementInterrupted
+		}
+
+		opening, err := ws.statementReader.OpenStatement(ctx, tx, walletID, from)
+		if err != nil {
+			return err
+		}
+
+		started = true
+		err = sw.Opening(&models.Statement{
+			WalletID:       walletID,
+			Currency:       wallet.Currency,
+			From:           from,
+			To:             to,
+			OpeningBalance: opening,
+		})
+		if err != nil {
+			return err
+		}
+
+		closing := opening
+		err = ws.statementReader.WalkStatement(ctx, tx, walletID, from, to, opening, func(operation *models.Operation) error {
+			closing = *operation.BalanceAfter
+			return sw.Line(operation)
+		})
+		if err != nil {
+			return err
+		}
+
+		return sw.Closing(closing)
+	})
+	if err != nil {
+		if errors.Is(err, storage.ErrWalletNotFound) {
+			return storage.ErrWalletNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxActorLength        = 255
+	maxStatusReasonLength = 500
+)
+
+type StatusUpdaterWallet interface {
+	UpdateWalletStatus(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		walletID uuid.UUID,
+		status models.WalletStatus,
+	) (*models.Wallet, error)
+	RecordStatusChange(ctx context.Context, tx pgxdriver.QueryExecuter, change *models.WalletStatusChange) error
+}
+
+// Freeze stops all balance movements of an active wallet, e.g. while it is
+// under investigation.
+func (ws *ServiceWallet) Freeze(ctx context.Context, walletID uuid.UUID, actor, reason string) (*models.Wallet, error) {
+	ctx, span := startSpan(ctx, "Freeze", uuidAttr("wallet.id", walletID))
+	defer span.End()
+
+	return ws.changeStatus(ctx, "services.wallet.Freeze", walletID, models.WalletFrozen, actor, reason)
+}
+
+// Unfreeze returns a frozen wallet to active.
+func (ws *ServiceWallet) Unfreeze(ctx context.Context, walletID uuid.UUID, actor, reason string) (*models.Wallet, error) {
+	ctx, span := startSpan(ctx, "Unfreeze", uuidAttr("wallet.id", walletID))
+	defer span.End()
+
+	return ws.changeStatus(ctx, "services.wallet.Unfreeze", walletID, models.WalletActive, actor, reason)
+}
+
+// Close permanently closes an active or frozen wallet. The wallet must hold
+// no money, including funds reserved by holds.
+func (ws *ServiceWallet) Close(ctx context.Context, walletID uuid.UUID, actor, reason string) (*models.Wallet, error) {
+	ctx, span := startSpan(ctx, "Close", uuidAttr("wallet.id", walletID))
+	defer span.End()
+
+	return ws.changeStatus(ctx, "services.wallet.Close", walletID, models.WalletClosed, actor, reason)
+}
+
+// allowedTransitions lists, per target status, the statuses it can be reached from.
+var allowedTransitions = map[models.WalletStatus][]models.WalletStatus{
+	models.WalletFrozen: {models.WalletActive},
+	models.WalletActive: {models.WalletFrozen},
+	models.WalletClosed: {models.WalletActive, models.WalletFrozen},
+}
+
+func (ws *ServiceWallet) changeStatus(
+	ctx context.Context,
+	op string,
+	walletID uuid.UUID,
+	to models.WalletStatus,
+	actor string,
+	reason string,
+) (*models.Wallet, error) {
+
+	if walletID == uuid.Nil {
+		return nil, services.ErrInvalidWalletID
+	}
+
+	if actor == "" || len(actor) > maxActorLength || reason == "" || len(reason) > maxStatusReasonLength {
+		return nil, services.ErrInvalidStatusChange
+	}
+
+	var result *models.Wallet
+	err := ws.txManager.ExecuteInTransaction(ctx, "change_wallet_status", func(tx pgxdriver.QueryExecuter) error {
+		locked, err := ws.walletLocker.LockWallets(ctx, tx, walletID)
+		if err != nil {
+			return err
+		}
+		current := locked[0]
+
+		if !slices.Contains(allowedTransitions[to], current.Status) {
+			return services.ErrInvalidStatusTransition
+		}
+
+		if to == models.WalletClosed && (current.Balance != 0 || current.HeldBalance != 0) {
+			return services.ErrWalletNotEmpty
+		}
+
+		result, err = ws.walletStatusUpdater.UpdateWalletStatus(ctx, tx, walletID, to)
+		if err != nil {
+			return err
+		}
+
+		return ws.walletStatusUpdater.RecordStatusChange(ctx, tx, &models.WalletStatusChange{
+			ID:         uuid.New(),
+			WalletID:   walletID,
+			FromStatus: current.Status,
+			ToStatus:   to,
+			Actor:      actor,
+			Reason:     reason,
+		})
+	})
+
+	if err != nil {
+		for _, known := range []error{
+			storage.ErrWalletNotFound,
+			services.ErrInvalidStatusTransition,
+			services.ErrWalletNotEmpty,
+		} {
+			if errors.Is(err, known) {
+				return nil, known
+			}
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,107 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+const streamBatchSize = 100
+
+// StreamCursor validates that the wallet exists and returns the cursor a
+// stream should start after. A non-empty lastEventID resumes a previous
+// stream; otherwise the stream starts after the newest operation, so only
+// operations committed from now on are pushed.
+func (ws *ServiceWallet) StreamCursor(ctx context.Context, walletID uuid.UUID, lastEventID string) (string, error) {
+	const op = "services.wallet.StreamCursor"
+
+	ctx, span := startSpan(ctx, "StreamCursor", uuidAttr("wallet.id", walletID))
+	defer span.End()
+
+	if walletID == uuid.Nil {
+		return "", services.ErrInvalidWalletID
+	}
+
+	if _, err := decodeStreamCursor(lastEventID); err != nil {
+		return "", services.ErrInvalidCursor
+	}
+
+	last, err := ws.operationGetter.SequenceOperations(ctx, walletID)
+	if err != nil {
+		if errors.Is(err, storage.ErrWalletNotFound) {
+			return "", storage.ErrWalletNotFound
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if lastEventID != "" {
+		return lastEventID, nil
+	}
+
+	return encodeStreamCursor(last), nil
+}
+
+// OperationsSince returns the next batch of the wallet's operations after
+// cursor, in sequence order. Operations still waiting for a sequence are
+// sequenced first. An empty batch means the stream has caught up.
+func (ws *ServiceWallet) OperationsSince(
+	ctx context.Context,
+	walletID uuid.UUID,
+	cursor string,
+) ([]models.OperationEvent, error) {
+	const op = "services.wallet.OperationsSince"
+
+	ctx, span := startSpan(ctx, "OperationsSince", uuidAttr("wallet.id", walletID))
+	defer span.End()
+
+	after, err := decodeStreamCursor(cursor)
+	if err != nil {
+		return nil, services.ErrInvalidCursor
+	}
+
+	if _, err := ws.operationGetter.SequenceOperations(ctx, walletID); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	operations, err := ws.operationGetter.GetOperationsSince(ctx, walletID, after, streamBatchSize)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	events := make([]models.OperationEvent, 0, len(operations))
+	for _, operation := range operations {
+		events = append(events, models.OperationEvent{
+			Cursor:    encodeStreamCursor(*operation.Sequence),
+			Operation: operation,
+		})
+	}
+
+	return events, nil
+}
+
+// encodeStreamCursor is the id of a stream event: the sequence of its
+// operation.
+func encodeStreamCursor(sequence int64) string {
+	return strconv.FormatInt(sequence, 10)
+}
+
+// decodeStreamCursor is the inverse of encodeStreamCursor. An empty token
+// means the beginning of the stream.
+func decodeStreamCursor(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	sequence, err := strconv.ParseInt(token, 10, 64)
+	if err != nil || sequence < 0 {
+		return 0, errMalformedCursor
+	}
+
+	return sequence, nil
+}
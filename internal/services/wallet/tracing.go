@@ -0,0 +1,41 @@
+package wallet
+
+import (
+	"context"
+	"wallet-service/internal/lib/metrics"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "wallet-service/internal/services/wallet"
+
+// startSpan starts the span of a ServiceWallet method. The transaction
+// attempts and statements of the call become its children. While tracing is
+// off the span is a no-op and ctx is returned as is.
+func startSpan(ctx context.Context, method string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	spanCtx, span := otel.Tracer(tracerName).Start(ctx, "ServiceWallet."+method, trace.WithAttributes(attrs...))
+	if !span.SpanContext().IsValid() {
+		return ctx, span
+	}
+
+	return spanCtx, span
+}
+
+func uuidAttr(key string, id uuid.UUID) attribute.KeyValue {
+	return attribute.String(key, id.String())
+}
+
+// recordOutcome tags the span of a balance movement with its outcome, and
+// marks it failed only on unexpected errors: an insufficient balance is an
+// answer, not a fault.
+func recordOutcome(span trace.Span, outcome string, err error) {
+	span.SetAttributes(attribute.String("wallet.operation.outcome", outcome))
+	if err != nil && outcome == metrics.OutcomeError {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
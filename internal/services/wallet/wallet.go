@@ -7,16 +7,24 @@ import (
 	"log/slog"
 	"time"
 	"wallet-service/internal/domain/models"
+	"wallet-service/internal/lib/metrics"
 	"wallet-service/internal/services"
 	"wallet-service/internal/storage"
 	pgxdriver "wallet-service/pkg/pgx-driver"
 	"wallet-service/pkg/pgx-driver/transaction"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type SaverWallet interface {
-	CreateWallet(ctx context.Context, id uuid.UUID, balance int64) (*models.Wallet, error)
+	CreateWallet(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		id uuid.UUID,
+		balance int64,
+		currency string,
+	) (*models.Wallet, error)
 }
 
 type GetterWallet interface {
@@ -25,6 +33,24 @@ type GetterWallet interface {
 
 type OperationSaver interface {
 	CreateOperation(ctx context.Context, tx pgxdriver.QueryExecuter, operation *models.Operation) error
+	CreateOperations(ctx context.Context, tx pgxdriver.QueryExecuter, operations []*models.Operation) error
+}
+
+type OperationGetter interface {
+	GetOperationsByWallet(
+		ctx context.Context,
+		walletID uuid.UUID,
+		filter models.OperationFilter,
+		after *models.OperationCursor,
+		limit int,
+	) ([]*models.Operation, error)
+	SequenceOperations(ctx context.Context, walletID uuid.UUID) (int64, error)
+	GetOperationsSince(
+		ctx context.Context,
+		walletID uuid.UUID,
+		after int64,
+		limit int,
+	) ([]*models.Operation, error)
 }
 
 type BalanceUpdaterWallet interface {
@@ -33,13 +59,43 @@ type BalanceUpdaterWallet interface {
 		tx pgxdriver.QueryExecuter,
 		walletID uuid.UUID,
 		amount int64,
-	) (int64, time.Time, error)
+	) (*models.Wallet, error)
 	DecreaseBalance(
 		ctx context.Context,
 		tx pgxdriver.QueryExecuter,
 		walletID uuid.UUID,
 		amount int64,
-	) (int64, time.Time, error)
+	) (*models.Wallet, error)
+}
+
+type LockerWallet interface {
+	LockWallets(ctx context.Context, tx pgxdriver.QueryExecuter, walletIDs ...uuid.UUID) ([]*models.Wallet, error)
+	LockExistingWallets(ctx context.Context, tx pgxdriver.QueryExecuter, walletIDs ...uuid.UUID) ([]*models.Wallet, error)
+}
+
+type IdempotencyStore interface {
+	GetIdempotencyRecord(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		key string,
+	) (*models.IdempotencyRecord, error)
+	SaveIdempotencyRecord(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		record *models.IdempotencyRecord,
+	) error
+}
+
+type LedgerWriter interface {
+	CreateWalletAccount(ctx context.Context, tx pgxdriver.QueryExecuter, walletID uuid.UUID, currency string) error
+	EnsureSystemAccount(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		accountID uuid.UUID,
+		kind models.LedgerAccountKind,
+		currency string,
+	) error
+	PostEntry(ctx context.Context, tx pgxdriver.QueryExecuter, entry *models.JournalEntry) error
 }
 
 type ServiceWallet struct {
@@ -49,39 +105,94 @@ type ServiceWallet struct {
 	walletSaver          SaverWallet
 	walletGetter         GetterWallet
 	walletBalanceUpdater BalanceUpdaterWallet
-
-	operationSaver OperationSaver
+	walletLocker         LockerWallet
+	bucketBalanceUpdater BucketBalanceUpdater
+	holdBalanceUpdater   HoldBalanceUpdater
+	walletStatusUpdater  StatusUpdaterWallet
+
+	operationSaver    OperationSaver
+	operationGetter   OperationGetter
+	operationReverser OperationReverser
+	balanceHistory    BalanceHistory
+	statementReader   StatementReader
+	idempotencyStore  IdempotencyStore
+	holdStore         HoldStore
+	ledger            LedgerWriter
+
+	fxRates    FXRateProvider
+	quoteStore QuoteStore
+	quoteTTL   time.Duration
+
+	limitStore LimitStore
+	limits     models.Limits
+
+	outbox OutboxWriter
+
+	metrics *metrics.Metrics
 }
 
-func New(
-	txManager transaction.Manager,
-	log *slog.Logger,
-	walletSaver SaverWallet,
-	walletGetter GetterWallet,
-	walletBalanceUpdater BalanceUpdaterWallet,
-	operationSaver OperationSaver,
-) *ServiceWallet {
+func New(txManager transaction.Manager, log *slog.Logger, opts ...Option) *ServiceWallet {
+	ws := &ServiceWallet{
+		txManager: txManager,
+		log:       log,
+	}
 
-	return &ServiceWallet{
-		txManager:            txManager,
-		log:                  log,
-		walletSaver:          walletSaver,
-		walletGetter:         walletGetter,
-		walletBalanceUpdater: walletBalanceUpdater,
-		operationSaver:       operationSaver,
+	for _, opt := range opts {
+		opt(ws)
 	}
+
+	return ws
 }
 
-func (ws *ServiceWallet) CreateWallet(ctx context.Context, amount int64) (*models.Wallet, error) {
+// CreateWallet opens a wallet in the given ISO 4217 currency; an empty
+// currency means models.DefaultCurrency. The currency cannot change later.
+func (ws *ServiceWallet) CreateWallet(ctx context.Context, amount int64, currency string) (*models.Wallet, error) {
 	const op = "services.wallet.CreateWallet"
+
+	ctx, span := startSpan(ctx, "CreateWallet", attribute.String("wallet.currency", currency))
+	defer span.End()
+
 	if amount < 0 {
 		ws.log.Error("amount negative value")
 		return nil, services.ErrAmountNegativeValue
 	}
 
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+	if _, ok := models.LookupCurrency(currency); !ok {
+		return nil, services.ErrUnsupportedCurrency
+	}
+
 	id := uuid.New()
 
-	wallet, err := ws.walletSaver.CreateWallet(ctx, id, amount)
+	var wallet *models.Wallet
+	err := ws.txManager.ExecuteInTransaction(ctx, "create_wallet", func(tx pgxdriver.QueryExecuter) error {
+		var err error
+
+		wallet, err = ws.walletSaver.CreateWallet(ctx, tx, id, amount, currency)
+		if err != nil {
+			return err
+		}
+
+		external := models.ExternalAccountFor(currency)
+		if err := ws.ledger.EnsureSystemAccount(ctx, tx, external, models.AccountExternal, currency); err != nil {
+			return err
+		}
+
+		if err := ws.ledger.CreateWalletAccount(ctx, tx, id, currency); err != nil {
+			return err
+		}
+
+		if amount == 0 {
+			return nil
+		}
+
+		return ws.postEntryTx(ctx, tx, models.EntryOpeningBalance, id,
+			models.Posting{AccountID: id, Amount: amount},
+			models.Posting{AccountID: external, Amount: -amount},
+		)
+	})
 	if err != nil {
 		if errors.Is(err, transaction.ErrConflictingData) {
 			ws.log.Debug("wallet already exist")
@@ -94,6 +205,10 @@ func (ws *ServiceWallet) CreateWallet(ctx context.Context, amount int64) (*model
 
 func (ws *ServiceWallet) GetWallet(ctx context.Context, id uuid.UUID) (*models.Wallet, error) {
 	const op = "services.wallet.GetWallet"
+
+	ctx, span := startSpan(ctx, "GetWallet", uuidAttr("wallet.id", id))
+	defer span.End()
+
 	if id == uuid.Nil {
 		return nil, services.ErrInvalidWalletID
 	}
@@ -115,53 +230,258 @@ func (ws *ServiceWallet) GetWallet(ctx context.Context, id uuid.UUID) (*models.W
 }
 
 func (ws *ServiceWallet) Deposit(ctx context.Context, walletID uuid.UUID, amount int64) (*models.Wallet, error) {
-	const op = "services.wallet.Deposit"
+	return ws.Apply(ctx, models.OperationRequest{WalletID: walletID, Type: models.Deposit, Amount: amount})
+}
 
-	if amount <= 0 {
-		return nil, services.ErrAmountNegativeValue
+func (ws *ServiceWallet) Withdraw(ctx context.Context, walletID uuid.UUID, amount int64) (*models.Wallet, error) {
+	return ws.Apply(ctx, models.OperationRequest{WalletID: walletID, Type: models.Withdraw, Amount: amount})
+}
+
+// Apply performs the deposit or withdrawal described by req. When
+// req.IdempotencyKey is set, a replay with the same key and payload returns
+// the originally stored result instead of moving money again.
+func (ws *ServiceWallet) Apply(ctx context.Context, req models.OperationRequest) (*models.Wallet, error) {
+	ctx, span := startSpan(ctx, "Apply",
+		uuidAttr("wallet.id", req.WalletID),
+		attribute.String("wallet.operation.type", string(req.Type)),
+		attribute.Int64("wallet.operation.amount", req.Amount),
+	)
+	defer span.End()
+
+	wallet, replayed, err := ws.apply(ctx, req)
+	ws.observeOperation(req, wallet, replayed, err)
+	span.SetAttributes(attribute.Bool("wallet.operation.replayed", replayed))
+	recordOutcome(span, operationOutcome(err), err)
+
+	return wallet, err
+}
+
+// apply performs req and reports whether its result was replayed from an
+// earlier request with the same idempotency key.
+func (ws *ServiceWallet) apply(ctx context.Context, req models.OperationRequest) (*models.Wallet, bool, error) {
+	if err := validateOperationRequest(req); err != nil {
+		return nil, false, err
+	}
+
+	op, txName := "services.wallet.Deposit", "deposit"
+	if req.Type == models.Withdraw {
+		op, txName = "services.wallet.Withdraw", "withdraw"
 	}
 
-	var result *models.Wallet
-	err := ws.txManager.ExecuteInTransaction(ctx, "deposit", func(tx pgxdriver.QueryExecuter) error {
-		newBalance, updatedAt, err :=
-			ws.walletBalanceUpdater.IncreaseBalance(ctx, tx, walletID, amount)
+	requestHash := hashOperationRequest(req)
 
+	var (
+		result   *models.Wallet
+		replayed bool
+	)
+	err := ws.txManager.ExecuteInTransaction(ctx, txName, func(tx pgxdriver.QueryExecuter) error {
+		stored, err := ws.lookupIdempotencyTx(ctx, tx, req.IdempotencyKey, requestHash)
 		if err != nil {
 			return err
 		}
+		if stored != nil {
+			result, replayed = stored, true
+			return nil
+		}
 
-		if err := ws.createOperationTx(ctx, tx, walletID, models.Deposit, amount); err != nil {
+		wallet, operation, err := ws.applyOperationTx(ctx, tx, req)
+		if err != nil {
 			return err
 		}
 
-		result = &models.Wallet{
-			ID:        walletID,
-			Balance:   newBalance,
-			UpdatedAt: updatedAt,
-		}
+		result, replayed = wallet, false
 
-		return nil
+		return ws.saveIdempotencyTx(ctx, tx, req.IdempotencyKey, requestHash, operation, result)
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		if errors.Is(err, storage.ErrInsufficientFunds) {
+			return nil, false, storage.ErrInsufficientFunds
+		}
+
+		if errors.Is(err, services.ErrCurrencyMismatch) {
+			return nil, false, services.ErrCurrencyMismatch
+		}
+
+		var limitErr *services.LimitExceededError
+		if errors.As(err, &limitErr) {
+			return nil, false, limitErr
+		}
+
+		if req.IdempotencyKey != "" && errors.Is(err, transaction.ErrConflictingData) {
+			wallet, err := ws.replayIdempotency(ctx, op, req.IdempotencyKey, requestHash)
+			return wallet, err == nil, err
+		}
+
+		if errors.Is(err, services.ErrIdempotencyKeyReused) {
+			return nil, false, services.ErrIdempotencyKeyReused
+		}
+
+		return nil, false, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return result, nil
+	return result, replayed, nil
 }
 
-func (ws *ServiceWallet) Withdraw(ctx context.Context, walletID uuid.UUID, amount int64) (*models.Wallet, error) {
-	const op = "services.wallet.Withdraw"
+// validateOperationRequest checks the parts of a deposit or withdrawal that
+// do not depend on the wallet.
+func validateOperationRequest(req models.OperationRequest) error {
+	if req.Type != models.Deposit && req.Type != models.Withdraw {
+		return services.ErrInvalidOperationType
+	}
+
+	if req.Amount <= 0 {
+		return services.ErrAmountNegativeValue
+	}
+
+	if req.Currency != "" {
+		if _, ok := models.LookupCurrency(req.Currency); !ok {
+			return services.ErrUnsupportedCurrency
+		}
+	}
+
+	return nil
+}
+
+// applyOperationTx moves the money of a validated deposit or withdrawal in tx
+// and records its operation, journal entry and balance-change event. It
+// returns the wallet as left by the operation.
+func (ws *ServiceWallet) applyOperationTx(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	req models.OperationRequest,
+) (*models.Wallet, *models.Operation, error) {
+
+	entryType, sign := models.EntryDeposit, int64(1)
+	if req.Type == models.Withdraw {
+		entryType, sign = models.EntryWithdraw, -1
+	}
+
+	var (
+		wallet *models.Wallet
+		err    error
+	)
+	if sign > 0 {
+		wallet, err = ws.walletBalanceUpdater.IncreaseBalance(ctx, tx, req.WalletID, req.Amount)
+	} else {
+		wallet, err = ws.walletBalanceUpdater.DecreaseBalance(ctx, tx, req.WalletID, req.Amount)
+	}
+	if errors.Is(err, storage.ErrWalletSharded) {
+		wallet, err = ws.applyShardedTx(ctx, tx, req)
+	}
+	if err != nil {
+		if errors.Is(err, storage.ErrInsufficientFunds) {
+			return nil, nil, storage.ErrInsufficientFunds
+		}
+		return nil, nil, err
+	}
+
+	// The currency of a wallet never changes, so checking it after the
+	// update costs no extra query; a mismatch rolls the update back.
+	if req.Currency != "" && req.Currency != wallet.Currency {
+		return nil, nil, services.ErrCurrencyMismatch
+	}
+
+	if err := ws.checkLimitsTx(ctx, tx, req, wallet, 1); err != nil {
+		return nil, nil, err
+	}
+
+	operation, err := ws.createOperationTx(ctx, tx, wallet, req.Type, req.Amount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = ws.postEntryTx(ctx, tx, entryType, operation.ID,
+		models.Posting{AccountID: req.WalletID, Amount: sign * req.Amount},
+		models.Posting{AccountID: models.ExternalAccountFor(wallet.Currency), Amount: -sign * req.Amount},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return wallet, operation, nil
+}
+
+// Transfer moves amount from one wallet to another in a single transaction.
+// Both wallets are locked up front in a deterministic order, and the debit and
+// credit are recorded as TRANSFER_OUT/TRANSFER_IN operations sharing one transfer id.
+//
+// Wallets in different currencies require an explicit conversion naming an
+// FX quote: the credited amount is converted at the quoted rate, the quote is
+// consumed, and the legs are booked through per-currency FX accounts.
+func (ws *ServiceWallet) Transfer(
+	ctx context.Context,
+	fromWalletID uuid.UUID,
+	toWalletID uuid.UUID,
+	amount int64,
+	conversion *models.Conversion,
+) (*models.Transfer, error) {
+	const op = "services.wallet.Transfer"
+
+	ctx, span := startSpan(ctx, "Transfer",
+		uuidAttr("wallet.from_id", fromWalletID),
+		uuidAttr("wallet.to_id", toWalletID),
+	)
+	defer span.End()
 
 	if amount <= 0 {
 		return nil, services.ErrAmountNegativeValue
 	}
 
-	var result *models.Wallet
-	err := ws.txManager.ExecuteInTransaction(ctx, "withdraw", func(tx pgxdriver.QueryExecuter) error {
-		newBalance, updatedAt, err :=
-			ws.walletBalanceUpdater.DecreaseBalance(ctx, tx, walletID, amount)
+	if fromWalletID == uuid.Nil || toWalletID == uuid.Nil {
+		return nil, services.ErrInvalidWalletID
+	}
+
+	if fromWalletID == toWalletID {
+		return nil, services.ErrSameWallet
+	}
+
+	if conversion != nil && conversion.QuoteID == uuid.Nil {
+		return nil, services.ErrInvalidQuoteID
+	}
+
+	var result *models.Transfer
+	err := ws.txManager.ExecuteInTransaction(ctx, "transfer", func(tx pgxdriver.QueryExecuter) error {
+		var quote *models.FXQuote
+		if conversion != nil {
+			var err error
+			quote, err = ws.usableQuoteTx(ctx, tx, conversion.QuoteID)
+			if err != nil {
+				return err
+			}
+		}
+
+		locked, err := ws.walletLocker.LockWallets(ctx, tx, fromWalletID, toWalletID)
+		if err != nil {
+			return err
+		}
 
+		var fromCurrency, toCurrency string
+		for _, wallet := range locked {
+			if wallet.ID == fromWalletID {
+				fromCurrency = wallet.Currency
+			} else {
+				toCurrency = wallet.Currency
+			}
+		}
+
+		credited := amount
+		switch {
+		case fromCurrency == toCurrency && quote != nil:
+			return services.ErrUnexpectedConversion
+		case fromCurrency != toCurrency && quote == nil:
+			return services.ErrCurrencyMismatch
+		case quote != nil:
+			if quote.FromCurrency != fromCurrency || quote.ToCurrency != toCurrency {
+				return services.ErrCurrencyMismatch
+			}
+			credited, err = convertAmount(amount, quote)
+			if err != nil {
+				return err
+			}
+		}
+
+		fromWallet, err := ws.decreaseBalanceTx(ctx, tx, fromWalletID, amount)
 		if err != nil {
 			if errors.Is(err, storage.ErrInsufficientFunds) {
 				return storage.ErrInsufficientFunds
@@ -169,14 +489,87 @@ func (ws *ServiceWallet) Withdraw(ctx context.Context, walletID uuid.UUID, amoun
 			return err
 		}
 
-		if err := ws.createOperationTx(ctx, tx, walletID, models.Withdraw, amount); err != nil {
+		toWallet, err := ws.increaseBalanceTx(ctx, tx, toWalletID, credited)
+		if err != nil {
+			return err
+		}
+
+		err = ws.checkLimitsTx(ctx, tx, models.OperationRequest{
+			WalletID: fromWalletID,
+			Type:     models.TransferOut,
+			Amount:   amount,
+		}, fromWallet, 1)
+		if err != nil {
+			return err
+		}
+
+		err = ws.checkLimitsTx(ctx, tx, models.OperationRequest{
+			WalletID: toWalletID,
+			Type:     models.TransferIn,
+			Amount:   credited,
+		}, toWallet, 1)
+		if err != nil {
+			return err
+		}
+
+		transferID := uuid.New()
+
+		var rate *string
+		if quote != nil {
+			rate = &quote.Rate
+		}
+
+		operations := []*models.Operation{
+			{
+				ID:         uuid.New(),
+				WalletID:   fromWalletID,
+				Type:       models.TransferOut,
+				Amount:     amount,
+				TransferID: &transferID,
+				FXRate:     rate,
+			},
+			{
+				ID:         uuid.New(),
+				WalletID:   toWalletID,
+				Type:       models.TransferIn,
+				Amount:     credited,
+				TransferID: &transferID,
+				FXRate:     rate,
+			},
+		}
+
+		for i, wallet := range []*models.Wallet{fromWallet, toWallet} {
+			if err := ws.saveOperationTx(ctx, tx, operations[i], wallet); err != nil {
+				return err
+			}
+		}
+
+		postings := []models.Posting{
+			{AccountID: fromWalletID, Amount: -amount},
+			{AccountID: toWalletID, Amount: credited},
+		}
+		if quote != nil {
+			postings, err = ws.conversionPostingsTx(ctx, tx, postings, fromCurrency, toCurrency, amount, credited)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := ws.postEntryTx(ctx, tx, models.EntryTransfer, transferID, postings...); err != nil {
 			return err
 		}
 
-		result = &models.Wallet{
-			ID:        walletID,
-			Balance:   newBalance,
-			UpdatedAt: updatedAt,
+		result = &models.Transfer{
+			ID:             transferID,
+			From:           fromWallet,
+			To:             toWallet,
+			Amount:         amount,
+			CreditedAmount: credited,
+		}
+
+		if quote != nil {
+			result.Rate = quote.Rate
+			return ws.quoteStore.MarkQuoteUsed(ctx, tx, quote.ID)
 		}
 
 		return nil
@@ -187,26 +580,155 @@ func (ws *ServiceWallet) Withdraw(ctx context.Context, walletID uuid.UUID, amoun
 			return nil, storage.ErrInsufficientFunds
 		}
 
+		if errors.Is(err, services.ErrCurrencyMismatch) {
+			return nil, services.ErrCurrencyMismatch
+		}
+
+		for _, known := range []error{
+			services.ErrUnexpectedConversion,
+			storage.ErrQuoteNotFound,
+			services.ErrQuoteUsed,
+			services.ErrQuoteExpired,
+			services.ErrExchangeAmountTooSmall,
+			services.ErrAmountTooLarge,
+		} {
+			if errors.Is(err, known) {
+				return nil, known
+			}
+		}
+
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return result, nil
 }
 
-func (ws *ServiceWallet) createOperationTx(
+// conversionPostingsTx adds the FX legs of a cross-currency movement, so the
+// entry balances within each currency: the debited amount goes into the FX
+// account of the source currency and the credited amount comes out of the FX
+// account of the destination currency.
+func (ws *ServiceWallet) conversionPostingsTx(
 	ctx context.Context,
 	tx pgxdriver.QueryExecuter,
+	postings []models.Posting,
+	fromCurrency string,
+	toCurrency string,
+	debited int64,
+	credited int64,
+) ([]models.Posting, error) {
+
+	fromFX := models.FXAccountFor(fromCurrency)
+	toFX := models.FXAccountFor(toCurrency)
+
+	if err := ws.ledger.EnsureSystemAccount(ctx, tx, fromFX, models.AccountFX, fromCurrency); err != nil {
+		return nil, err
+	}
+	if err := ws.ledger.EnsureSystemAccount(ctx, tx, toFX, models.AccountFX, toCurrency); err != nil {
+		return nil, err
+	}
+
+	return append(postings,
+		models.Posting{AccountID: fromFX, Amount: debited},
+		models.Posting{AccountID: toFX, Amount: -credited},
+	), nil
+}
+
+// ListOperations returns one page of the wallet's operation history, newest
+// first. The returned NextCursor is empty on the last page.
+func (ws *ServiceWallet) ListOperations(
+	ctx context.Context,
 	walletID uuid.UUID,
+	filter models.OperationFilter,
+) (*models.OperationPage, error) {
+	const op = "services.wallet.ListOperations"
+
+	ctx, span := startSpan(ctx, "ListOperations", uuidAttr("wallet.id", walletID))
+	defer span.End()
+
+	if walletID == uuid.Nil {
+		return nil, services.ErrInvalidWalletID
+	}
+
+	after, err := decodeOperationCursor(filter.Cursor)
+	if err != nil {
+		return nil, services.ErrInvalidCursor
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultOperationsLimit
+	}
+	limit = min(limit, maxOperationsLimit)
+
+	if _, err := ws.walletGetter.GetWallet(ctx, walletID); err != nil {
+		if errors.Is(err, storage.ErrWalletNotFound) {
+			return nil, storage.ErrWalletNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	operations, err := ws.operationGetter.GetOperationsByWallet(ctx, walletID, filter, after, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	page := &models.OperationPage{Operations: operations}
+	if len(operations) > limit {
+		page.Operations = operations[:limit]
+
+		last := page.Operations[limit-1]
+		page.NextCursor = encodeOperationCursor(&models.OperationCursor{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		})
+	}
+
+	return page, nil
+}
+
+// postEntryTx records a balanced journal entry for a balance change made in tx.
+func (ws *ServiceWallet) postEntryTx(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	entryType models.JournalEntryType,
+	referenceID uuid.UUID,
+	postings ...models.Posting,
+) error {
+
+	var total int64
+	for _, posting := range postings {
+		total += posting.Amount
+	}
+	if total != 0 {
+		return services.ErrUnbalancedEntry
+	}
+
+	return ws.ledger.PostEntry(ctx, tx, &models.JournalEntry{
+		ID:          uuid.New(),
+		Type:        entryType,
+		ReferenceID: referenceID,
+		Postings:    postings,
+	})
+}
+
+func (ws *ServiceWallet) createOperationTx(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	wallet *models.Wallet,
 	opType models.OperationType,
 	amount int64,
-) error {
+) (*models.Operation, error) {
 
 	operation := &models.Operation{
 		ID:       uuid.New(),
-		WalletID: walletID,
+		WalletID: wallet.ID,
 		Type:     opType,
 		Amount:   amount,
 	}
 
-	return ws.operationSaver.CreateOperation(ctx, tx, operation)
+	if err := ws.saveOperationTx(ctx, tx, operation, wallet); err != nil {
+		return nil, err
+	}
+
+	return operation, nil
 }
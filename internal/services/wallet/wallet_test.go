@@ -2,17 +2,84 @@ package wallet
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/lib/metrics"
+	"wallet-service/internal/services"
 	"wallet-service/internal/services/wallet/mocks"
 	"wallet-service/internal/storage"
 	pgxdriver "wallet-service/pkg/pgx-driver"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"go.uber.org/mock/gomock"
 )
 
+type walletStorage struct {
+	*mocks.MockSaverWallet
+	*mocks.MockGetterWallet
+	*mocks.MockBalanceUpdaterWallet
+	*mocks.MockLockerWallet
+	*mocks.MockBucketBalanceUpdater
+	*mocks.MockHoldBalanceUpdater
+	*mocks.MockStatusUpdaterWallet
+}
+
+func TestNew_Options(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	wallets := walletStorage{
+		mocks.NewMockSaverWallet(ctrl),
+		mocks.NewMockGetterWallet(ctrl),
+		mocks.NewMockBalanceUpdaterWallet(ctrl),
+		mocks.NewMockLockerWallet(ctrl),
+		mocks.NewMockBucketBalanceUpdater(ctrl),
+		mocks.NewMockHoldBalanceUpdater(ctrl),
+		mocks.NewMockStatusUpdaterWallet(ctrl),
+	}
+	limits := models.Limits{DailyWithdrawal: 1000}
+
+	service := New(mocks.NewMockManager(ctrl), slog.Default(),
+		Wallets(wallets),
+		QuoteTTL(time.Minute),
+		DefaultLimits(limits),
+	)
+
+	for _, dependency := range []any{
+		service.walletSaver,
+		service.walletGetter,
+		service.walletBalanceUpdater,
+		service.walletLocker,
+		service.bucketBalanceUpdater,
+		service.holdBalanceUpdater,
+		service.walletStatusUpdater,
+	} {
+		require.Equal(t, wallets, dependency)
+	}
+	require.Equal(t, time.Minute, service.quoteTTL)
+	require.Equal(t, limits, service.limits)
+	require.Nil(t, service.operationSaver)
+}
+
 func TestWalletService_Deposit_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -20,6 +87,9 @@ func TestWalletService_Deposit_Success(t *testing.T) {
 	mockTxManager := mocks.NewMockManager(ctrl)
 	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
 	mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+	mockOutbox := mocks.NewMockOutboxWriter(ctrl)
+	mockLedger := mocks.NewMockLedgerWriter(ctrl)
+	mockLimitStore := mocks.NewMockLimitStore(ctrl)
 
 	ctx := context.Background()
 	walletID := uuid.New()
@@ -41,17 +111,57 @@ func TestWalletService_Deposit_Success(t *testing.T) {
 	mockBalanceUpdater.
 		EXPECT().
 		IncreaseBalance(ctx, gomock.Any(), walletID, amount).
-		Return(newBalance, now, nil)
+		Return(&models.Wallet{ID: walletID, Balance: newBalance, Currency: "USD", UpdatedAt: now}, nil)
+
+	mockLimitStore.
+		EXPECT().
+		GetWalletLimits(ctx, gomock.Any(), walletID).
+		Return(nil, nil)
 
 	mockOperationSaver.
 		EXPECT().
 		CreateOperation(ctx, gomock.Any(), gomock.Any()).
-		Return(nil)
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, operation *models.Operation) error {
+			require.Equal(t, amount, operation.Amount)
+			require.Nil(t, operation.BalanceAfter)
+			return nil
+		})
+
+	mockOutbox.
+		EXPECT().
+		EnqueueEvent(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, event *models.OutboxEvent) error {
+			require.Equal(t, models.EventBalanceChanged, event.EventType)
+			require.Equal(t, walletID, event.AggregateID)
+
+			var payload models.BalanceChanged
+			require.NoError(t, json.Unmarshal(event.Payload, &payload))
+			require.Equal(t, models.Deposit, payload.Type)
+			require.Equal(t, amount, payload.Amount)
+			require.Equal(t, newBalance, payload.Balance)
+			return nil
+		})
+
+	mockLedger.
+		EXPECT().
+		PostEntry(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, entry *models.JournalEntry) error {
+			require.Equal(t, models.EntryDeposit, entry.Type)
+			require.ElementsMatch(t, []models.Posting{
+				{AccountID: walletID, Amount: amount},
+				{AccountID: models.ExternalAccountID, Amount: -amount},
+			}, entry.Postings)
+			return nil
+		})
 
 	service := &ServiceWallet{
 		txManager:            mockTxManager,
 		walletBalanceUpdater: mockBalanceUpdater,
 		operationSaver:       mockOperationSaver,
+		outbox:               mockOutbox,
+		ledger:               mockLedger,
+		limitStore:           mockLimitStore,
+		metrics:              metrics.New(),
 	}
 
 	result, err := service.Deposit(ctx, walletID, amount)
@@ -59,6 +169,9 @@ func TestWalletService_Deposit_Success(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, result)
 	require.Equal(t, newBalance, result.Balance)
+
+	require.Equal(t, 1.0, testutil.ToFloat64(service.metrics.Operations.WithLabelValues("deposit", metrics.OutcomeSuccess)))
+	require.Equal(t, float64(amount), testutil.ToFloat64(service.metrics.OperationAmounts.WithLabelValues("deposit", metrics.OutcomeSuccess, "USD")))
 }
 
 func TestWalletService_Withdraw_InsufficientFunds(t *testing.T) {
@@ -87,16 +200,3151 @@ func TestWalletService_Withdraw_InsufficientFunds(t *testing.T) {
 	mockBalanceUpdater.
 		EXPECT().
 		DecreaseBalance(ctx, gomock.Any(), walletID, amount).
-		Return(int64(0), time.Time{}, storage.ErrInsufficientFunds)
+		Return(nil, storage.ErrInsufficientFunds)
 
 	service := &ServiceWallet{
 		txManager:            mockTxManager,
 		walletBalanceUpdater: mockBalanceUpdater,
 		operationSaver:       mockOperationSaver,
+		metrics:              metrics.New(),
 	}
 
 	_, err := service.Withdraw(ctx, walletID, amount)
 
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "insufficient funds")
+
+	require.Equal(t, 1.0, testutil.ToFloat64(service.metrics.Operations.WithLabelValues("withdraw", metrics.OutcomeInsufficientFunds)))
+	require.Zero(t, testutil.ToFloat64(service.metrics.Operations.WithLabelValues("withdraw", metrics.OutcomeSuccess)))
+}
+
+func TestWalletService_Apply_Span(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+
+	walletID := uuid.New()
+
+	var txSpan trace.SpanContext
+	mockTxManager.
+		EXPECT().
+		ExecuteInTransaction(gomock.Any(), "withdraw", gomock.Any()).
+		DoAndReturn(func(
+			ctx context.Context,
+			name string,
+			fn func(tx pgxdriver.QueryExecuter) error,
+		) error {
+			txSpan = trace.SpanContextFromContext(ctx)
+			return fn(nil)
+		})
+
+	mockBalanceUpdater.
+		EXPECT().
+		DecreaseBalance(gomock.Any(), gomock.Any(), walletID, int64(100)).
+		Return(nil, storage.ErrInsufficientFunds)
+
+	service := &ServiceWallet{
+		txManager:            mockTxManager,
+		walletBalanceUpdater: mockBalanceUpdater,
+	}
+
+	_, err := service.Withdraw(context.Background(), walletID, 100)
+	require.ErrorIs(t, err, storage.ErrInsufficientFunds)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	span := spans[0]
+	require.Equal(t, "ServiceWallet.Apply", span.Name())
+	require.Equal(t, span.SpanContext(), txSpan)
+	require.Contains(t, span.Attributes(), attribute.String("wallet.id", walletID.String()))
+	require.Contains(t, span.Attributes(), attribute.String("wallet.operation.outcome", metrics.OutcomeInsufficientFunds))
+	// A refused withdrawal is an answer, not a failure of the service.
+	require.Equal(t, codes.Unset, span.Status().Code)
+}
+
+func TestWalletService_Transfer_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+	mockLocker := mocks.NewMockLockerWallet(ctrl)
+	mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+	mockOutbox := mocks.NewMockOutboxWriter(ctrl)
+	mockLedger := mocks.NewMockLedgerWriter(ctrl)
+	mockLimitStore := mocks.NewMockLimitStore(ctrl)
+
+	ctx := context.Background()
+	fromID := uuid.New()
+	toID := uuid.New()
+	amount := int64(40)
+	now := time.Now()
+
+	mockTxManager.
+		EXPECT().
+		ExecuteInTransaction(ctx, "transfer", gomock.Any()).
+		DoAndReturn(func(
+			ctx context.Context,
+			name string,
+			fn func(tx pgxdriver.QueryExecuter) error,
+		) error {
+			return fn(nil)
+		})
+
+	gomock.InOrder(
+		mockLocker.
+			EXPECT().
+			LockWallets(ctx, gomock.Any(), fromID, toID).
+			Return([]*models.Wallet{{ID: fromID, Currency: "USD"}, {ID: toID, Currency: "USD"}}, nil),
+		mockBalanceUpdater.
+			EXPECT().
+			DecreaseBalance(ctx, gomock.Any(), fromID, amount).
+			Return(&models.Wallet{ID: fromID, Balance: 60, UpdatedAt: now}, nil),
+		mockBalanceUpdater.
+			EXPECT().
+			IncreaseBalance(ctx, gomock.Any(), toID, amount).
+			Return(&models.Wallet{ID: toID, Balance: 140, UpdatedAt: now}, nil),
+	)
+
+	var saved []*models.Operation
+	mockOperationSaver.
+		EXPECT().
+		CreateOperation(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, operation *models.Operation) error {
+			saved = append(saved, operation)
+			return nil
+		}).
+		Times(2)
+
+	mockOutbox.
+		EXPECT().
+		EnqueueEvent(ctx, gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(2)
+
+	mockLedger.
+		EXPECT().
+		PostEntry(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, entry *models.JournalEntry) error {
+			require.Equal(t, models.EntryTransfer, entry.Type)
+			require.ElementsMatch(t, []models.Posting{
+				{AccountID: fromID, Amount: -amount},
+				{AccountID: toID, Amount: amount},
+			}, entry.Postings)
+			return nil
+		})
+
+	mockLimitStore.
+		EXPECT().
+		GetWalletLimits(ctx, gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		Times(2)
+
+	service := &ServiceWallet{
+		txManager:            mockTxManager,
+		walletBalanceUpdater: mockBalanceUpdater,
+		walletLocker:         mockLocker,
+		operationSaver:       mockOperationSaver,
+		outbox:               mockOutbox,
+		ledger:               mockLedger,
+		limitStore:           mockLimitStore,
+	}
+
+	result, err := service.Transfer(ctx, fromID, toID, amount, nil)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(60), result.From.Balance)
+	require.Equal(t, int64(140), result.To.Balance)
+
+	require.Len(t, saved, 2)
+	require.Equal(t, models.TransferOut, saved[0].Type)
+	require.Equal(t, fromID, saved[0].WalletID)
+	require.Equal(t, models.TransferIn, saved[1].Type)
+	require.Equal(t, toID, saved[1].WalletID)
+	require.Equal(t, result.ID, *saved[0].TransferID)
+	require.Equal(t, result.ID, *saved[1].TransferID)
+}
+
+func TestWalletService_Transfer_InsufficientFunds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+	mockLocker := mocks.NewMockLockerWallet(ctrl)
+	mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+
+	ctx := context.Background()
+	fromID := uuid.New()
+	toID := uuid.New()
+	amount := int64(40)
+
+	mockTxManager.
+		EXPECT().
+		ExecuteInTransaction(ctx, "transfer", gomock.Any()).
+		DoAndReturn(func(
+			ctx context.Context,
+			name string,
+			fn func(tx pgxdriver.QueryExecuter) error,
+		) error {
+			return fn(nil)
+		})
+
+	mockLocker.
+		EXPECT().
+		LockWallets(ctx, gomock.Any(), fromID, toID).
+		Return([]*models.Wallet{{ID: fromID, Currency: "USD"}, {ID: toID, Currency: "USD"}}, nil)
+
+	mockBalanceUpdater.
+		EXPECT().
+		DecreaseBalance(ctx, gomock.Any(), fromID, amount).
+		Return(nil, storage.ErrInsufficientFunds)
+
+	service := &ServiceWallet{
+		txManager:            mockTxManager,
+		walletBalanceUpdater: mockBalanceUpdater,
+		walletLocker:         mockLocker,
+		operationSaver:       mockOperationSaver,
+	}
+
+	_, err := service.Transfer(ctx, fromID, toID, amount, nil)
+
+	require.ErrorIs(t, err, storage.ErrInsufficientFunds)
+}
+
+func TestWalletService_Transfer_SameWallet(t *testing.T) {
+	service := &ServiceWallet{}
+
+	id := uuid.New()
+	_, err := service.Transfer(context.Background(), id, id, 10, nil)
+
+	require.ErrorIs(t, err, services.ErrSameWallet)
+}
+
+func TestWalletService_Transfer_ConversionWithoutQuote(t *testing.T) {
+	service := &ServiceWallet{}
+
+	_, err := service.Transfer(context.Background(), uuid.New(), uuid.New(), 10, &models.Conversion{})
+
+	require.ErrorIs(t, err, services.ErrInvalidQuoteID)
+}
+
+func TestWalletService_Apply_IdempotentReplay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+	mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+	mockIdempotencyStore := mocks.NewMockIdempotencyStore(ctrl)
+	mockLimitStore := mocks.NewMockLimitStore(ctrl)
+
+	ctx := context.Background()
+	walletID := uuid.New()
+	amount := int64(100)
+	key := "retry-1"
+	now := time.Now()
+
+	mockTxManager.
+		EXPECT().
+		ExecuteInTransaction(ctx, "deposit", gomock.Any()).
+		DoAndReturn(func(
+			ctx context.Context,
+			name string,
+			fn func(tx pgxdriver.QueryExecuter) error,
+		) error {
+			return fn(nil)
+		})
+
+	mockIdempotencyStore.
+		EXPECT().
+		GetIdempotencyRecord(ctx, gomock.Any(), key).
+		Return(&models.IdempotencyRecord{
+			Key:             key,
+			WalletID:        walletID,
+			RequestHash:     hashOperationRequest(models.OperationRequest{WalletID: walletID, Type: models.Deposit, Amount: amount}),
+			Balance:         300,
+			WalletUpdatedAt: now,
+		}, nil)
+
+	service := &ServiceWallet{
+		txManager:            mockTxManager,
+		walletBalanceUpdater: mockBalanceUpdater,
+		operationSaver:       mockOperationSaver,
+		idempotencyStore:     mockIdempotencyStore,
+		limitStore:           mockLimitStore,
+	}
+
+	result, err := service.Apply(ctx, models.OperationRequest{
+		WalletID:       walletID,
+		Type:           models.Deposit,
+		Amount:         amount,
+		IdempotencyKey: key,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, int64(300), result.Balance)
+}
+
+func TestWalletService_Apply_IdempotentFirstCall(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+	mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+	mockOutbox := mocks.NewMockOutboxWriter(ctrl)
+	mockLedger := mocks.NewMockLedgerWriter(ctrl)
+	mockIdempotencyStore := mocks.NewMockIdempotencyStore(ctrl)
+	mockLimitStore := mocks.NewMockLimitStore(ctrl)
+
+	ctx := context.Background()
+	walletID := uuid.New()
+	amount := int64(100)
+	key := "retry-1"
+	now := time.Now()
+
+	mockTxManager.
+		EXPECT().
+		ExecuteInTransaction(ctx, "deposit", gomock.Any()).
+		DoAndReturn(func(
+			ctx context.Context,
+			name string,
+			fn func(tx pgxdriver.QueryExecuter) error,
+		) error {
+			return fn(nil)
+		})
+
+	mockIdempotencyStore.
+		EXPECT().
+		GetIdempotencyRecord(ctx, gomock.Any(), key).
+		Return(nil, storage.ErrIdempotencyKeyNotFound)
+
+	mockBalanceUpdater.
+		EXPECT().
+		IncreaseBalance(ctx, gomock.Any(), walletID, amount).
+		Return(&models.Wallet{ID: walletID, Balance: 100, Currency: "USD", UpdatedAt: now}, nil)
+
+	mockLimitStore.
+		EXPECT().
+		GetWalletLimits(ctx, gomock.Any(), walletID).
+		Return(nil, nil)
+
+	mockOperationSaver.
+		EXPECT().
+		CreateOperation(ctx, gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockOutbox.
+		EXPECT().
+		EnqueueEvent(ctx, gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockIdempotencyStore.
+		EXPECT().
+		SaveIdempotencyRecord(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, record *models.IdempotencyRecord) error {
+			require.Equal(t, key, record.Key)
+			require.Equal(t, int64(100), record.Balance)
+			return nil
+		})
+
+	mockLedger.
+		EXPECT().
+		PostEntry(ctx, gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	service := &ServiceWallet{
+		txManager:            mockTxManager,
+		walletBalanceUpdater: mockBalanceUpdater,
+		operationSaver:       mockOperationSaver,
+		outbox:               mockOutbox,
+		ledger:               mockLedger,
+		idempotencyStore:     mockIdempotencyStore,
+		limitStore:           mockLimitStore,
+	}
+
+	result, err := service.Apply(ctx, models.OperationRequest{
+		WalletID:       walletID,
+		Type:           models.Deposit,
+		Amount:         amount,
+		IdempotencyKey: key,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, int64(100), result.Balance)
+}
+
+func TestWalletService_Apply_IdempotencyPayloadMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockIdempotencyStore := mocks.NewMockIdempotencyStore(ctrl)
+
+	ctx := context.Background()
+	walletID := uuid.New()
+	key := "retry-2"
+
+	mockTxManager.
+		EXPECT().
+		ExecuteInTransaction(ctx, "withdraw", gomock.Any()).
+		DoAndReturn(func(
+			ctx context.Context,
+			name string,
+			fn func(tx pgxdriver.QueryExecuter) error,
+		) error {
+			return fn(nil)
+		})
+
+	mockIdempotencyStore.
+		EXPECT().
+		GetIdempotencyRecord(ctx, gomock.Any(), key).
+		Return(&models.IdempotencyRecord{
+			Key:         key,
+			WalletID:    walletID,
+			RequestHash: hashOperationRequest(models.OperationRequest{WalletID: walletID, Type: models.Withdraw, Amount: 10}),
+		}, nil)
+
+	service := &ServiceWallet{
+		txManager:        mockTxManager,
+		idempotencyStore: mockIdempotencyStore,
+	}
+
+	_, err := service.Apply(ctx, models.OperationRequest{
+		WalletID:       walletID,
+		Type:           models.Withdraw,
+		Amount:         20,
+		IdempotencyKey: key,
+	})
+
+	require.ErrorIs(t, err, services.ErrIdempotencyKeyReused)
+}
+
+func TestWalletService_ListOperations_NextCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGetter := mocks.NewMockGetterWallet(ctrl)
+	mockOperationGetter := mocks.NewMockOperationGetter(ctrl)
+
+	ctx := context.Background()
+	walletID := uuid.New()
+	now := time.Now().UTC()
+
+	operations := []*models.Operation{
+		{ID: uuid.New(), WalletID: walletID, CreatedAt: now},
+		{ID: uuid.New(), WalletID: walletID, CreatedAt: now.Add(-time.Second)},
+		{ID: uuid.New(), WalletID: walletID, CreatedAt: now.Add(-2 * time.Second)},
+	}
+
+	mockGetter.
+		EXPECT().
+		GetWallet(ctx, walletID).
+		Return(&models.Wallet{ID: walletID}, nil)
+
+	mockOperationGetter.
+		EXPECT().
+		GetOperationsByWallet(ctx, walletID, gomock.Any(), nil, 3).
+		Return(operations, nil)
+
+	service := &ServiceWallet{
+		walletGetter:    mockGetter,
+		operationGetter: mockOperationGetter,
+	}
+
+	page, err := service.ListOperations(ctx, walletID, models.OperationFilter{Limit: 2})
+
+	require.NoError(t, err)
+	require.Len(t, page.Operations, 2)
+	require.NotEmpty(t, page.NextCursor)
+
+	cursor, err := decodeOperationCursor(page.NextCursor)
+	require.NoError(t, err)
+	require.Equal(t, operations[1].ID, cursor.ID)
+	require.True(t, operations[1].CreatedAt.Equal(cursor.CreatedAt))
+}
+
+func TestWalletService_ListOperations_InvalidCursor(t *testing.T) {
+	service := &ServiceWallet{}
+
+	_, err := service.ListOperations(context.Background(), uuid.New(), models.OperationFilter{Cursor: "%%%"})
+
+	require.ErrorIs(t, err, services.ErrInvalidCursor)
+}
+
+func runInTx(mockTxManager *mocks.MockManager, name string) {
+	mockTxManager.
+		EXPECT().
+		ExecuteInTransaction(gomock.Any(), name, gomock.Any()).
+		DoAndReturn(func(
+			ctx context.Context,
+			name string,
+			fn func(tx pgxdriver.QueryExecuter) error,
+		) error {
+			return fn(nil)
+		})
+}
+
+func TestWalletService_Authorize_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockHoldUpdater := mocks.NewMockHoldBalanceUpdater(ctrl)
+	mockHoldStore := mocks.NewMockHoldStore(ctrl)
+
+	ctx := context.Background()
+	walletID := uuid.New()
+
+	runInTx(mockTxManager, "authorize")
+
+	mockHoldUpdater.
+		EXPECT().
+		HoldBalance(ctx, gomock.Any(), walletID, int64(30)).
+		Return(&models.Wallet{ID: walletID, Balance: 100, HeldBalance: 30, AvailableBalance: 70}, nil)
+
+	mockHoldStore.
+		EXPECT().
+		CreateHold(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, hold *models.Hold) (*models.Hold, error) {
+			require.Equal(t, models.HoldActive, hold.Status)
+			require.WithinDuration(t, time.Now().Add(defaultHoldTTL), hold.ExpiresAt, time.Second)
+			return hold, nil
+		})
+
+	service := &ServiceWallet{
+		txManager:          mockTxManager,
+		holdBalanceUpdater: mockHoldUpdater,
+		holdStore:          mockHoldStore,
+	}
+
+	result, err := service.Authorize(ctx, walletID, 30, 0)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(70), result.Wallet.AvailableBalance)
+	require.Equal(t, int64(30), result.Hold.Amount)
+}
+
+func TestWalletService_Capture_FrozenWallet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockHoldUpdater := mocks.NewMockHoldBalanceUpdater(ctrl)
+	mockHoldStore := mocks.NewMockHoldStore(ctrl)
+
+	ctx := context.Background()
+	hold := &models.Hold{
+		ID:        uuid.New(),
+		WalletID:  uuid.New(),
+		Amount:    50,
+		Status:    models.HoldActive,
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+
+	runInTx(mockTxManager, "capture")
+
+	mockHoldStore.
+		EXPECT().
+		GetHoldForUpdate(ctx, gomock.Any(), hold.ID).
+		Return(hold, nil)
+
+	mockHoldUpdater.
+		EXPECT().
+		CaptureHeldBalance(ctx, gomock.Any(), hold.WalletID, int64(50), int64(50)).
+		Return(nil, storage.ErrWalletFrozen)
+
+	service := &ServiceWallet{
+		txManager:          mockTxManager,
+		holdBalanceUpdater: mockHoldUpdater,
+		holdStore:          mockHoldStore,
+	}
+
+	_, err := service.Capture(ctx, hold.ID, 0)
+
+	require.ErrorIs(t, err, storage.ErrWalletFrozen)
+}
+
+func TestWalletService_Authorize_InvalidTTL(t *testing.T) {
+	service := &ServiceWallet{}
+
+	_, err := service.Authorize(context.Background(), uuid.New(), 10, maxHoldTTL+time.Second)
+
+	require.ErrorIs(t, err, services.ErrInvalidHoldTTL)
+}
+
+func TestWalletService_Capture_Partial(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockHoldUpdater := mocks.NewMockHoldBalanceUpdater(ctrl)
+	mockHoldStore := mocks.NewMockHoldStore(ctrl)
+	mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+	mockOutbox := mocks.NewMockOutboxWriter(ctrl)
+	mockLedger := mocks.NewMockLedgerWriter(ctrl)
+	mockLimitStore := mocks.NewMockLimitStore(ctrl)
+
+	ctx := context.Background()
+	walletID := uuid.New()
+	hold := &models.Hold{
+		ID:        uuid.New(),
+		WalletID:  walletID,
+		Amount:    50,
+		Status:    models.HoldActive,
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+
+	runInTx(mockTxManager, "capture")
+
+	mockHoldStore.
+		EXPECT().
+		GetHoldForUpdate(ctx, gomock.Any(), hold.ID).
+		Return(hold, nil)
+
+	mockHoldUpdater.
+		EXPECT().
+		CaptureHeldBalance(ctx, gomock.Any(), walletID, int64(50), int64(20)).
+		Return(&models.Wallet{ID: walletID, Balance: 80, Currency: "USD"}, nil)
+
+	mockHoldStore.
+		EXPECT().
+		UpdateHoldStatus(ctx, gomock.Any(), hold.ID, models.HoldCaptured, int64(20)).
+		Return(&models.Hold{ID: hold.ID, WalletID: walletID, Status: models.HoldCaptured, CapturedAmount: 20}, nil)
+
+	mockOperationSaver.
+		EXPECT().
+		CreateOperation(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, operation *models.Operation) error {
+			require.Equal(t, models.Capture, operation.Type)
+			require.Equal(t, int64(20), operation.Amount)
+			require.Equal(t, hold.ID, *operation.HoldID)
+			return nil
+		})
+
+	mockOutbox.
+		EXPECT().
+		EnqueueEvent(ctx, gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockLedger.
+		EXPECT().
+		PostEntry(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, entry *models.JournalEntry) error {
+			require.Equal(t, models.EntryCapture, entry.Type)
+			require.ElementsMatch(t, []models.Posting{
+				{AccountID: walletID, Amount: -20},
+				{AccountID: models.ExternalAccountID, Amount: 20},
+			}, entry.Postings)
+			return nil
+		})
+
+	mockLimitStore.
+		EXPECT().
+		GetWalletLimits(ctx, gomock.Any(), gomock.Any()).
+		Return(nil, nil)
+
+	service := &ServiceWallet{
+		txManager:          mockTxManager,
+		holdBalanceUpdater: mockHoldUpdater,
+		holdStore:          mockHoldStore,
+		operationSaver:     mockOperationSaver,
+		outbox:             mockOutbox,
+		ledger:             mockLedger,
+		limitStore:         mockLimitStore,
+	}
+
+	result, err := service.Capture(ctx, hold.ID, 20)
+
+	require.NoError(t, err)
+	require.Equal(t, models.HoldCaptured, result.Hold.Status)
+	require.Equal(t, int64(80), result.Wallet.Balance)
+}
+
+func TestWalletService_Capture_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		hold    *models.Hold
+		amount  int64
+		wantErr error
+	}{
+		{
+			name:    "exceeds hold",
+			hold:    &models.Hold{Amount: 10, Status: models.HoldActive, ExpiresAt: time.Now().Add(time.Minute)},
+			amount:  11,
+			wantErr: services.ErrCaptureExceedsHold,
+		},
+		{
+			name:    "expired",
+			hold:    &models.Hold{Amount: 10, Status: models.HoldActive, ExpiresAt: time.Now().Add(-time.Minute)},
+			wantErr: services.ErrHoldExpired,
+		},
+		{
+			name:    "already voided",
+			hold:    &models.Hold{Amount: 10, Status: models.HoldVoided, ExpiresAt: time.Now().Add(time.Minute)},
+			wantErr: services.ErrHoldNotActive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTxManager := mocks.NewMockManager(ctrl)
+			mockHoldStore := mocks.NewMockHoldStore(ctrl)
+
+			tt.hold.ID = uuid.New()
+
+			runInTx(mockTxManager, "capture")
+
+			mockHoldStore.
+				EXPECT().
+				GetHoldForUpdate(gomock.Any(), gomock.Any(), tt.hold.ID).
+				Return(tt.hold, nil)
+
+			service := &ServiceWallet{
+				txManager: mockTxManager,
+				holdStore: mockHoldStore,
+			}
+
+			_, err := service.Capture(context.Background(), tt.hold.ID, tt.amount)
+
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWalletService_ExpireHolds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockHoldUpdater := mocks.NewMockHoldBalanceUpdater(ctrl)
+	mockHoldStore := mocks.NewMockHoldStore(ctrl)
+
+	ctx := context.Background()
+	holds := []*models.Hold{
+		{ID: uuid.New(), WalletID: uuid.New(), Amount: 5, Status: models.HoldActive},
+		{ID: uuid.New(), WalletID: uuid.New(), Amount: 7, Status: models.HoldActive},
+	}
+
+	runInTx(mockTxManager, "expire_holds")
+
+	mockHoldStore.
+		EXPECT().
+		GetExpiredHoldsForUpdate(ctx, gomock.Any(), gomock.Any(), 10).
+		Return(holds, nil)
+
+	for _, hold := range holds {
+		mockHoldUpdater.
+			EXPECT().
+			SettleHeldBalance(ctx, gomock.Any(), hold.WalletID, hold.Amount).
+			Return(&models.Wallet{ID: hold.WalletID}, nil)
+
+		mockHoldStore.
+			EXPECT().
+			UpdateHoldStatus(ctx, gomock.Any(), hold.ID, models.HoldExpired, int64(0)).
+			Return(&models.Hold{ID: hold.ID, Status: models.HoldExpired}, nil)
+	}
+
+	service := &ServiceWallet{
+		txManager:          mockTxManager,
+		holdBalanceUpdater: mockHoldUpdater,
+		holdStore:          mockHoldStore,
+	}
+
+	expired, err := service.ExpireHolds(ctx, 10)
+
+	require.NoError(t, err)
+	require.Equal(t, 2, expired)
+}
+
+func TestWalletService_CreateWallet_OpeningEntry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockSaver := mocks.NewMockSaverWallet(ctrl)
+	mockLedger := mocks.NewMockLedgerWriter(ctrl)
+
+	ctx := context.Background()
+	amount := int64(250)
+
+	runInTx(mockTxManager, "create_wallet")
+
+	var walletID uuid.UUID
+	mockSaver.
+		EXPECT().
+		CreateWallet(ctx, gomock.Any(), gomock.Any(), amount, models.DefaultCurrency).
+		DoAndReturn(func(
+			_ context.Context,
+			_ pgxdriver.QueryExecuter,
+			id uuid.UUID,
+			balance int64,
+			currency string,
+		) (*models.Wallet, error) {
+			walletID = id
+			return &models.Wallet{ID: id, Balance: balance, Currency: currency}, nil
+		})
+
+	mockLedger.
+		EXPECT().
+		EnsureSystemAccount(ctx, gomock.Any(), models.ExternalAccountID, models.AccountExternal, models.DefaultCurrency).
+		Return(nil)
+
+	mockLedger.
+		EXPECT().
+		CreateWalletAccount(ctx, gomock.Any(), gomock.Any(), models.DefaultCurrency).
+		Return(nil)
+
+	mockLedger.
+		EXPECT().
+		PostEntry(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, entry *models.JournalEntry) error {
+			require.Equal(t, models.EntryOpeningBalance, entry.Type)
+			require.ElementsMatch(t, []models.Posting{
+				{AccountID: walletID, Amount: amount},
+				{AccountID: models.ExternalAccountID, Amount: -amount},
+			}, entry.Postings)
+			return nil
+		})
+
+	service := &ServiceWallet{
+		txManager:   mockTxManager,
+		walletSaver: mockSaver,
+		ledger:      mockLedger,
+	}
+
+	wallet, err := service.CreateWallet(ctx, amount, "")
+
+	require.NoError(t, err)
+	require.Equal(t, amount, wallet.Balance)
+}
+
+func TestWalletService_PostEntry_Unbalanced(t *testing.T) {
+	service := &ServiceWallet{}
+
+	err := service.postEntryTx(context.Background(), nil, models.EntryDeposit, uuid.New(),
+		models.Posting{AccountID: uuid.New(), Amount: 10},
+		models.Posting{AccountID: models.ExternalAccountID, Amount: -9},
+	)
+
+	require.ErrorIs(t, err, services.ErrUnbalancedEntry)
+}
+
+func TestWalletService_CreateWallet_UnsupportedCurrency(t *testing.T) {
+	service := &ServiceWallet{}
+
+	_, err := service.CreateWallet(context.Background(), 10, "XYZ")
+
+	require.ErrorIs(t, err, services.ErrUnsupportedCurrency)
+}
+
+func TestWalletService_Apply_CurrencyMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+
+	ctx := context.Background()
+	walletID := uuid.New()
+
+	runInTx(mockTxManager, "deposit")
+
+	mockBalanceUpdater.
+		EXPECT().
+		IncreaseBalance(ctx, gomock.Any(), walletID, int64(100)).
+		Return(&models.Wallet{ID: walletID, Balance: 100, Currency: "USD"}, nil)
+
+	service := &ServiceWallet{
+		txManager:            mockTxManager,
+		walletBalanceUpdater: mockBalanceUpdater,
+	}
+
+	_, err := service.Apply(ctx, models.OperationRequest{
+		WalletID: walletID,
+		Type:     models.Deposit,
+		Amount:   100,
+		Currency: "EUR",
+	})
+
+	require.ErrorIs(t, err, services.ErrCurrencyMismatch)
+}
+
+func TestWalletService_Transfer_CrossCurrency(t *testing.T) {
+	fromID := uuid.New()
+	toID := uuid.New()
+	locked := []*models.Wallet{{ID: fromID, Currency: "EUR"}, {ID: toID, Currency: "USD"}}
+
+	t.Run("without conversion", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTxManager := mocks.NewMockManager(ctrl)
+		mockLocker := mocks.NewMockLockerWallet(ctrl)
+
+		ctx := context.Background()
+
+		runInTx(mockTxManager, "transfer")
+
+		mockLocker.
+			EXPECT().
+			LockWallets(ctx, gomock.Any(), fromID, toID).
+			Return(locked, nil)
+
+		service := &ServiceWallet{
+			txManager:    mockTxManager,
+			walletLocker: mockLocker,
+		}
+
+		_, err := service.Transfer(ctx, fromID, toID, 100, nil)
+
+		require.ErrorIs(t, err, services.ErrCurrencyMismatch)
+	})
+
+	t.Run("with conversion", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTxManager := mocks.NewMockManager(ctrl)
+		mockLocker := mocks.NewMockLockerWallet(ctrl)
+		mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+		mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+		mockOutbox := mocks.NewMockOutboxWriter(ctrl)
+		mockLedger := mocks.NewMockLedgerWriter(ctrl)
+		mockLimitStore := mocks.NewMockLimitStore(ctrl)
+		mockQuoteStore := mocks.NewMockQuoteStore(ctrl)
+
+		ctx := context.Background()
+		quoteID := uuid.New()
+
+		runInTx(mockTxManager, "transfer")
+
+		mockQuoteStore.
+			EXPECT().
+			GetQuoteForUpdate(ctx, gomock.Any(), quoteID).
+			Return(&models.FXQuote{
+				ID:           quoteID,
+				FromCurrency: "EUR",
+				ToCurrency:   "USD",
+				Rate:         "1.0825",
+				ExpiresAt:    time.Now().Add(time.Minute),
+			}, nil)
+
+		mockLocker.
+			EXPECT().
+			LockWallets(ctx, gomock.Any(), fromID, toID).
+			Return(locked, nil)
+
+		mockBalanceUpdater.
+			EXPECT().
+			DecreaseBalance(ctx, gomock.Any(), fromID, int64(100)).
+			Return(&models.Wallet{ID: fromID, Currency: "EUR"}, nil)
+
+		mockBalanceUpdater.
+			EXPECT().
+			IncreaseBalance(ctx, gomock.Any(), toID, int64(108)).
+			Return(&models.Wallet{ID: toID, Balance: 108, Currency: "USD"}, nil)
+
+		mockOperationSaver.
+			EXPECT().
+			CreateOperation(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, operation *models.Operation) error {
+				require.Equal(t, "1.0825", *operation.FXRate)
+				return nil
+			}).
+			Times(2)
+
+		mockOutbox.
+			EXPECT().
+			EnqueueEvent(ctx, gomock.Any(), gomock.Any()).
+			Return(nil).
+			Times(2)
+
+		mockLedger.
+			EXPECT().
+			EnsureSystemAccount(ctx, gomock.Any(), gomock.Any(), models.AccountFX, gomock.Any()).
+			Return(nil).
+			Times(2)
+
+		mockLedger.
+			EXPECT().
+			PostEntry(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, entry *models.JournalEntry) error {
+				require.ElementsMatch(t, []models.Posting{
+					{AccountID: fromID, Amount: -100},
+					{AccountID: models.FXAccountFor("EUR"), Amount: 100},
+					{AccountID: models.FXAccountFor("USD"), Amount: -108},
+					{AccountID: toID, Amount: 108},
+				}, entry.Postings)
+				return nil
+			})
+
+		mockLimitStore.
+			EXPECT().
+			GetWalletLimits(ctx, gomock.Any(), gomock.Any()).
+			Return(nil, nil).
+			Times(2)
+
+		mockQuoteStore.
+			EXPECT().
+			MarkQuoteUsed(ctx, gomock.Any(), quoteID).
+			Return(nil)
+
+		service := &ServiceWallet{
+			txManager:            mockTxManager,
+			walletLocker:         mockLocker,
+			walletBalanceUpdater: mockBalanceUpdater,
+			operationSaver:       mockOperationSaver,
+			outbox:               mockOutbox,
+			ledger:               mockLedger,
+			limitStore:           mockLimitStore,
+			quoteStore:           mockQuoteStore,
+		}
+
+		// 1.00 EUR at 1.0825 is 1.0825 USD, rounded down to whole cents.
+		result, err := service.Transfer(ctx, fromID, toID, 100, &models.Conversion{QuoteID: quoteID})
+
+		require.NoError(t, err)
+		require.Equal(t, int64(108), result.CreditedAmount)
+		require.Equal(t, "1.0825", result.Rate)
+	})
+
+	t.Run("quote for other currencies", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockTxManager := mocks.NewMockManager(ctrl)
+		mockLocker := mocks.NewMockLockerWallet(ctrl)
+		mockQuoteStore := mocks.NewMockQuoteStore(ctrl)
+
+		ctx := context.Background()
+		quoteID := uuid.New()
+
+		runInTx(mockTxManager, "transfer")
+
+		mockQuoteStore.
+			EXPECT().
+			GetQuoteForUpdate(ctx, gomock.Any(), quoteID).
+			Return(&models.FXQuote{
+				ID:           quoteID,
+				FromCurrency: "USD",
+				ToCurrency:   "EUR",
+				Rate:         "0.92",
+				ExpiresAt:    time.Now().Add(time.Minute),
+			}, nil)
+
+		mockLocker.
+			EXPECT().
+			LockWallets(ctx, gomock.Any(), fromID, toID).
+			Return(locked, nil)
+
+		service := &ServiceWallet{
+			txManager:    mockTxManager,
+			walletLocker: mockLocker,
+			quoteStore:   mockQuoteStore,
+		}
+
+		_, err := service.Transfer(ctx, fromID, toID, 100, &models.Conversion{QuoteID: quoteID})
+
+		require.ErrorIs(t, err, services.ErrCurrencyMismatch)
+	})
+}
+
+func TestWalletService_Quote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRates := mocks.NewMockFXRateProvider(ctrl)
+	mockQuoteStore := mocks.NewMockQuoteStore(ctrl)
+
+	ctx := context.Background()
+
+	mockRates.
+		EXPECT().
+		Rate(ctx, "EUR", "USD").
+		Return(big.NewRat(433, 400), nil)
+
+	mockQuoteStore.
+		EXPECT().
+		CreateQuote(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, quote *models.FXQuote) (*models.FXQuote, error) {
+			require.Equal(t, "1.0825", quote.Rate)
+			require.WithinDuration(t, time.Now().Add(time.Minute), quote.ExpiresAt, time.Second)
+			return quote, nil
+		})
+
+	service := &ServiceWallet{
+		fxRates:    mockRates,
+		quoteStore: mockQuoteStore,
+		quoteTTL:   time.Minute,
+	}
+
+	quote, err := service.Quote(ctx, "EUR", "USD")
+
+	require.NoError(t, err)
+	require.Equal(t, "EUR", quote.FromCurrency)
+}
+
+func TestWalletService_Exchange_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockQuoteStore := mocks.NewMockQuoteStore(ctrl)
+	mockLocker := mocks.NewMockLockerWallet(ctrl)
+	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+	mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+	mockOutbox := mocks.NewMockOutboxWriter(ctrl)
+	mockLedger := mocks.NewMockLedgerWriter(ctrl)
+	mockLimitStore := mocks.NewMockLimitStore(ctrl)
+
+	ctx := context.Background()
+	quoteID := uuid.New()
+	fromID := uuid.New()
+	toID := uuid.New()
+
+	runInTx(mockTxManager, "exchange")
+
+	mockQuoteStore.
+		EXPECT().
+		GetQuoteForUpdate(ctx, gomock.Any(), quoteID).
+		Return(&models.FXQuote{
+			ID:           quoteID,
+			FromCurrency: "USD",
+			ToCurrency:   "JPY",
+			Rate:         "151.3",
+			ExpiresAt:    time.Now().Add(time.Minute),
+		}, nil)
+
+	mockLocker.
+		EXPECT().
+		LockWallets(ctx, gomock.Any(), fromID, toID).
+		Return([]*models.Wallet{{ID: fromID, Currency: "USD"}, {ID: toID, Currency: "JPY"}}, nil)
+
+	// 12.34 USD at 151.3 is 1867.042 JPY, rounded down to whole yen.
+	mockBalanceUpdater.
+		EXPECT().
+		DecreaseBalance(ctx, gomock.Any(), fromID, int64(1234)).
+		Return(&models.Wallet{ID: fromID, Currency: "USD"}, nil)
+
+	mockBalanceUpdater.
+		EXPECT().
+		IncreaseBalance(ctx, gomock.Any(), toID, int64(1867)).
+		Return(&models.Wallet{ID: toID, Balance: 1867, Currency: "JPY"}, nil)
+
+	var saved []*models.Operation
+	mockOperationSaver.
+		EXPECT().
+		CreateOperation(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, operation *models.Operation) error {
+			saved = append(saved, operation)
+			return nil
+		}).
+		Times(2)
+
+	mockOutbox.
+		EXPECT().
+		EnqueueEvent(ctx, gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(2)
+
+	mockLedger.
+		EXPECT().
+		EnsureSystemAccount(ctx, gomock.Any(), gomock.Any(), models.AccountFX, gomock.Any()).
+		Return(nil).
+		Times(2)
+
+	mockLedger.
+		EXPECT().
+		PostEntry(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, entry *models.JournalEntry) error {
+			require.Equal(t, models.EntryExchange, entry.Type)
+			return nil
+		})
+
+	mockQuoteStore.
+		EXPECT().
+		MarkQuoteUsed(ctx, gomock.Any(), quoteID).
+		Return(nil)
+
+	mockLimitStore.
+		EXPECT().
+		GetWalletLimits(ctx, gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		Times(2)
+
+	service := &ServiceWallet{
+		txManager:            mockTxManager,
+		walletLocker:         mockLocker,
+		walletBalanceUpdater: mockBalanceUpdater,
+		operationSaver:       mockOperationSaver,
+		outbox:               mockOutbox,
+		ledger:               mockLedger,
+		quoteStore:           mockQuoteStore,
+		limitStore:           mockLimitStore,
+	}
+
+	result, err := service.Exchange(ctx, quoteID, fromID, toID, 1234)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(1867), result.CreditedAmount)
+
+	require.Len(t, saved, 2)
+	require.Equal(t, models.ExchangeOut, saved[0].Type)
+	require.Equal(t, models.ExchangeIn, saved[1].Type)
+	require.Equal(t, "151.3", *saved[0].FXRate)
+	require.Equal(t, "151.3", *saved[1].FXRate)
+}
+
+func TestWalletService_Exchange_WithoutQuote(t *testing.T) {
+	service := &ServiceWallet{}
+
+	_, err := service.Exchange(context.Background(), uuid.Nil, uuid.New(), uuid.New(), 10)
+
+	require.ErrorIs(t, err, services.ErrInvalidQuoteID)
+}
+
+func TestWalletService_Exchange_QuoteRejected(t *testing.T) {
+	tests := []struct {
+		name  string
+		quote *models.FXQuote
+		err   error
+	}{
+		{
+			name:  "expired",
+			quote: &models.FXQuote{ExpiresAt: time.Now().Add(-time.Second)},
+			err:   services.ErrQuoteExpired,
+		},
+		{
+			name:  "used",
+			quote: &models.FXQuote{ExpiresAt: time.Now().Add(time.Minute), UsedAt: &time.Time{}},
+			err:   services.ErrQuoteUsed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTxManager := mocks.NewMockManager(ctrl)
+			mockQuoteStore := mocks.NewMockQuoteStore(ctrl)
+
+			runInTx(mockTxManager, "exchange")
+
+			mockQuoteStore.
+				EXPECT().
+				GetQuoteForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(tt.quote, nil)
+
+			service := &ServiceWallet{
+				txManager:  mockTxManager,
+				quoteStore: mockQuoteStore,
+			}
+
+			_, err := service.Exchange(context.Background(), uuid.New(), uuid.New(), uuid.New(), 100)
+
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestConvertAmount(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		quote  models.FXQuote
+		want   int64
+		err    error
+	}{
+		{name: "same exponent", amount: 1000, quote: models.FXQuote{FromCurrency: "EUR", ToCurrency: "USD", Rate: "1.0825"}, want: 1082},
+		{name: "to fewer digits", amount: 1234, quote: models.FXQuote{FromCurrency: "USD", ToCurrency: "JPY", Rate: "151.3"}, want: 1867},
+		{name: "to more digits", amount: 1000, quote: models.FXQuote{FromCurrency: "JPY", ToCurrency: "KWD", Rate: "0.00203"}, want: 2030},
+		{name: "too small", amount: 1, quote: models.FXQuote{FromCurrency: "JPY", ToCurrency: "USD", Rate: "0.0066"}, err: services.ErrExchangeAmountTooSmall},
+		{name: "too large", amount: math.MaxInt64, quote: models.FXQuote{FromCurrency: "USD", ToCurrency: "JPY", Rate: "151.3"}, err: services.ErrAmountTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := convertAmount(tt.amount, &tt.quote)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWalletService_Reverse_PartialDeposit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockReverser := mocks.NewMockOperationReverser(ctrl)
+	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+	mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+	mockOutbox := mocks.NewMockOutboxWriter(ctrl)
+	mockLedger := mocks.NewMockLedgerWriter(ctrl)
+
+	ctx := context.Background()
+	operationID := uuid.New()
+	walletID := uuid.New()
+
+	runInTx(mockTxManager, "reverse")
+
+	mockReverser.
+		EXPECT().
+		GetOperationForUpdate(ctx, gomock.Any(), operationID).
+		Return(&models.Operation{
+			ID:             operationID,
+			WalletID:       walletID,
+			Type:           models.Deposit,
+			Amount:         100,
+			ReversedAmount: 30,
+		}, nil)
+
+	mockBalanceUpdater.
+		EXPECT().
+		DecreaseBalance(ctx, gomock.Any(), walletID, int64(50)).
+		Return(&models.Wallet{ID: walletID, Balance: 20, Currency: "USD"}, nil)
+
+	mockReverser.
+		EXPECT().
+		AddReversedAmount(ctx, gomock.Any(), operationID, int64(50)).
+		Return(nil)
+
+	mockOperationSaver.
+		EXPECT().
+		CreateOperation(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, operation *models.Operation) error {
+			require.Equal(t, models.Reversal, operation.Type)
+			require.Equal(t, operationID, *operation.ReversedOperationID)
+			require.Equal(t, "customer refund", operation.Reason)
+			return nil
+		})
+
+	mockOutbox.
+		EXPECT().
+		EnqueueEvent(ctx, gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockLedger.
+		EXPECT().
+		PostEntry(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, entry *models.JournalEntry) error {
+			require.Equal(t, models.EntryReversal, entry.Type)
+			require.ElementsMatch(t, []models.Posting{
+				{AccountID: walletID, Amount: -50},
+				{AccountID: models.ExternalAccountID, Amount: 50},
+			}, entry.Postings)
+			return nil
+		})
+
+	service := &ServiceWallet{
+		txManager:            mockTxManager,
+		operationReverser:    mockReverser,
+		walletBalanceUpdater: mockBalanceUpdater,
+		operationSaver:       mockOperationSaver,
+		outbox:               mockOutbox,
+		ledger:               mockLedger,
+	}
+
+	result, err := service.Reverse(ctx, operationID, 50, "customer refund")
+
+	require.NoError(t, err)
+	require.Equal(t, int64(80), result.Original.ReversedAmount)
+	require.Equal(t, int64(20), result.Wallet.Balance)
+}
+
+func TestWalletService_Reverse_InvalidOperationID(t *testing.T) {
+	service := &ServiceWallet{}
+
+	_, err := service.Reverse(context.Background(), uuid.Nil, 0, "mistake")
+
+	require.ErrorIs(t, err, services.ErrInvalidOperationID)
+}
+
+func TestWalletService_Reverse_WithdrawalExceedsMaxBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockReverser := mocks.NewMockOperationReverser(ctrl)
+	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+	mockLimitStore := mocks.NewMockLimitStore(ctrl)
+
+	ctx := context.Background()
+	operationID := uuid.New()
+	walletID := uuid.New()
+	maxBalance := int64(100)
+
+	runInTx(mockTxManager, "reverse")
+
+	mockReverser.
+		EXPECT().
+		GetOperationForUpdate(ctx, gomock.Any(), operationID).
+		Return(&models.Operation{ID: operationID, WalletID: walletID, Type: models.Withdraw, Amount: 60}, nil)
+
+	mockBalanceUpdater.
+		EXPECT().
+		IncreaseBalance(ctx, gomock.Any(), walletID, int64(60)).
+		Return(&models.Wallet{ID: walletID, Balance: 150, Currency: "USD"}, nil)
+
+	mockLimitStore.
+		EXPECT().
+		GetWalletLimits(ctx, gomock.Any(), walletID).
+		Return(&models.LimitOverrides{MaxBalance: &maxBalance}, nil)
+
+	// The credit is rolled back before the withdrawal is marked reversed.
+	service := &ServiceWallet{
+		txManager:            mockTxManager,
+		operationReverser:    mockReverser,
+		walletBalanceUpdater: mockBalanceUpdater,
+		limitStore:           mockLimitStore,
+	}
+
+	_, err := service.Reverse(ctx, operationID, 0, "mistake")
+
+	var limitErr *services.LimitExceededError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, models.LimitMaxBalance, limitErr.Limit)
+	require.Equal(t, int64(150), limitErr.Attempted)
+}
+
+func TestWalletService_Reverse_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		original *models.Operation
+		amount   int64
+		debitErr error
+		err      error
+	}{
+		{
+			name:     "already reversed",
+			original: &models.Operation{Type: models.Deposit, Amount: 100, ReversedAmount: 100},
+			err:      services.ErrOperationAlreadyReversed,
+		},
+		{
+			name:     "exceeds remaining",
+			original: &models.Operation{Type: models.Withdraw, Amount: 100, ReversedAmount: 70},
+			amount:   40,
+			err:      services.ErrReversalExceedsAmount,
+		},
+		{
+			name:     "not reversible",
+			original: &models.Operation{Type: models.TransferOut, Amount: 100},
+			err:      services.ErrOperationNotReversible,
+		},
+		{
+			name:     "deposit already spent",
+			original: &models.Operation{Type: models.Deposit, Amount: 100},
+			debitErr: storage.ErrInsufficientFunds,
+			err:      storage.ErrInsufficientFunds,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTxManager := mocks.NewMockManager(ctrl)
+			mockReverser := mocks.NewMockOperationReverser(ctrl)
+			mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+
+			runInTx(mockTxManager, "reverse")
+
+			mockReverser.
+				EXPECT().
+				GetOperationForUpdate(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(tt.original, nil)
+
+			if tt.debitErr != nil {
+				mockBalanceUpdater.
+					EXPECT().
+					DecreaseBalance(gomock.Any(), gomock.Any(), gomock.Any(), tt.original.Amount).
+					Return(nil, tt.debitErr)
+			}
+
+			service := &ServiceWallet{
+				txManager:            mockTxManager,
+				operationReverser:    mockReverser,
+				walletBalanceUpdater: mockBalanceUpdater,
+			}
+
+			_, err := service.Reverse(context.Background(), uuid.New(), tt.amount, "mistake")
+
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestWalletService_Freeze_RecordsChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockLocker := mocks.NewMockLockerWallet(ctrl)
+	mockStatusUpdater := mocks.NewMockStatusUpdaterWallet(ctrl)
+
+	ctx := context.Background()
+	walletID := uuid.New()
+
+	runInTx(mockTxManager, "change_wallet_status")
+
+	mockLocker.
+		EXPECT().
+		LockWallets(ctx, gomock.Any(), walletID).
+		Return([]*models.Wallet{{ID: walletID, Balance: 100, Status: models.WalletActive}}, nil)
+
+	mockStatusUpdater.
+		EXPECT().
+		UpdateWalletStatus(ctx, gomock.Any(), walletID, models.WalletFrozen).
+		Return(&models.Wallet{ID: walletID, Balance: 100, Status: models.WalletFrozen}, nil)
+
+	mockStatusUpdater.
+		EXPECT().
+		RecordStatusChange(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, change *models.WalletStatusChange) error {
+			require.Equal(t, models.WalletActive, change.FromStatus)
+			require.Equal(t, models.WalletFrozen, change.ToStatus)
+			require.Equal(t, "support:alice", change.Actor)
+			require.Equal(t, "fraud check", change.Reason)
+			return nil
+		})
+
+	service := &ServiceWallet{
+		txManager:           mockTxManager,
+		walletLocker:        mockLocker,
+		walletStatusUpdater: mockStatusUpdater,
+	}
+
+	wallet, err := service.Freeze(ctx, walletID, "support:alice", "fraud check")
+
+	require.NoError(t, err)
+	require.Equal(t, models.WalletFrozen, wallet.Status)
+}
+
+func TestWalletService_ChangeStatus_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		current *models.Wallet
+		change  func(*ServiceWallet, uuid.UUID) error
+		err     error
+	}{
+		{
+			name:    "close with balance",
+			current: &models.Wallet{Balance: 10, Status: models.WalletActive},
+			change: func(s *ServiceWallet, id uuid.UUID) error {
+				_, err := s.Close(context.Background(), id, "a", "b")
+				return err
+			},
+			err: services.ErrWalletNotEmpty,
+		},
+		{
+			name:    "close with held funds",
+			current: &models.Wallet{HeldBalance: 10, Status: models.WalletFrozen},
+			change: func(s *ServiceWallet, id uuid.UUID) error {
+				_, err := s.Close(context.Background(), id, "a", "b")
+				return err
+			},
+			err: services.ErrWalletNotEmpty,
+		},
+		{
+			name:    "unfreeze active",
+			current: &models.Wallet{Status: models.WalletActive},
+			change: func(s *ServiceWallet, id uuid.UUID) error {
+				_, err := s.Unfreeze(context.Background(), id, "a", "b")
+				return err
+			},
+			err: services.ErrInvalidStatusTransition,
+		},
+		{
+			name:    "freeze closed",
+			current: &models.Wallet{Status: models.WalletClosed},
+			change: func(s *ServiceWallet, id uuid.UUID) error {
+				_, err := s.Freeze(context.Background(), id, "a", "b")
+				return err
+			},
+			err: services.ErrInvalidStatusTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTxManager := mocks.NewMockManager(ctrl)
+			mockLocker := mocks.NewMockLockerWallet(ctrl)
+
+			runInTx(mockTxManager, "change_wallet_status")
+
+			mockLocker.
+				EXPECT().
+				LockWallets(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return([]*models.Wallet{tt.current}, nil)
+
+			service := &ServiceWallet{
+				txManager:    mockTxManager,
+				walletLocker: mockLocker,
+			}
+
+			require.ErrorIs(t, tt.change(service, uuid.New()), tt.err)
+		})
+	}
+}
+
+func TestWalletService_ChangeStatus_RequiresActorAndReason(t *testing.T) {
+	service := &ServiceWallet{}
+
+	_, err := service.Freeze(context.Background(), uuid.New(), "", "reason")
+
+	require.ErrorIs(t, err, services.ErrInvalidStatusChange)
+}
+
+func TestWalletService_Apply_LimitExceeded(t *testing.T) {
+	int64Ptr := func(v int64) *int64 { return &v }
+
+	tests := []struct {
+		name      string
+		opType    models.OperationType
+		amount    int64
+		balance   int64
+		defaults  models.Limits
+		overrides *models.LimitOverrides
+		usage     *models.LimitUsage
+		limit     string
+		attempted int64
+	}{
+		{
+			name:      "single withdrawal",
+			opType:    models.Withdraw,
+			amount:    500,
+			defaults:  models.Limits{MaxSingleWithdrawal: 100},
+			limit:     models.LimitMaxSingleWithdrawal,
+			attempted: 500,
+		},
+		{
+			name:      "daily withdrawal",
+			opType:    models.Withdraw,
+			amount:    50,
+			defaults:  models.Limits{DailyWithdrawal: 1000, MonthlyWithdrawal: 5000},
+			usage:     &models.LimitUsage{WithdrawnToday: 960, WithdrawnThisMonth: 960},
+			limit:     models.LimitDailyWithdrawal,
+			attempted: 1010,
+		},
+		{
+			name:      "monthly withdrawal override",
+			opType:    models.Withdraw,
+			amount:    50,
+			defaults:  models.Limits{MonthlyWithdrawal: 5000},
+			overrides: &models.LimitOverrides{MonthlyWithdrawal: int64Ptr(1000)},
+			usage:     &models.LimitUsage{WithdrawnThisMonth: 990},
+			limit:     models.LimitMonthlyWithdrawal,
+			attempted: 1040,
+		},
+		{
+			name:      "max balance",
+			opType:    models.Deposit,
+			amount:    50,
+			balance:   1050,
+			defaults:  models.Limits{MaxBalance: 1000},
+			limit:     models.LimitMaxBalance,
+			attempted: 1050,
+		},
+		{
+			name:      "operation count",
+			opType:    models.Deposit,
+			amount:    50,
+			defaults:  models.Limits{MaxOperations: 3, OperationWindow: time.Minute},
+			usage:     &models.LimitUsage{OperationsInWindow: 3},
+			limit:     models.LimitMaxOperations,
+			attempted: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTxManager := mocks.NewMockManager(ctrl)
+			mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+			mockLimitStore := mocks.NewMockLimitStore(ctrl)
+
+			walletID := uuid.New()
+			wallet := &models.Wallet{ID: walletID, Balance: tt.balance, Currency: "USD"}
+
+			runInTx(mockTxManager, strings.ToLower(string(tt.opType)))
+
+			if tt.opType == models.Deposit {
+				mockBalanceUpdater.EXPECT().IncreaseBalance(gomock.Any(), gomock.Any(), walletID, tt.amount).Return(wallet, nil)
+			} else {
+				mockBalanceUpdater.EXPECT().DecreaseBalance(gomock.Any(), gomock.Any(), walletID, tt.amount).Return(wallet, nil)
+			}
+
+			mockLimitStore.
+				EXPECT().
+				GetWalletLimits(gomock.Any(), gomock.Any(), walletID).
+				Return(tt.overrides, nil)
+
+			if tt.usage != nil {
+				mockLimitStore.
+					EXPECT().
+					GetLimitUsage(gomock.Any(), gomock.Any(), walletID, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(tt.usage, nil)
+			}
+
+			service := &ServiceWallet{
+				txManager:            mockTxManager,
+				walletBalanceUpdater: mockBalanceUpdater,
+				limitStore:           mockLimitStore,
+				limits:               tt.defaults,
+			}
+
+			_, err := service.Apply(context.Background(), models.OperationRequest{
+				WalletID: walletID,
+				Type:     tt.opType,
+				Amount:   tt.amount,
+			})
+
+			require.ErrorIs(t, err, services.ErrLimitExceeded)
+
+			var limitErr *services.LimitExceededError
+			require.ErrorAs(t, err, &limitErr)
+			require.Equal(t, tt.limit, limitErr.Limit)
+			require.Equal(t, tt.attempted, limitErr.Attempted)
+		})
+	}
+}
+
+func TestWalletService_Transfer_LimitExceeded(t *testing.T) {
+	tests := []struct {
+		name      string
+		defaults  models.Limits
+		fromUsage *models.LimitUsage
+		limit     string
+		attempted int64
+	}{
+		{
+			name:      "sender daily withdrawal",
+			defaults:  models.Limits{DailyWithdrawal: 100},
+			fromUsage: &models.LimitUsage{WithdrawnToday: 80},
+			limit:     models.LimitDailyWithdrawal,
+			attempted: 120,
+		},
+		{
+			name:      "recipient max balance",
+			defaults:  models.Limits{MaxBalance: 100},
+			limit:     models.LimitMaxBalance,
+			attempted: 140,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTxManager := mocks.NewMockManager(ctrl)
+			mockLocker := mocks.NewMockLockerWallet(ctrl)
+			mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+			mockLimitStore := mocks.NewMockLimitStore(ctrl)
+
+			fromID := uuid.New()
+			toID := uuid.New()
+
+			runInTx(mockTxManager, "transfer")
+
+			mockLocker.
+				EXPECT().
+				LockWallets(gomock.Any(), gomock.Any(), fromID, toID).
+				Return([]*models.Wallet{{ID: fromID, Currency: "USD"}, {ID: toID, Currency: "USD"}}, nil)
+
+			mockBalanceUpdater.
+				EXPECT().
+				DecreaseBalance(gomock.Any(), gomock.Any(), fromID, int64(40)).
+				Return(&models.Wallet{ID: fromID, Balance: 60, Currency: "USD"}, nil)
+
+			mockBalanceUpdater.
+				EXPECT().
+				IncreaseBalance(gomock.Any(), gomock.Any(), toID, int64(40)).
+				Return(&models.Wallet{ID: toID, Balance: 140, Currency: "USD"}, nil)
+
+			mockLimitStore.
+				EXPECT().
+				GetWalletLimits(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, nil).
+				AnyTimes()
+
+			if tt.fromUsage != nil {
+				mockLimitStore.
+					EXPECT().
+					GetLimitUsage(gomock.Any(), gomock.Any(), fromID, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(tt.fromUsage, nil)
+			}
+
+			service := &ServiceWallet{
+				txManager:            mockTxManager,
+				walletLocker:         mockLocker,
+				walletBalanceUpdater: mockBalanceUpdater,
+				limitStore:           mockLimitStore,
+				limits:               tt.defaults,
+			}
+
+			_, err := service.Transfer(context.Background(), fromID, toID, 40, nil)
+
+			var limitErr *services.LimitExceededError
+			require.ErrorAs(t, err, &limitErr)
+			require.Equal(t, tt.limit, limitErr.Limit)
+			require.Equal(t, tt.attempted, limitErr.Attempted)
+		})
+	}
+}
+
+func TestWalletService_Capture_LimitExceeded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockHoldUpdater := mocks.NewMockHoldBalanceUpdater(ctrl)
+	mockHoldStore := mocks.NewMockHoldStore(ctrl)
+	mockLimitStore := mocks.NewMockLimitStore(ctrl)
+
+	ctx := context.Background()
+	hold := &models.Hold{
+		ID:        uuid.New(),
+		WalletID:  uuid.New(),
+		Amount:    50,
+		Status:    models.HoldActive,
+		ExpiresAt: time.Now().Add(time.Minute),
+	}
+
+	runInTx(mockTxManager, "capture")
+
+	mockHoldStore.
+		EXPECT().
+		GetHoldForUpdate(ctx, gomock.Any(), hold.ID).
+		Return(hold, nil)
+
+	mockHoldUpdater.
+		EXPECT().
+		CaptureHeldBalance(ctx, gomock.Any(), hold.WalletID, int64(50), int64(50)).
+		Return(&models.Wallet{ID: hold.WalletID, Balance: 50, Currency: "USD"}, nil)
+
+	mockLimitStore.
+		EXPECT().
+		GetWalletLimits(ctx, gomock.Any(), hold.WalletID).
+		Return(nil, nil)
+
+	mockLimitStore.
+		EXPECT().
+		GetLimitUsage(ctx, gomock.Any(), hold.WalletID, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(&models.LimitUsage{WithdrawnToday: 60}, nil)
+
+	service := &ServiceWallet{
+		txManager:          mockTxManager,
+		holdBalanceUpdater: mockHoldUpdater,
+		holdStore:          mockHoldStore,
+		limitStore:         mockLimitStore,
+		limits:             models.Limits{DailyWithdrawal: 100},
+	}
+
+	_, err := service.Capture(ctx, hold.ID, 0)
+
+	var limitErr *services.LimitExceededError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, models.LimitDailyWithdrawal, limitErr.Limit)
+	require.Equal(t, int64(110), limitErr.Attempted)
+}
+
+func TestWalletService_SetLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockLocker := mocks.NewMockLockerWallet(ctrl)
+	mockLimitStore := mocks.NewMockLimitStore(ctrl)
+
+	walletID := uuid.New()
+	daily := int64(700)
+	overrides := &models.LimitOverrides{DailyWithdrawal: &daily}
+
+	runInTx(mockTxManager, "set_wallet_limits")
+
+	mockLocker.
+		EXPECT().
+		LockWallets(gomock.Any(), gomock.Any(), walletID).
+		Return([]*models.Wallet{{ID: walletID}}, nil)
+
+	mockLimitStore.
+		EXPECT().
+		SetWalletLimits(gomock.Any(), gomock.Any(), walletID, overrides).
+		Return(nil)
+
+	service := &ServiceWallet{
+		txManager:    mockTxManager,
+		walletLocker: mockLocker,
+		limitStore:   mockLimitStore,
+		limits:       models.Limits{DailyWithdrawal: 100, MaxBalance: 5000},
+	}
+
+	limits, err := service.SetLimits(context.Background(), walletID, overrides)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(700), limits.DailyWithdrawal)
+	require.Equal(t, int64(5000), limits.MaxBalance)
+}
+
+func TestWalletService_SetLimits_Invalid(t *testing.T) {
+	service := &ServiceWallet{}
+	negative := int64(-1)
+
+	_, err := service.SetLimits(context.Background(), uuid.New(), &models.LimitOverrides{MaxBalance: &negative})
+
+	require.ErrorIs(t, err, services.ErrInvalidLimits)
+}
+
+func TestWalletService_StreamCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOperationGetter := mocks.NewMockOperationGetter(ctrl)
+
+	ctx := context.Background()
+	walletID := uuid.New()
+
+	mockOperationGetter.
+		EXPECT().
+		SequenceOperations(ctx, walletID).
+		Return(int64(42), nil).
+		Times(2)
+
+	service := &ServiceWallet{operationGetter: mockOperationGetter}
+
+	cursor, err := service.StreamCursor(ctx, walletID, "")
+	require.NoError(t, err)
+	require.Equal(t, "42", cursor)
+
+	resumed, err := service.StreamCursor(ctx, walletID, "17")
+	require.NoError(t, err)
+	require.Equal(t, "17", resumed)
+
+	_, err = service.StreamCursor(ctx, walletID, "%%%")
+	require.ErrorIs(t, err, services.ErrInvalidCursor)
+
+	_, err = service.StreamCursor(ctx, walletID, "-1")
+	require.ErrorIs(t, err, services.ErrInvalidCursor)
+
+	mockOperationGetter.
+		EXPECT().
+		SequenceOperations(ctx, walletID).
+		Return(int64(0), storage.ErrWalletNotFound)
+
+	_, err = service.StreamCursor(ctx, walletID, "")
+	require.ErrorIs(t, err, storage.ErrWalletNotFound)
+}
+
+func TestWalletService_OperationsSince(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOperationGetter := mocks.NewMockOperationGetter(ctrl)
+
+	ctx := context.Background()
+	walletID := uuid.New()
+	first, second := int64(8), int64(9)
+	operations := []*models.Operation{
+		{ID: uuid.New(), WalletID: walletID, Sequence: &first},
+		{ID: uuid.New(), WalletID: walletID, Sequence: &second},
+	}
+
+	gomock.InOrder(
+		mockOperationGetter.
+			EXPECT().
+			SequenceOperations(ctx, walletID).
+			Return(int64(9), nil),
+		mockOperationGetter.
+			EXPECT().
+			GetOperationsSince(ctx, walletID, int64(7), streamBatchSize).
+			Return(operations, nil),
+	)
+
+	service := &ServiceWallet{operationGetter: mockOperationGetter}
+
+	events, err := service.OperationsSince(ctx, walletID, "7")
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	for i, event := range events {
+		require.Same(t, operations[i], event.Operation)
+		require.Equal(t, encodeStreamCursor(*operations[i].Sequence), event.Cursor)
+	}
+
+	_, err = service.OperationsSince(ctx, walletID, "x")
+	require.ErrorIs(t, err, services.ErrInvalidCursor)
+}
+
+// recordingTx is a transaction that records the statements sent to it in
+// batches; the repositories touching it are mocked.
+type recordingTx struct {
+	pgxdriver.QueryExecuter
+	sent []string
+}
+
+func (r *recordingTx) SendBatch(_ context.Context, b *pgx.Batch) pgx.BatchResults {
+	for _, query := range b.QueuedQueries {
+		r.sent = append(r.sent, query.SQL)
+	}
+	return closedBatchResults{}
+}
+
+type closedBatchResults struct {
+	pgx.BatchResults
+}
+
+func (closedBatchResults) Close() error {
+	return nil
+}
+
+func TestWalletService_ApplyBatch_BestEffort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockLocker := mocks.NewMockLockerWallet(ctrl)
+	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+	mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+	mockOutbox := mocks.NewMockOutboxWriter(ctrl)
+	mockLedger := mocks.NewMockLedgerWriter(ctrl)
+	mockLimitStore := mocks.NewMockLimitStore(ctrl)
+
+	ctx := context.Background()
+	funded, empty, missing := uuid.New(), uuid.New(), uuid.New()
+	tx := &recordingTx{}
+
+	mockTxManager.
+		EXPECT().
+		ExecuteInTransaction(ctx, "batch_operations", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, fn func(tx pgxdriver.QueryExecuter) error) error {
+			return fn(tx)
+		})
+
+	mockLocker.
+		EXPECT().
+		LockExistingWallets(ctx, tx, funded, empty, missing).
+		Return([]*models.Wallet{{ID: funded}, {ID: empty}}, nil)
+
+	mockBalanceUpdater.
+		EXPECT().
+		IncreaseBalance(ctx, gomock.Any(), funded, int64(100)).
+		Return(&models.Wallet{ID: funded, Balance: 100, Currency: "USD"}, nil)
+
+	mockLimitStore.
+		EXPECT().
+		GetWalletLimits(ctx, gomock.Any(), funded).
+		Return(nil, nil)
+
+	mockOperationSaver.
+		EXPECT().
+		CreateOperation(ctx, gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockOutbox.
+		EXPECT().
+		EnqueueEvent(ctx, gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockLedger.
+		EXPECT().
+		PostEntry(ctx, gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockBalanceUpdater.
+		EXPECT().
+		DecreaseBalance(ctx, gomock.Any(), empty, int64(500)).
+		Return(nil, storage.ErrInsufficientFunds)
+
+	service := &ServiceWallet{
+		txManager:            mockTxManager,
+		walletLocker:         mockLocker,
+		walletBalanceUpdater: mockBalanceUpdater,
+		operationSaver:       mockOperationSaver,
+		outbox:               mockOutbox,
+		ledger:               mockLedger,
+		limitStore:           mockLimitStore,
+	}
+
+	results, err := service.ApplyBatch(ctx, models.BatchBestEffort, []models.OperationRequest{
+		{WalletID: funded, Type: models.Deposit, Amount: 100},
+		{WalletID: empty, Type: models.Withdraw, Amount: 500},
+		{WalletID: missing, Type: models.Deposit, Amount: 10},
+		{WalletID: funded, Type: models.Deposit, Amount: -1},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	require.NoError(t, results[0].Err)
+	require.Equal(t, int64(100), results[0].Wallet.Balance)
+	require.ErrorIs(t, results[1].Err, storage.ErrInsufficientFunds)
+	require.ErrorIs(t, results[2].Err, storage.ErrWalletNotFound)
+	require.ErrorIs(t, results[3].Err, services.ErrAmountNegativeValue)
+
+	require.Equal(t, []string{
+		"SAVEPOINT batch_item",
+		"RELEASE SAVEPOINT batch_item",
+		"SAVEPOINT batch_item",
+		"ROLLBACK TO SAVEPOINT batch_item",
+		"RELEASE SAVEPOINT batch_item",
+	}, tx.sent)
+}
+
+func TestWalletService_ApplyBatch_AtomicRejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockLocker := mocks.NewMockLockerWallet(ctrl)
+	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+	mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+	mockOutbox := mocks.NewMockOutboxWriter(ctrl)
+	mockLedger := mocks.NewMockLedgerWriter(ctrl)
+	mockLimitStore := mocks.NewMockLimitStore(ctrl)
+
+	ctx := context.Background()
+	first, second := uuid.New(), uuid.New()
+
+	runInTx(mockTxManager, "batch_operations")
+
+	mockLocker.
+		EXPECT().
+		LockExistingWallets(ctx, gomock.Any(), first, second).
+		Return([]*models.Wallet{{ID: first}, {ID: second}}, nil)
+
+	mockBalanceUpdater.
+		EXPECT().
+		IncreaseBalance(ctx, gomock.Any(), first, int64(100)).
+		Return(&models.Wallet{ID: first, Balance: 100, Currency: "USD"}, nil)
+
+	mockLimitStore.
+		EXPECT().
+		GetWalletLimits(ctx, gomock.Any(), first).
+		Return(nil, nil)
+
+	mockOperationSaver.
+		EXPECT().
+		CreateOperation(ctx, gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockOutbox.
+		EXPECT().
+		EnqueueEvent(ctx, gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockLedger.
+		EXPECT().
+		PostEntry(ctx, gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockBalanceUpdater.
+		EXPECT().
+		DecreaseBalance(ctx, gomock.Any(), second, int64(50)).
+		Return(nil, storage.ErrWalletFrozen)
+
+	service := &ServiceWallet{
+		txManager:            mockTxManager,
+		walletLocker:         mockLocker,
+		walletBalanceUpdater: mockBalanceUpdater,
+		operationSaver:       mockOperationSaver,
+		outbox:               mockOutbox,
+		ledger:               mockLedger,
+		limitStore:           mockLimitStore,
+	}
+
+	_, err := service.ApplyBatch(ctx, models.BatchAtomic, []models.OperationRequest{
+		{WalletID: first, Type: models.Deposit, Amount: 100},
+		{WalletID: second, Type: models.Withdraw, Amount: 50},
+	})
+
+	var itemErr *services.BatchItemError
+	require.ErrorAs(t, err, &itemErr)
+	require.Equal(t, 1, itemErr.Index)
+	require.ErrorIs(t, err, storage.ErrWalletFrozen)
+}
+
+func TestWalletService_ApplyBatch_Invalid(t *testing.T) {
+	service := &ServiceWallet{}
+	ctx := context.Background()
+
+	_, err := service.ApplyBatch(ctx, "parallel", []models.OperationRequest{{}})
+	require.ErrorIs(t, err, services.ErrInvalidBatchMode)
+
+	_, err = service.ApplyBatch(ctx, models.BatchAtomic, nil)
+	require.ErrorIs(t, err, services.ErrEmptyBatch)
+
+	_, err = service.ApplyBatch(ctx, models.BatchAtomic, make([]models.OperationRequest, maxBatchItems+1))
+	require.ErrorIs(t, err, services.ErrBatchTooLarge)
+
+	_, err = service.ApplyBatch(ctx, models.BatchAtomic, []models.OperationRequest{
+		{WalletID: uuid.New(), Type: models.Deposit, Amount: 10},
+		{WalletID: uuid.New(), Type: models.Deposit, Amount: 10, Currency: "XXX"},
+	})
+
+	var itemErr *services.BatchItemError
+	require.ErrorAs(t, err, &itemErr)
+	require.Equal(t, 1, itemErr.Index)
+	require.ErrorIs(t, err, services.ErrUnsupportedCurrency)
+}
+
+func TestWalletService_Apply_ShardedDeposit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+	mockBuckets := mocks.NewMockBucketBalanceUpdater(ctrl)
+	mockLimitStore := mocks.NewMockLimitStore(ctrl)
+	mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+	mockOutbox := mocks.NewMockOutboxWriter(ctrl)
+	mockLedger := mocks.NewMockLedgerWriter(ctrl)
+
+	walletID := uuid.New()
+
+	runInTx(mockTxManager, "deposit")
+
+	gomock.InOrder(
+		mockBalanceUpdater.
+			EXPECT().
+			IncreaseBalance(gomock.Any(), gomock.Any(), walletID, int64(100)).
+			Return(nil, storage.ErrWalletSharded),
+		mockBuckets.
+			EXPECT().
+			IncreaseBucketBalance(gomock.Any(), gomock.Any(), walletID, int64(100)).
+			Return(&models.Wallet{ID: walletID, Balance: 700, Currency: "USD", BalanceBuckets: 8}, nil),
+	)
+
+	mockLimitStore.
+		EXPECT().
+		GetWalletLimits(gomock.Any(), gomock.Any(), walletID).
+		Return(nil, nil).
+		Times(2)
+
+	mockOperationSaver.EXPECT().CreateOperation(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockOutbox.EXPECT().EnqueueEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	mockLedger.EXPECT().PostEntry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	service := &ServiceWallet{
+		txManager:            mockTxManager,
+		walletBalanceUpdater: mockBalanceUpdater,
+		bucketBalanceUpdater: mockBuckets,
+		limitStore:           mockLimitStore,
+		operationSaver:       mockOperationSaver,
+		outbox:               mockOutbox,
+		ledger:               mockLedger,
+	}
+
+	wallet, err := service.Deposit(context.Background(), walletID, 100)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(700), wallet.Balance)
+}
+
+func TestWalletService_Apply_ShardedWithdraw(t *testing.T) {
+	tests := []struct {
+		name         string
+		limits       models.Limits
+		drawsBucket  bool
+		bucketErr    error
+		consolidates bool
+		rowErr       error
+		wantErr      error
+	}{
+		{
+			name:        "single bucket covers it",
+			drawsBucket: true,
+		},
+		{
+			name:         "falls back to the consolidated balance",
+			drawsBucket:  true,
+			bucketErr:    storage.ErrInsufficientFunds,
+			consolidates: true,
+		},
+		{
+			name:         "withdrawal limits serialize on the row",
+			limits:       models.Limits{DailyWithdrawal: 1000},
+			consolidates: true,
+		},
+		{
+			name:         "consolidated balance falls short",
+			drawsBucket:  true,
+			bucketErr:    storage.ErrInsufficientFunds,
+			consolidates: true,
+			rowErr:       storage.ErrWalletSharded,
+			wantErr:      storage.ErrInsufficientFunds,
+		},
+		{
+			name:        "frozen",
+			drawsBucket: true,
+			bucketErr:   storage.ErrWalletFrozen,
+			wantErr:     storage.ErrWalletFrozen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockTxManager := mocks.NewMockManager(ctrl)
+			mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+			mockBuckets := mocks.NewMockBucketBalanceUpdater(ctrl)
+			mockLimitStore := mocks.NewMockLimitStore(ctrl)
+			mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+			mockOutbox := mocks.NewMockOutboxWriter(ctrl)
+			mockLedger := mocks.NewMockLedgerWriter(ctrl)
+
+			walletID := uuid.New()
+			wallet := &models.Wallet{ID: walletID, Balance: 400, Currency: "USD", BalanceBuckets: 8}
+
+			runInTx(mockTxManager, "withdraw")
+
+			calls := []any{
+				mockBalanceUpdater.
+					EXPECT().
+					DecreaseBalance(gomock.Any(), gomock.Any(), walletID, int64(100)).
+					Return(nil, storage.ErrWalletSharded),
+			}
+			if tt.drawsBucket {
+				bucketWallet := wallet
+				if tt.bucketErr != nil {
+					bucketWallet = nil
+				}
+				calls = append(calls, mockBuckets.
+					EXPECT().
+					DecreaseBucketBalance(gomock.Any(), gomock.Any(), walletID, int64(100)).
+					Return(bucketWallet, tt.bucketErr))
+			}
+			if tt.consolidates {
+				rowWallet := wallet
+				if tt.rowErr != nil {
+					rowWallet = nil
+				}
+				calls = append(calls,
+					mockBuckets.
+						EXPECT().
+						ConsolidateBalance(gomock.Any(), gomock.Any(), walletID).
+						Return(&models.Wallet{ID: walletID, Balance: 500, BalanceBuckets: 8}, nil),
+					mockBalanceUpdater.
+						EXPECT().
+						DecreaseBalance(gomock.Any(), gomock.Any(), walletID, int64(100)).
+						Return(rowWallet, tt.rowErr))
+			}
+			gomock.InOrder(calls...)
+
+			mockLimitStore.EXPECT().GetWalletLimits(gomock.Any(), gomock.Any(), walletID).Return(nil, nil).MinTimes(1)
+			mockLimitStore.
+				EXPECT().
+				GetLimitUsage(gomock.Any(), gomock.Any(), walletID, gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(&models.LimitUsage{}, nil).
+				AnyTimes()
+			mockOperationSaver.EXPECT().CreateOperation(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockOutbox.EXPECT().EnqueueEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			mockLedger.EXPECT().PostEntry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			service := &ServiceWallet{
+				txManager:            mockTxManager,
+				walletBalanceUpdater: mockBalanceUpdater,
+				bucketBalanceUpdater: mockBuckets,
+				limitStore:           mockLimitStore,
+				limits:               tt.limits,
+				operationSaver:       mockOperationSaver,
+				outbox:               mockOutbox,
+				ledger:               mockLedger,
+			}
+
+			result, err := service.Withdraw(context.Background(), walletID, 100)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, int64(400), result.Balance)
+		})
+	}
+}
+
+func TestWalletService_SetBalanceBuckets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockLocker := mocks.NewMockLockerWallet(ctrl)
+	mockBuckets := mocks.NewMockBucketBalanceUpdater(ctrl)
+
+	walletID := uuid.New()
+
+	runInTx(mockTxManager, "set_balance_buckets")
+
+	gomock.InOrder(
+		mockLocker.
+			EXPECT().
+			LockWallets(gomock.Any(), gomock.Any(), walletID).
+			Return([]*models.Wallet{{ID: walletID}}, nil),
+		mockBuckets.
+			EXPECT().
+			SetBalanceBuckets(gomock.Any(), gomock.Any(), walletID, 16).
+			Return(&models.Wallet{ID: walletID, BalanceBuckets: 16}, nil),
+	)
+
+	service := &ServiceWallet{
+		txManager:            mockTxManager,
+		walletLocker:         mockLocker,
+		bucketBalanceUpdater: mockBuckets,
+	}
+
+	wallet, err := service.SetBalanceBuckets(context.Background(), walletID, 16)
+
+	require.NoError(t, err)
+	require.Equal(t, 16, wallet.BalanceBuckets)
+
+	for _, buckets := range []int{-1, maxBalanceBuckets + 1} {
+		_, err := service.SetBalanceBuckets(context.Background(), walletID, buckets)
+		require.ErrorIs(t, err, services.ErrInvalidBalanceBuckets)
+	}
+}
+
+func TestDepositCoalescer_CombinesDeposits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+	mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+	mockOutbox := mocks.NewMockOutboxWriter(ctrl)
+	mockLedger := mocks.NewMockLedgerWriter(ctrl)
+	mockLimitStore := mocks.NewMockLimitStore(ctrl)
+
+	ctx := context.Background()
+	walletID := uuid.New()
+	amounts := []int64{10, 20, 30}
+
+	runInTx(mockTxManager, "coalesced_deposit")
+
+	mockBalanceUpdater.
+		EXPECT().
+		IncreaseBalance(gomock.Any(), gomock.Any(), walletID, int64(60)).
+		Return(&models.Wallet{ID: walletID, Balance: 160, HeldBalance: 40, AvailableBalance: 120, Currency: "USD"}, nil)
+
+	mockLimitStore.
+		EXPECT().
+		GetWalletLimits(gomock.Any(), gomock.Any(), walletID).
+		Return(nil, nil)
+
+	// Balances each deposit leaves, by amount, in the order of the batch.
+	expected := make(map[int64]int64, len(amounts))
+	mockOperationSaver.
+		EXPECT().
+		CreateOperations(gomock.Any(), gomock.Any(), gomock.Len(len(amounts))).
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, operations []*models.Operation) error {
+			balance := int64(100)
+			for _, operation := range operations {
+				require.Equal(t, walletID, operation.WalletID)
+				require.Equal(t, models.Deposit, operation.Type)
+				balance += operation.Amount
+				expected[operation.Amount] = balance
+			}
+			return nil
+		})
+
+	mockLedger.
+		EXPECT().
+		PostEntry(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(len(amounts)).
+		Return(nil)
+
+	mockOutbox.
+		EXPECT().
+		EnqueueEvent(gomock.Any(), gomock.Any(), gomock.Any()).
+		Times(len(amounts)).
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, event *models.OutboxEvent) error {
+			var payload models.BalanceChanged
+			require.NoError(t, json.Unmarshal(event.Payload, &payload))
+			require.Equal(t, expected[payload.Amount], payload.Balance)
+			return nil
+		})
+
+	service := &ServiceWallet{
+		txManager:            mockTxManager,
+		walletBalanceUpdater: mockBalanceUpdater,
+		operationSaver:       mockOperationSaver,
+		outbox:               mockOutbox,
+		ledger:               mockLedger,
+		limitStore:           mockLimitStore,
+	}
+
+	coalescer := NewDepositCoalescer(service, slog.New(slog.DiscardHandler), time.Hour, len(amounts))
+	defer coalescer.Close()
+
+	var (
+		wg      sync.WaitGroup
+		wallets = make([]*models.Wallet, len(amounts))
+		errs    = make([]error, len(amounts))
+	)
+	for i, amount := range amounts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wallets[i], errs[i] = coalescer.Deposit(ctx, walletID, amount)
+		}()
+	}
+	wg.Wait()
+
+	for i, amount := range amounts {
+		require.NoError(t, errs[i])
+		require.Equal(t, expected[amount], wallets[i].Balance)
+		require.Equal(t, expected[amount]-40, wallets[i].AvailableBalance)
+	}
+}
+
+func TestDepositCoalescer_FallsBackPerDeposit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+	mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+	mockOutbox := mocks.NewMockOutboxWriter(ctrl)
+	mockLedger := mocks.NewMockLedgerWriter(ctrl)
+	mockLimitStore := mocks.NewMockLimitStore(ctrl)
+
+	ctx := context.Background()
+	walletID := uuid.New()
+
+	// The deposit in the wrong currency fails the coalesced transaction,
+	// then each deposit is applied on its own.
+	runInTx(mockTxManager, "coalesced_deposit")
+	runInTx(mockTxManager, "deposit")
+	runInTx(mockTxManager, "deposit")
+
+	mockBalanceUpdater.
+		EXPECT().
+		IncreaseBalance(gomock.Any(), gomock.Any(), walletID, int64(30)).
+		Return(&models.Wallet{ID: walletID, Balance: 130, Currency: "USD"}, nil)
+
+	mockBalanceUpdater.
+		EXPECT().
+		IncreaseBalance(gomock.Any(), gomock.Any(), walletID, int64(10)).
+		Return(&models.Wallet{ID: walletID, Balance: 110, Currency: "USD"}, nil)
+
+	mockBalanceUpdater.
+		EXPECT().
+		IncreaseBalance(gomock.Any(), gomock.Any(), walletID, int64(20)).
+		Return(&models.Wallet{ID: walletID, Balance: 120, Currency: "USD"}, nil)
+
+	mockLimitStore.
+		EXPECT().
+		GetWalletLimits(gomock.Any(), gomock.Any(), walletID).
+		Return(nil, nil)
+
+	mockOperationSaver.
+		EXPECT().
+		CreateOperation(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockOutbox.
+		EXPECT().
+		EnqueueEvent(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockLedger.
+		EXPECT().
+		PostEntry(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	service := &ServiceWallet{
+		txManager:            mockTxManager,
+		walletBalanceUpdater: mockBalanceUpdater,
+		operationSaver:       mockOperationSaver,
+		outbox:               mockOutbox,
+		ledger:               mockLedger,
+		limitStore:           mockLimitStore,
+	}
+
+	coalescer := NewDepositCoalescer(service, slog.New(slog.DiscardHandler), time.Hour, 2)
+	defer coalescer.Close()
+
+	var (
+		wg          sync.WaitGroup
+		wallet      *models.Wallet
+		depositErr  error
+		mismatchErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		wallet, depositErr = coalescer.Deposit(ctx, walletID, 10)
+	}()
+	go func() {
+		defer wg.Done()
+		_, mismatchErr = coalescer.Apply(ctx, models.OperationRequest{
+			WalletID: walletID,
+			Type:     models.Deposit,
+			Amount:   20,
+			Currency: "EUR",
+		})
+	}()
+	wg.Wait()
+
+	require.NoError(t, depositErr)
+	require.Equal(t, int64(110), wallet.Balance)
+	require.ErrorIs(t, mismatchErr, services.ErrCurrencyMismatch)
+}
+
+func TestDepositCoalescer_FlushesOnClose(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+	mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+	mockOutbox := mocks.NewMockOutboxWriter(ctrl)
+	mockLedger := mocks.NewMockLedgerWriter(ctrl)
+	mockLimitStore := mocks.NewMockLimitStore(ctrl)
+
+	ctx := context.Background()
+	walletID := uuid.New()
+
+	runInTx(mockTxManager, "deposit")
+
+	mockBalanceUpdater.
+		EXPECT().
+		IncreaseBalance(gomock.Any(), gomock.Any(), walletID, int64(10)).
+		Return(&models.Wallet{ID: walletID, Balance: 10, Currency: "USD"}, nil)
+
+	mockLimitStore.
+		EXPECT().
+		GetWalletLimits(gomock.Any(), gomock.Any(), walletID).
+		Return(nil, nil)
+
+	mockOperationSaver.
+		EXPECT().
+		CreateOperation(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockOutbox.
+		EXPECT().
+		EnqueueEvent(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	mockLedger.
+		EXPECT().
+		PostEntry(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	service := &ServiceWallet{
+		txManager:            mockTxManager,
+		walletBalanceUpdater: mockBalanceUpdater,
+		operationSaver:       mockOperationSaver,
+		outbox:               mockOutbox,
+		ledger:               mockLedger,
+		limitStore:           mockLimitStore,
+	}
+
+	coalescer := NewDepositCoalescer(service, slog.New(slog.DiscardHandler), time.Hour, 100)
+
+	var (
+		wallet *models.Wallet
+		err    error
+	)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wallet, err = coalescer.Deposit(ctx, walletID, 10)
+	}()
+
+	require.Eventually(t, func() bool {
+		coalescer.mu.Lock()
+		defer coalescer.mu.Unlock()
+		return len(coalescer.pending) == 1
+	}, time.Second, time.Millisecond)
+
+	coalescer.Close()
+	<-done
+
+	require.NoError(t, err)
+	require.Equal(t, int64(10), wallet.Balance)
+}
+
+func TestDepositCoalescer_CancelledBeforeFlush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Nothing is expected of the store: the deposit leaves its batch before
+	// the batch is flushed.
+	service := &ServiceWallet{txManager: mocks.NewMockManager(ctrl)}
+
+	coalescer := NewDepositCoalescer(service, slog.New(slog.DiscardHandler), time.Hour, 100)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var err error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, err = coalescer.Deposit(ctx, uuid.New(), 10)
+	}()
+
+	require.Eventually(t, func() bool {
+		coalescer.mu.Lock()
+		defer coalescer.mu.Unlock()
+		return len(coalescer.pending) == 1
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	<-done
+	coalescer.Close()
+
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestDepositCoalescer_CancelledDuringFlush(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+	mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+	mockOutbox := mocks.NewMockOutboxWriter(ctrl)
+	mockLedger := mocks.NewMockLedgerWriter(ctrl)
+	mockLimitStore := mocks.NewMockLimitStore(ctrl)
+
+	walletID := uuid.New()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	runInTx(mockTxManager, "coalesced_deposit")
+
+	// The caller gives up while its batch is being applied.
+	mockBalanceUpdater.
+		EXPECT().
+		IncreaseBalance(gomock.Any(), gomock.Any(), walletID, int64(30)).
+		DoAndReturn(func(context.Context, pgxdriver.QueryExecuter, uuid.UUID, int64) (*models.Wallet, error) {
+			cancel()
+			return &models.Wallet{ID: walletID, Balance: 30, Currency: "USD"}, nil
+		})
+
+	mockLimitStore.EXPECT().GetWalletLimits(gomock.Any(), gomock.Any(), walletID).Return(nil, nil)
+	mockOperationSaver.EXPECT().CreateOperations(gomock.Any(), gomock.Any(), gomock.Len(2)).Return(nil)
+	mockLedger.EXPECT().PostEntry(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+	mockOutbox.EXPECT().EnqueueEvent(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).Return(nil)
+
+	service := &ServiceWallet{
+		txManager:            mockTxManager,
+		walletBalanceUpdater: mockBalanceUpdater,
+		operationSaver:       mockOperationSaver,
+		outbox:               mockOutbox,
+		ledger:               mockLedger,
+		limitStore:           mockLimitStore,
+	}
+
+	coalescer := NewDepositCoalescer(service, slog.New(slog.DiscardHandler), time.Hour, 2)
+	defer coalescer.Close()
+
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, 2)
+	)
+	for i, amount := range []int64{10, 20} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = coalescer.Deposit(ctx, walletID, amount)
+		}()
+	}
+	wg.Wait()
+
+	// Both deposits were committed, so neither caller sees an error.
+	require.NoError(t, errs[0])
+	require.NoError(t, errs[1])
+}
+
+// newBenchmarkService returns a wallet service over mocked storage that
+// accepts any deposit to a single wallet and counts the transactions it runs.
+func newBenchmarkService(b *testing.B, transactions *atomic.Int64) *ServiceWallet {
+	ctrl := gomock.NewController(b)
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockBalanceUpdater := mocks.NewMockBalanceUpdaterWallet(ctrl)
+	mockOperationSaver := mocks.NewMockOperationSaver(ctrl)
+	mockOutbox := mocks.NewMockOutboxWriter(ctrl)
+	mockLedger := mocks.NewMockLedgerWriter(ctrl)
+	mockLimitStore := mocks.NewMockLimitStore(ctrl)
+
+	mockTxManager.
+		EXPECT().
+		ExecuteInTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			ctx context.Context,
+			name string,
+			fn func(tx pgxdriver.QueryExecuter) error,
+		) error {
+			transactions.Add(1)
+			return fn(nil)
+		}).
+		AnyTimes()
+
+	mockBalanceUpdater.
+		EXPECT().
+		IncreaseBalance(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, walletID uuid.UUID, amount int64) (*models.Wallet, error) {
+			return &models.Wallet{ID: walletID, Balance: amount, AvailableBalance: amount, Currency: "USD"}, nil
+		}).
+		AnyTimes()
+
+	mockLimitStore.EXPECT().GetWalletLimits(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockOperationSaver.EXPECT().CreateOperation(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockOperationSaver.EXPECT().CreateOperations(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockLedger.EXPECT().PostEntry(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	mockOutbox.EXPECT().EnqueueEvent(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	return &ServiceWallet{
+		txManager:            mockTxManager,
+		walletBalanceUpdater: mockBalanceUpdater,
+		operationSaver:       mockOperationSaver,
+		outbox:               mockOutbox,
+		ledger:               mockLedger,
+		limitStore:           mockLimitStore,
+	}
+}
+
+// benchmarkDeposits runs concurrent deposits to one wallet through deposit
+// and reports their rate and the transactions they took. The storage is
+// mocked, so the rate measures the service alone; the transactions per
+// deposit are what coalescing saves on a real database.
+func benchmarkDeposits(
+	b *testing.B,
+	transactions *atomic.Int64,
+	deposit func(ctx context.Context, walletID uuid.UUID, amount int64) (*models.Wallet, error),
+) {
+
+	ctx := context.Background()
+	walletID := uuid.New()
+
+	b.SetParallelism(16)
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := deposit(ctx, walletID, 1); err != nil {
+				b.Error(err)
+			}
+		}
+	})
+
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "deposits/s")
+	b.ReportMetric(float64(transactions.Load())/float64(b.N), "tx/deposit")
+}
+
+func BenchmarkDeposit_Direct(b *testing.B) {
+	var transactions atomic.Int64
+	service := newBenchmarkService(b, &transactions)
+
+	benchmarkDeposits(b, &transactions, service.Deposit)
+}
+
+func BenchmarkDeposit_Coalesced(b *testing.B) {
+	var transactions atomic.Int64
+	coalescer := NewDepositCoalescer(newBenchmarkService(b, &transactions), slog.New(slog.DiscardHandler), 2*time.Millisecond, 100)
+	defer coalescer.Close()
+
+	benchmarkDeposits(b, &transactions, func(ctx context.Context, walletID uuid.UUID, amount int64) (*models.Wallet, error) {
+		return coalescer.Apply(ctx, models.OperationRequest{WalletID: walletID, Type: models.Deposit, Amount: amount})
+	})
+}
+
+func TestWalletService_BalanceAt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGetter := mocks.NewMockGetterWallet(ctrl)
+	mockHistory := mocks.NewMockBalanceHistory(ctrl)
+
+	ctx := context.Background()
+	walletID := uuid.New()
+	createdAt := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	at := time.Date(2025, 3, 31, 23, 59, 59, 0, time.UTC)
+
+	mockGetter.
+		EXPECT().
+		GetWallet(ctx, walletID).
+		Return(&models.Wallet{ID: walletID, Balance: 900, Currency: "USD", CreatedAt: createdAt}, nil).
+		Times(2)
+
+	mockHistory.
+		EXPECT().
+		GetBalancesAt(ctx, walletID, []time.Time{at}).
+		Return([]models.BalancePoint{{At: at, Balance: 4200}}, nil)
+
+	service := &ServiceWallet{
+		walletGetter:   mockGetter,
+		balanceHistory: mockHistory,
+	}
+
+	balance, err := service.BalanceAt(ctx, walletID, at)
+	require.NoError(t, err)
+	require.Equal(t, int64(4200), balance.Balance)
+	require.Equal(t, "USD", balance.Currency)
+	require.Equal(t, at, balance.At)
+
+	_, err = service.BalanceAt(ctx, walletID, createdAt.Add(-time.Hour))
+	require.ErrorIs(t, err, services.ErrWalletNotOpenedYet)
+
+	_, err = service.BalanceAt(ctx, walletID, time.Now().Add(time.Hour))
+	require.ErrorIs(t, err, services.ErrBalanceTimeInFuture)
+}
+
+func TestWalletService_DailyBalances(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGetter := mocks.NewMockGetterWallet(ctrl)
+	mockHistory := mocks.NewMockBalanceHistory(ctrl)
+
+	ctx := context.Background()
+	walletID := uuid.New()
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	// Opened at 01:00 on March 2 Moscow time, i.e. still March 1 in UTC.
+	createdAt := time.Date(2025, 3, 1, 22, 0, 0, 0, time.UTC)
+
+	mockGetter.
+		EXPECT().
+		GetWallet(ctx, walletID).
+		Return(&models.Wallet{ID: walletID, Currency: "RUB", CreatedAt: createdAt}, nil)
+
+	mockHistory.
+		EXPECT().
+		GetBalancesAt(ctx, walletID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ uuid.UUID, at []time.Time) ([]models.BalancePoint, error) {
+			require.Equal(t, []time.Time{
+				time.Date(2025, 3, 2, 23, 59, 59, 999999000, moscow),
+				time.Date(2025, 3, 3, 23, 59, 59, 999999000, moscow),
+			}, at)
+
+			return []models.BalancePoint{{At: at[0], Balance: 100}, {At: at[1], Balance: 250}}, nil
+		})
+
+	service := &ServiceWallet{
+		walletGetter:   mockGetter,
+		balanceHistory: mockHistory,
+	}
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	series, err := service.DailyBalances(ctx, walletID, from, to, moscow)
+	require.NoError(t, err)
+	require.Equal(t, "RUB", series.Currency)
+	require.Equal(t, "MSK", series.TimeZone)
+	require.Equal(t, []models.DailyBalance{
+		{Date: "2025-03-02", At: time.Date(2025, 3, 2, 23, 59, 59, 999999000, moscow), Balance: 100},
+		{Date: "2025-03-03", At: time.Date(2025, 3, 3, 23, 59, 59, 999999000, moscow), Balance: 250},
+	}, series.Days)
+}
+
+func TestWalletService_DailyBalances_InvalidRange(t *testing.T) {
+	service := &ServiceWallet{}
+	walletID := uuid.New()
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := service.DailyBalances(context.Background(), walletID, day, day.AddDate(0, 0, -1), time.UTC)
+	require.ErrorIs(t, err, services.ErrInvalidDateRange)
+
+	_, err = service.DailyBalances(context.Background(), walletID, day, day.AddDate(1, 0, 1), time.UTC)
+	require.ErrorIs(t, err, services.ErrInvalidDateRange)
+
+	now := time.Now()
+	_, err = service.DailyBalances(context.Background(), walletID, now.AddDate(0, 0, -2), now.AddDate(0, 0, 1), time.UTC)
+	require.ErrorIs(t, err, services.ErrBalanceTimeInFuture)
+}
+
+// statementRecorder is a StatementWriter keeping what it was given.
+type statementRecorder struct {
+	opening *models.Statement
+	lines   []*models.Operation
+	closing *int64
+}
+
+func (s *statementRecorder) Opening(statement *models.Statement) error {
+	s.opening = statement
+	return nil
+}
+
+func (s *statementRecorder) Line(operation *models.Operation) error {
+	s.lines = append(s.lines, operation)
+	return nil
+}
+
+func (s *statementRecorder) Closing(balance int64) error {
+	s.closing = &balance
+	return nil
+}
+
+func TestWalletService_Statement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockGetter := mocks.NewMockGetterWallet(ctrl)
+	mockReader := mocks.NewMockStatementReader(ctrl)
+
+	ctx := context.Background()
+	walletID := uuid.New()
+	createdAt := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	runInTx(mockTxManager, "statement")
+
+	mockGetter.
+		EXPECT().
+		GetWallet(ctx, walletID).
+		Return(&models.Wallet{ID: walletID, Currency: "EUR", CreatedAt: createdAt}, nil)
+
+	mockReader.
+		EXPECT().
+		OpenStatement(ctx, gomock.Any(), walletID, createdAt).
+		Return(int64(500), nil)
+
+	balanceAfter := func(v int64) *int64 { return &v }
+	operations := []*models.Operation{
+		{ID: uuid.New(), Type: models.Deposit, Amount: 200, BalanceAfter: balanceAfter(700)},
+		{ID: uuid.New(), Type: models.Withdraw, Amount: 50, BalanceAfter: balanceAfter(650)},
+	}
+
+	mockReader.
+		EXPECT().
+		WalkStatement(ctx, gomock.Any(), walletID, createdAt, to, int64(500), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ pgxdriver.QueryExecuter,
+			_ uuid.UUID,
+			_ time.Time,
+			_ time.Time,
+			_ int64,
+			visit func(operation *models.Operation) error,
+		) error {
+			for _, operation := range operations {
+				if err := visit(operation); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+
+	service := &ServiceWallet{
+		txManager:       mockTxManager,
+		walletGetter:    mockGetter,
+		statementReader: mockReader,
+	}
+
+	recorder := &statementRecorder{}
+
+	err := service.Statement(ctx, walletID, time.Time{}, to, recorder)
+	require.NoError(t, err)
+	require.Equal(t, &models.Statement{
+		WalletID:       walletID,
+		Currency:       "EUR",
+		From:           createdAt,
+		To:             to,
+		OpeningBalance: 500,
+	}, recorder.opening)
+	require.Equal(t, operations, recorder.lines)
+	require.Equal(t, int64(650), *recorder.closing)
+}
+
+func TestWalletService_Statement_NotRepeatedOnRetry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTxManager := mocks.NewMockManager(ctrl)
+	mockGetter := mocks.NewMockGetterWallet(ctrl)
+	mockReader := mocks.NewMockStatementReader(ctrl)
+
+	ctx := context.Background()
+	walletID := uuid.New()
+	lost := errors.New("connection lost")
+
+	// A retrying manager runs the transaction again after the failure.
+	mockTxManager.
+		EXPECT().
+		ExecuteInTransaction(ctx, "statement", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, fn func(tx pgxdriver.QueryExecuter) error) error {
+			if err := fn(nil); !errors.Is(err, lost) {
+				return err
+			}
+			return fn(nil)
+		})
+
+	mockGetter.
+		EXPECT().
+		GetWallet(ctx, walletID).
+		Return(&models.Wallet{ID: walletID, Currency: "EUR"}, nil)
+
+	mockReader.
+		EXPECT().
+		OpenStatement(ctx, gomock.Any(), walletID, gomock.Any()).
+		Return(int64(500), nil)
+
+	mockReader.
+		EXPECT().
+		WalkStatement(ctx, gomock.Any(), walletID, gomock.Any(), gomock.Any(), int64(500), gomock.Any()).
+		Return(lost)
+
+	service := &ServiceWallet{
+		txManager:       mockTxManager,
+		walletGetter:    mockGetter,
+		statementReader: mockReader,
+	}
+
+	recorder := &statementRecorder{}
+
+	err := service.Statement(ctx, walletID, time.Time{}, time.Time{}, recorder)
+	require.ErrorIs(t, err, errStatementInterrupted)
+	require.NotNil(t, recorder.opening)
+	require.Nil(t, recorder.closing)
+}
+
+func TestWalletService_Statement_InvalidRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockGetter := mocks.NewMockGetterWallet(ctrl)
+
+	walletID := uuid.New()
+	to := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	mockGetter.
+		EXPECT().
+		GetWallet(gomock.Any(), walletID).
+		Return(&models.Wallet{ID: walletID}, nil)
+
+	service := &ServiceWallet{walletGetter: mockGetter}
+
+	err := service.Statement(context.Background(), walletID, to.Add(time.Hour), to, &statementRecorder{})
+	require.ErrorIs(t, err, services.ErrInvalidDateRange)
 }
@@ -0,0 +1,257 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/webhook/webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/services/webhook/webhook.go -destination=internal/services/webhook/mocks/mock_webhook.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	http "net/http"
+	reflect "reflect"
+	time "time"
+	models "wallet-service/internal/domain/models"
+	pgx_driver "wallet-service/pkg/pgx-driver"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSubscriptionStore is a mock of SubscriptionStore interface.
+type MockSubscriptionStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriptionStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriptionStoreMockRecorder is the mock recorder for MockSubscriptionStore.
+type MockSubscriptionStoreMockRecorder struct {
+	mock *MockSubscriptionStore
+}
+
+// NewMockSubscriptionStore creates a new mock instance.
+func NewMockSubscriptionStore(ctrl *gomock.Controller) *MockSubscriptionStore {
+	mock := &MockSubscriptionStore{ctrl: ctrl}
+	mock.recorder = &MockSubscriptionStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriptionStore) EXPECT() *MockSubscriptionStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateSubscription mocks base method.
+func (m *MockSubscriptionStore) CreateSubscription(ctx context.Context, sub *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", ctx, sub)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockSubscriptionStoreMockRecorder) CreateSubscription(ctx, sub any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockSubscriptionStore)(nil).CreateSubscription), ctx, sub)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockSubscriptionStore) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockSubscriptionStoreMockRecorder) DeleteSubscription(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockSubscriptionStore)(nil).DeleteSubscription), ctx, id)
+}
+
+// GetSubscription mocks base method.
+func (m *MockSubscriptionStore) GetSubscription(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscription", ctx, id)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscription indicates an expected call of GetSubscription.
+func (mr *MockSubscriptionStoreMockRecorder) GetSubscription(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscription", reflect.TypeOf((*MockSubscriptionStore)(nil).GetSubscription), ctx, id)
+}
+
+// ListSubscriptions mocks base method.
+func (m *MockSubscriptionStore) ListSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSubscriptions", ctx)
+	ret0, _ := ret[0].([]*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSubscriptions indicates an expected call of ListSubscriptions.
+func (mr *MockSubscriptionStoreMockRecorder) ListSubscriptions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSubscriptions", reflect.TypeOf((*MockSubscriptionStore)(nil).ListSubscriptions), ctx)
+}
+
+// UpdateSubscription mocks base method.
+func (m *MockSubscriptionStore) UpdateSubscription(ctx context.Context, id uuid.UUID, update *models.WebhookSubscriptionUpdate) (*models.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscription", ctx, id, update)
+	ret0, _ := ret[0].(*models.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSubscription indicates an expected call of UpdateSubscription.
+func (mr *MockSubscriptionStoreMockRecorder) UpdateSubscription(ctx, id, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockSubscriptionStore)(nil).UpdateSubscription), ctx, id, update)
+}
+
+// MockDeliveryStore is a mock of DeliveryStore interface.
+type MockDeliveryStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeliveryStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockDeliveryStoreMockRecorder is the mock recorder for MockDeliveryStore.
+type MockDeliveryStoreMockRecorder struct {
+	mock *MockDeliveryStore
+}
+
+// NewMockDeliveryStore creates a new mock instance.
+func NewMockDeliveryStore(ctrl *gomock.Controller) *MockDeliveryStore {
+	mock := &MockDeliveryStore{ctrl: ctrl}
+	mock.recorder = &MockDeliveryStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeliveryStore) EXPECT() *MockDeliveryStoreMockRecorder {
+	return m.recorder
+}
+
+// EnqueueDeliveries mocks base method.
+func (m *MockDeliveryStore) EnqueueDeliveries(ctx context.Context, event *models.OutboxEvent) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueDeliveries", ctx, event)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnqueueDeliveries indicates an expected call of EnqueueDeliveries.
+func (mr *MockDeliveryStoreMockRecorder) EnqueueDeliveries(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueDeliveries", reflect.TypeOf((*MockDeliveryStore)(nil).EnqueueDeliveries), ctx, event)
+}
+
+// GetPendingDeliveriesForUpdate mocks base method.
+func (m *MockDeliveryStore) GetPendingDeliveriesForUpdate(ctx context.Context, tx pgx_driver.QueryExecuter, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingDeliveriesForUpdate", ctx, tx, now, limit)
+	ret0, _ := ret[0].([]*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingDeliveriesForUpdate indicates an expected call of GetPendingDeliveriesForUpdate.
+func (mr *MockDeliveryStoreMockRecorder) GetPendingDeliveriesForUpdate(ctx, tx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingDeliveriesForUpdate", reflect.TypeOf((*MockDeliveryStore)(nil).GetPendingDeliveriesForUpdate), ctx, tx, now, limit)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockDeliveryStore) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, subscriptionID, limit)
+	ret0, _ := ret[0].([]*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockDeliveryStoreMockRecorder) ListDeliveries(ctx, subscriptionID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockDeliveryStore)(nil).ListDeliveries), ctx, subscriptionID, limit)
+}
+
+// RecordDeliveryAttempt mocks base method.
+func (m *MockDeliveryStore) RecordDeliveryAttempt(ctx context.Context, tx pgx_driver.QueryExecuter, id uuid.UUID, status models.WebhookDeliveryStatus, nextAttemptAt time.Time, statusCode *int, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordDeliveryAttempt", ctx, tx, id, status, nextAttemptAt, statusCode, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordDeliveryAttempt indicates an expected call of RecordDeliveryAttempt.
+func (mr *MockDeliveryStoreMockRecorder) RecordDeliveryAttempt(ctx, tx, id, status, nextAttemptAt, statusCode, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordDeliveryAttempt", reflect.TypeOf((*MockDeliveryStore)(nil).RecordDeliveryAttempt), ctx, tx, id, status, nextAttemptAt, statusCode, lastError)
+}
+
+// ResetDelivery mocks base method.
+func (m *MockDeliveryStore) ResetDelivery(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*models.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetDelivery", ctx, subscriptionID, deliveryID)
+	ret0, _ := ret[0].(*models.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetDelivery indicates an expected call of ResetDelivery.
+func (mr *MockDeliveryStoreMockRecorder) ResetDelivery(ctx, subscriptionID, deliveryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetDelivery", reflect.TypeOf((*MockDeliveryStore)(nil).ResetDelivery), ctx, subscriptionID, deliveryID)
+}
+
+// MockHTTPDoer is a mock of HTTPDoer interface.
+type MockHTTPDoer struct {
+	ctrl     *gomock.Controller
+	recorder *MockHTTPDoerMockRecorder
+	isgomock struct{}
+}
+
+// MockHTTPDoerMockRecorder is the mock recorder for MockHTTPDoer.
+type MockHTTPDoerMockRecorder struct {
+	mock *MockHTTPDoer
+}
+
+// NewMockHTTPDoer creates a new mock instance.
+func NewMockHTTPDoer(ctrl *gomock.Controller) *MockHTTPDoer {
+	mock := &MockHTTPDoer{ctrl: ctrl}
+	mock.recorder = &MockHTTPDoerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHTTPDoer) EXPECT() *MockHTTPDoerMockRecorder {
+	return m.recorder
+}
+
+// Do mocks base method.
+func (m *MockHTTPDoer) Do(req *http.Request) (*http.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Do", req)
+	ret0, _ := ret[0].(*http.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockHTTPDoerMockRecorder) Do(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockHTTPDoer)(nil).Do), req)
+}
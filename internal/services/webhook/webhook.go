@@ -0,0 +1,446 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/lib/backoff"
+	"wallet-service/internal/lib/logger/sl"
+	"wallet-service/internal/lib/signature"
+	"wallet-service/internal/services"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+	"wallet-service/pkg/pgx-driver/transaction"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EventIDHeader = "X-Webhook-Event-Id"
+
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 200
+	maxURLLength         = 2048
+	maxLastErrorLength   = 1000
+	maxErrorBodyLength   = 512
+	secretLength         = 32
+)
+
+type SubscriptionStore interface {
+	CreateSubscription(ctx context.Context, sub *models.WebhookSubscription) (*models.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error)
+	UpdateSubscription(
+		ctx context.Context,
+		id uuid.UUID,
+		update *models.WebhookSubscriptionUpdate,
+	) (*models.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+}
+
+type DeliveryStore interface {
+	EnqueueDeliveries(ctx context.Context, event *models.OutboxEvent) (int64, error)
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*models.WebhookDelivery, error)
+	GetPendingDeliveriesForUpdate(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		now time.Time,
+		limit int,
+	) ([]*models.WebhookDelivery, error)
+	RecordDeliveryAttempt(
+		ctx context.Context,
+		tx pgxdriver.QueryExecuter,
+		id uuid.UUID,
+		status models.WebhookDeliveryStatus,
+		nextAttemptAt time.Time,
+		statusCode *int,
+		lastError string,
+	) error
+	ResetDelivery(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*models.WebhookDelivery, error)
+}
+
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type Service struct {
+	txManager transaction.Manager
+	log       *slog.Logger
+
+	subscriptions SubscriptionStore
+	deliveries    DeliveryStore
+	client        HTTPDoer
+
+	maxAttempts int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+}
+
+func New(
+	txManager transaction.Manager,
+	log *slog.Logger,
+	subscriptions SubscriptionStore,
+	deliveries DeliveryStore,
+	client HTTPDoer,
+	maxAttempts int,
+	baseBackoff time.Duration,
+	maxBackoff time.Duration,
+) *Service {
+
+	return &Service{
+		txManager:     txManager,
+		log:           log,
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		client:        client,
+		maxAttempts:   maxAttempts,
+		baseBackoff:   baseBackoff,
+		maxBackoff:    maxBackoff,
+	}
+}
+
+// CreateSubscription registers url for events matching eventTypes and
+// walletIDs; empty filters match everything. Without a secret one is
+// generated. The secret is returned only here.
+func (s *Service) CreateSubscription(
+	ctx context.Context,
+	rawURL string,
+	eventTypes []string,
+	walletIDs []uuid.UUID,
+	secret string,
+) (*models.WebhookSubscription, error) {
+
+	const op = "services.webhook.CreateSubscription"
+
+	if err := validateURL(rawURL); err != nil {
+		return nil, err
+	}
+
+	if err := validateEventTypes(eventTypes); err != nil {
+		return nil, err
+	}
+
+	if secret == "" {
+		buf := make([]byte, secretLength)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		secret = hex.EncodeToString(buf)
+	}
+
+	sub, err := s.subscriptions.CreateSubscription(ctx, &models.WebhookSubscription{
+		ID:         uuid.New(),
+		URL:        rawURL,
+		EventTypes: nonNil(eventTypes),
+		WalletIDs:  nonNil(walletIDs),
+		Secret:     secret,
+		Active:     true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return sub, nil
+}
+
+func (s *Service) ListSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	const op = "services.webhook.ListSubscriptions"
+
+	subs, err := s.subscriptions.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, sub := range subs {
+		sub.Secret = ""
+	}
+
+	return subs, nil
+}
+
+func (s *Service) GetSubscription(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	const op = "services.webhook.GetSubscription"
+
+	sub, err := s.subscriptions.GetSubscription(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrWebhookNotFound) {
+			return nil, storage.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sub.Secret = ""
+	return sub, nil
+}
+
+func (s *Service) UpdateSubscription(
+	ctx context.Context,
+	id uuid.UUID,
+	update *models.WebhookSubscriptionUpdate,
+) (*models.WebhookSubscription, error) {
+
+	const op = "services.webhook.UpdateSubscription"
+
+	if update.URL != nil {
+		if err := validateURL(*update.URL); err != nil {
+			return nil, err
+		}
+	}
+
+	if update.EventTypes != nil {
+		if err := validateEventTypes(*update.EventTypes); err != nil {
+			return nil, err
+		}
+		eventTypes := nonNil(*update.EventTypes)
+		update.EventTypes = &eventTypes
+	}
+
+	if update.WalletIDs != nil {
+		walletIDs := nonNil(*update.WalletIDs)
+		update.WalletIDs = &walletIDs
+	}
+
+	sub, err := s.subscriptions.UpdateSubscription(ctx, id, update)
+	if err != nil {
+		if errors.Is(err, storage.ErrWebhookNotFound) {
+			return nil, storage.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	sub.Secret = ""
+	return sub, nil
+}
+
+func (s *Service) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	const op = "services.webhook.DeleteSubscription"
+
+	if err := s.subscriptions.DeleteSubscription(ctx, id); err != nil {
+		if errors.Is(err, storage.ErrWebhookNotFound) {
+			return storage.ErrWebhookNotFound
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ListDeliveries returns the delivery log of a subscription, newest first.
+// A limit of 0 means the default page size.
+func (s *Service) ListDeliveries(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	limit int,
+) ([]*models.WebhookDelivery, error) {
+
+	const op = "services.webhook.ListDeliveries"
+
+	if limit <= 0 {
+		limit = defaultDeliveryLimit
+	}
+	limit = min(limit, maxDeliveryLimit)
+
+	if _, err := s.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := s.deliveries.ListDeliveries(ctx, subscriptionID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return deliveries, nil
+}
+
+// Redeliver queues a delivery again with a fresh retry budget, e.g. after
+// the receiver fixed an outage that exhausted the retries.
+func (s *Service) Redeliver(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	deliveryID uuid.UUID,
+) (*models.WebhookDelivery, error) {
+
+	const op = "services.webhook.Redeliver"
+
+	delivery, err := s.deliveries.ResetDelivery(ctx, subscriptionID, deliveryID)
+	if err != nil {
+		if errors.Is(err, storage.ErrDeliveryNotFound) {
+			return nil, storage.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return delivery, nil
+}
+
+// Publish queues event for every matching subscription. It makes the
+// service an outbox EventPublisher; queuing is idempotent per event, so an
+// event relayed twice is still delivered once per subscription.
+func (s *Service) Publish(ctx context.Context, event *models.OutboxEvent) error {
+	const op = "services.webhook.Publish"
+
+	if _, err := s.deliveries.EnqueueDeliveries(ctx, event); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeliverBatch sends up to batchSize due deliveries and returns how many it
+// claimed. A delivery succeeds on any 2xx response; otherwise it is retried
+// with exponential backoff and marked FAILED after maxAttempts.
+func (s *Service) DeliverBatch(ctx context.Context, batchSize int) (int, error) {
+	const op = "services.webhook.DeliverBatch"
+
+	var claimed int
+	err := s.txManager.ExecuteInTransaction(ctx, "deliver_webhooks", func(tx pgxdriver.QueryExecuter) error {
+		now := time.Now()
+
+		deliveries, err := s.deliveries.GetPendingDeliveriesForUpdate(ctx, tx, now, batchSize)
+		if err != nil {
+			return err
+		}
+		claimed = len(deliveries)
+
+		for _, delivery := range deliveries {
+			result := s.send(ctx, delivery)
+
+			status := models.DeliveryDelivered
+			lastError := ""
+			if result.Err != nil {
+				status = models.DeliveryPending
+				if delivery.Attempts+1 >= s.maxAttempts {
+					status = models.DeliveryFailed
+				}
+
+				lastError = result.Err.Error()
+				if len(lastError) > maxLastErrorLength {
+					lastError = lastError[:maxLastErrorLength]
+				}
+
+				s.log.Warn("failed to deliver webhook",
+					slog.String("delivery_id", delivery.ID.String()),
+					slog.Int("attempts", delivery.Attempts+1),
+					sl.Err(result.Err),
+				)
+			}
+
+			nextAttemptAt := now.Add(backoff.Exponential(s.baseBackoff, s.maxBackoff, delivery.Attempts+1))
+
+			err := s.deliveries.RecordDeliveryAttempt(ctx, tx, delivery.ID, status,
+				nextAttemptAt, result.StatusCode, lastError)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return claimed, nil
+}
+
+// attempt is the outcome of one POST of a delivery.
+type attempt struct {
+	StatusCode *int
+	Err        error
+}
+
+// webhookBody is the JSON document POSTed to subscribers.
+type webhookBody struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       string          `json:"type"`
+	DeliveryID uuid.UUID       `json:"delivery_id"`
+	CreatedAt  time.Time       `json:"created_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+func (s *Service) send(ctx context.Context, delivery *models.WebhookDelivery) attempt {
+	body, err := json.Marshal(webhookBody{
+		ID:         delivery.EventID,
+		Type:       delivery.EventType,
+		DeliveryID: delivery.ID,
+		CreatedAt:  delivery.CreatedAt,
+		Data:       delivery.Payload,
+	})
+	if err != nil {
+		return attempt{Err: err}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return attempt{Err: err}
+	}
+
+	sentAt := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventIDHeader, delivery.EventID.String())
+	req.Header.Set(signature.TimestampHeader, strconv.FormatInt(sentAt.Unix(), 10))
+	req.Header.Set(signature.SignatureHeader, signature.Sign(delivery.Secret, sentAt, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return attempt{Err: err}
+	}
+	defer resp.Body.Close()
+
+	statusCode := resp.StatusCode
+	if statusCode >= 200 && statusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return attempt{StatusCode: &statusCode}
+	}
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+	return attempt{
+		StatusCode: &statusCode,
+		Err:        fmt.Errorf("unexpected status %d: %s", statusCode, bytes.TrimSpace(snippet)),
+	}
+}
+
+func validateURL(rawURL string) error {
+	if len(rawURL) > maxURLLength {
+		return services.ErrInvalidWebhookURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return services.ErrInvalidWebhookURL
+	}
+
+	return nil
+}
+
+func validateEventTypes(eventTypes []string) error {
+	known := models.EventTypes()
+	for _, eventType := range eventTypes {
+		if !slices.Contains(known, eventType) {
+			return services.ErrInvalidEventType
+		}
+	}
+
+	return nil
+}
+
+// nonNil turns a nil filter into an empty one; the columns are NOT NULL.
+func nonNil[T any](values []T) []T {
+	if values == nil {
+		return []T{}
+	}
+	return values
+}
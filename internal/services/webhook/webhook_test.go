@@ -0,0 +1,214 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/lib/signature"
+	"wallet-service/internal/services"
+	"wallet-service/internal/services/webhook/mocks"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func runInTx(mockTxManager *mocks.MockManager, name string) {
+	mockTxManager.
+		EXPECT().
+		ExecuteInTransaction(gomock.Any(), name, gomock.Any()).
+		DoAndReturn(func(
+			ctx context.Context,
+			name string,
+			fn func(tx pgxdriver.QueryExecuter) error,
+		) error {
+			return fn(nil)
+		})
+}
+
+func newService(ctrl *gomock.Controller, deliveries DeliveryStore) *Service {
+	mockTxManager := mocks.NewMockManager(ctrl)
+	runInTx(mockTxManager, "deliver_webhooks")
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	return New(mockTxManager, logger, nil, deliveries, http.DefaultClient, 3, time.Minute, time.Hour)
+}
+
+func TestService_DeliverBatch_SignedDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDeliveries := mocks.NewMockDeliveryStore(ctrl)
+
+	delivery := &models.WebhookDelivery{
+		ID:        uuid.New(),
+		EventID:   uuid.New(),
+		EventType: models.EventBalanceChanged,
+		Payload:   json.RawMessage(`{"amount":100}`),
+		Secret:    "s3cret",
+	}
+
+	received := make(chan *http.Request, 1)
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		received <- r
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+	delivery.URL = receiver.URL
+
+	mockDeliveries.
+		EXPECT().
+		GetPendingDeliveriesForUpdate(gomock.Any(), gomock.Any(), gomock.Any(), 10).
+		Return([]*models.WebhookDelivery{delivery}, nil)
+
+	noContent := http.StatusNoContent
+	mockDeliveries.
+		EXPECT().
+		RecordDeliveryAttempt(gomock.Any(), gomock.Any(), delivery.ID, models.DeliveryDelivered,
+			gomock.Any(), &noContent, "").
+		Return(nil)
+
+	claimed, err := newService(ctrl, mockDeliveries).DeliverBatch(context.Background(), 10)
+
+	require.NoError(t, err)
+	require.Equal(t, 1, claimed)
+
+	req := <-received
+	require.Equal(t, delivery.EventID.String(), req.Header.Get(EventIDHeader))
+	require.NoError(t, signature.Verify(
+		"s3cret",
+		req.Header.Get(signature.SignatureHeader),
+		req.Header.Get(signature.TimestampHeader),
+		body,
+		time.Now(),
+		time.Minute,
+	))
+
+	var sent webhookBody
+	require.NoError(t, json.Unmarshal(body, &sent))
+	require.Equal(t, delivery.EventID, sent.ID)
+	require.JSONEq(t, `{"amount":100}`, string(sent.Data))
+}
+
+func TestService_DeliverBatch_Retries(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		status   models.WebhookDeliveryStatus
+		delay    time.Duration
+	}{
+		{name: "first failure is retried", attempts: 0, status: models.DeliveryPending, delay: time.Minute},
+		{name: "backoff doubles", attempts: 1, status: models.DeliveryPending, delay: 2 * time.Minute},
+		{name: "last attempt fails the delivery", attempts: 2, status: models.DeliveryFailed, delay: 4 * time.Minute},
+	}
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDeliveries := mocks.NewMockDeliveryStore(ctrl)
+
+			delivery := &models.WebhookDelivery{
+				ID:       uuid.New(),
+				Payload:  json.RawMessage(`{}`),
+				Attempts: tt.attempts,
+				URL:      receiver.URL,
+			}
+
+			var claimedAt time.Time
+			mockDeliveries.
+				EXPECT().
+				GetPendingDeliveriesForUpdate(gomock.Any(), gomock.Any(), gomock.Any(), 10).
+				DoAndReturn(func(_ context.Context, _ pgxdriver.QueryExecuter, now time.Time, _ int) ([]*models.WebhookDelivery, error) {
+					claimedAt = now
+					return []*models.WebhookDelivery{delivery}, nil
+				})
+
+			mockDeliveries.
+				EXPECT().
+				RecordDeliveryAttempt(gomock.Any(), gomock.Any(), delivery.ID, tt.status,
+					gomock.Any(), gomock.Any(), "unexpected status 503: maintenance").
+				DoAndReturn(func(
+					_ context.Context,
+					_ pgxdriver.QueryExecuter,
+					_ uuid.UUID,
+					_ models.WebhookDeliveryStatus,
+					next time.Time,
+					statusCode *int,
+					_ string,
+				) error {
+					require.Equal(t, tt.delay, next.Sub(claimedAt))
+					require.Equal(t, http.StatusServiceUnavailable, *statusCode)
+					return nil
+				})
+
+			_, err := newService(ctrl, mockDeliveries).DeliverBatch(context.Background(), 10)
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_CreateSubscription(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSubscriptions := mocks.NewMockSubscriptionStore(ctrl)
+
+	mockSubscriptions.
+		EXPECT().
+		CreateSubscription(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, sub *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+			require.Len(t, sub.Secret, 2*secretLength)
+			require.NotNil(t, sub.EventTypes)
+			require.NotNil(t, sub.WalletIDs)
+			require.True(t, sub.Active)
+			return sub, nil
+		})
+
+	service := &Service{subscriptions: mockSubscriptions}
+
+	sub, err := service.CreateSubscription(context.Background(), "https://partner.example/hooks", nil, nil, "")
+
+	require.NoError(t, err)
+	require.NotEmpty(t, sub.Secret)
+}
+
+func TestService_CreateSubscription_Invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		eventTypes []string
+		err        error
+	}{
+		{name: "relative url", url: "/hooks", err: services.ErrInvalidWebhookURL},
+		{name: "unsupported scheme", url: "ftp://partner.example", err: services.ErrInvalidWebhookURL},
+		{name: "unknown event", url: "https://partner.example", eventTypes: []string{"wallet.deleted"}, err: services.ErrInvalidEventType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &Service{}
+
+			_, err := service.CreateSubscription(context.Background(), tt.url, tt.eventTypes, nil, "")
+
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
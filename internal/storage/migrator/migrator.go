@@ -0,0 +1,188 @@
+// Package migrator applies the embedded SQL migrations with golang-migrate,
+// keeping the schema_migrations table of the standalone migrate tool.
+package migrator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"wallet-service/migrations"
+
+	"github.com/golang-migrate/migrate/v4"
+	pgxmigrate "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+var ErrInvalidSteps = errors.New("invalid number of steps: must be > 0")
+
+var migrationFile = regexp.MustCompile(`^(\d+)_.+\.(up|down)\.sql$`)
+
+// Status compares the schema of the database with the embedded migrations.
+type Status struct {
+	Version uint `json:"version"`
+	Dirty   bool `json:"dirty"`
+	Latest  uint `json:"latest"`
+	Pending int  `json:"pending"`
+}
+
+// Behind reports whether migrations have to be applied before the binary
+// can use the database.
+func (s *Status) Behind() bool {
+	return s.Dirty || s.Version < s.Latest
+}
+
+// Migrator runs migrations under a Postgres advisory lock: replicas started
+// together take turns, and all but the first find nothing left to apply.
+type Migrator struct {
+	m        *migrate.Migrate
+	versions []uint
+}
+
+func New(dsn string, log *slog.Logger, lockTimeout time.Duration) (*Migrator, error) {
+	const op = "storage.migrator.New"
+
+	versions, err := Versions()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	source, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return nil, fmt.Errorf("%s: open migrations: %w", op, err)
+	}
+
+	connConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%s: parse dsn: %w", op, err)
+	}
+
+	driver, err := pgxmigrate.WithInstance(stdlib.OpenDB(*connConfig), &pgxmigrate.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("%s: connect: %w", op, err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, "pgx5", driver)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	m.Log = &logger{log: log}
+	m.LockTimeout = lockTimeout
+
+	return &Migrator{m: m, versions: versions}, nil
+}
+
+// Up applies every pending migration.
+func (mg *Migrator) Up() error {
+	if err := mg.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("storage.migrator.Up: %w", err)
+	}
+
+	return nil
+}
+
+// Down rolls back the last steps migrations.
+func (mg *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return ErrInvalidSteps
+	}
+
+	if err := mg.m.Steps(-steps); err != nil {
+		return fmt.Errorf("storage.migrator.Down: %w", err)
+	}
+
+	return nil
+}
+
+// Force records version as applied and clears the dirty flag without running
+// anything, after a failed migration has been repaired by hand.
+func (mg *Migrator) Force(version int) error {
+	if err := mg.m.Force(version); err != nil {
+		return fmt.Errorf("storage.migrator.Force: %w", err)
+	}
+
+	return nil
+}
+
+func (mg *Migrator) Status() (*Status, error) {
+	version, dirty, err := mg.m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, fmt.Errorf("storage.migrator.Status: %w", err)
+	}
+
+	return newStatus(mg.versions, version, dirty), nil
+}
+
+func (mg *Migrator) Close() error {
+	sourceErr, dbErr := mg.m.Close()
+
+	return errors.Join(sourceErr, dbErr)
+}
+
+func newStatus(versions []uint, version uint, dirty bool) *Status {
+	status := &Status{Version: version, Dirty: dirty}
+	for _, v := range versions {
+		if v > version {
+			status.Pending++
+		}
+	}
+	if len(versions) > 0 {
+		status.Latest = versions[len(versions)-1]
+	}
+
+	return status
+}
+
+// Versions lists the embedded migrations in order.
+func Versions() ([]uint, error) {
+	entries, err := fs.ReadDir(migrations.FS, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []uint
+	for _, entry := range entries {
+		match := migrationFile.FindStringSubmatch(entry.Name())
+		if match == nil || match[2] != "up" {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", entry.Name(), err)
+		}
+		versions = append(versions, uint(version))
+	}
+
+	return versions, nil
+}
+
+// LatestVersion is the version the schema has once every embedded migration
+// is applied.
+func LatestVersion() uint {
+	versions, err := Versions()
+	if err != nil || len(versions) == 0 {
+		return 0
+	}
+
+	return versions[len(versions)-1]
+}
+
+// logger passes the progress of golang-migrate to slog.
+type logger struct {
+	log *slog.Logger
+}
+
+func (l *logger) Printf(format string, v ...any) {
+	l.log.Info(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (l *logger) Verbose() bool {
+	return false
+}
@@ -0,0 +1,59 @@
+package migrator
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+	"wallet-service/migrations"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersions(t *testing.T) {
+	versions, err := Versions()
+	require.NoError(t, err)
+	require.NotEmpty(t, versions)
+
+	for i, version := range versions {
+		require.Equal(t, uint(i+1), version, "migrations must be numbered without gaps")
+	}
+	require.Equal(t, versions[len(versions)-1], LatestVersion())
+}
+
+func TestEveryMigrationHasDown(t *testing.T) {
+	ups, err := fs.Glob(migrations.FS, "*.up.sql")
+	require.NoError(t, err)
+
+	for _, up := range ups {
+		_, err := fs.Stat(migrations.FS, strings.TrimSuffix(up, ".up.sql")+".down.sql")
+		require.NoError(t, err, up)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	versions := []uint{1, 2, 3}
+
+	tests := []struct {
+		name    string
+		version uint
+		dirty   bool
+		pending int
+		behind  bool
+	}{
+		{name: "empty database", version: 0, pending: 3, behind: true},
+		{name: "behind", version: 2, pending: 1, behind: true},
+		{name: "up to date", version: 3, pending: 0, behind: false},
+		{name: "dirty", version: 3, dirty: true, pending: 0, behind: true},
+		{name: "ahead", version: 4, pending: 0, behind: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := newStatus(versions, tt.version, tt.dirty)
+
+			require.Equal(t, uint(3), status.Latest)
+			require.Equal(t, tt.pending, status.Pending)
+			require.Equal(t, tt.behind, status.Behind())
+		})
+	}
+}
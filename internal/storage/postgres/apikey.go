@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+	"wallet-service/pkg/pgx-driver/transaction"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var apiKeyColumns = []string{
+	"id", "name", "prefix", "scopes", "created_at", "last_used_at", "revoked_at",
+}
+
+func scanAPIKey(row pgx.Row) (*models.APIKey, error) {
+	key := &models.APIKey{}
+	err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Prefix,
+		&key.Scopes,
+		&key.CreatedAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+type APIKeyRepository struct {
+	postgres *pgxdriver.Postgres
+	log      *slog.Logger
+}
+
+func NewAPIKeyRepository(log *slog.Logger, postgres *pgxdriver.Postgres) *APIKeyRepository {
+	return &APIKeyRepository{
+		postgres: postgres,
+		log:      log,
+	}
+}
+
+func (ar *APIKeyRepository) CreateAPIKey(ctx context.Context, key *models.APIKey, keyHash []byte) (*models.APIKey, error) {
+	const op = "storage.postgres.CreateAPIKey"
+
+	query, args, err := ar.postgres.
+		Insert("api_keys").
+		Columns("id", "name", "prefix", "key_hash", "scopes").
+		Values(key.ID, key.Name, key.Prefix, keyHash, key.Scopes).
+		Suffix("RETURNING " + strings.Join(apiKeyColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_insert", err)
+	}
+
+	created, err := scanAPIKey(ar.postgres.Pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, transaction.HandleError(op, "insert", err)
+	}
+
+	return created, nil
+}
+
+// GetAPIKeyByHash returns the key with the given hash, revoked or not.
+func (ar *APIKeyRepository) GetAPIKeyByHash(ctx context.Context, keyHash []byte) (*models.APIKey, error) {
+	const op = "storage.postgres.GetAPIKeyByHash"
+
+	query, args, err := ar.postgres.
+		Select(apiKeyColumns...).
+		From("api_keys").
+		Where("key_hash = ?", keyHash).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	key, err := scanAPIKey(ar.postgres.Pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrAPIKeyNotFound
+		}
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return key, nil
+}
+
+func (ar *APIKeyRepository) ListAPIKeys(ctx context.Context) ([]*models.APIKey, error) {
+	const op = "storage.postgres.ListAPIKeys"
+
+	query, args, err := ar.postgres.
+		Select(apiKeyColumns...).
+		From("api_keys").
+		OrderBy("created_at", "id").
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	rows, err := ar.postgres.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+	defer rows.Close()
+
+	keys := make([]*models.APIKey, 0)
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, transaction.HandleError(op, "scan", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey revokes a key that is not revoked yet and returns it; an
+// already revoked key is returned unchanged.
+func (ar *APIKeyRepository) RevokeAPIKey(ctx context.Context, id uuid.UUID) (*models.APIKey, error) {
+	const op = "storage.postgres.RevokeAPIKey"
+
+	query, args, err := ar.postgres.
+		Update("api_keys").
+		Set("revoked_at", squirrel.Expr("COALESCE(revoked_at, now())")).
+		Where("id = ?", id).
+		Suffix("RETURNING " + strings.Join(apiKeyColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_update", err)
+	}
+
+	key, err := scanAPIKey(ar.postgres.Pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrAPIKeyNotFound
+		}
+		return nil, transaction.HandleError(op, "update", err)
+	}
+
+	return key, nil
+}
+
+func (ar *APIKeyRepository) TouchAPIKey(ctx context.Context, id uuid.UUID) error {
+	const op = "storage.postgres.TouchAPIKey"
+
+	query, args, err := ar.postgres.
+		Update("api_keys").
+		Set("last_used_at", squirrel.Expr("now()")).
+		Where("id = ?", id).
+		ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_update", err)
+	}
+
+	if _, err := ar.postgres.Pool.Exec(ctx, query, args...); err != nil {
+		return transaction.HandleError(op, "update", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+	"wallet-service/pkg/pgx-driver/transaction"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// shardedWalletSelect returns an active sharded wallet once the "target"
+// CTE of the same statement has share-locked its row, and the "source" CTE
+// has found what the operation needs.
+var shardedWalletSelect = `
+SELECT ` + strings.Join(walletColumns, ", ") + `
+FROM wallets
+WHERE id = (SELECT id FROM target)
+  AND EXISTS (SELECT 1 FROM source)`
+
+// The wallet row is share-locked by the bucket writers: status changes and
+// LockWallets, which lock it exclusively, wait for them and keep them out.
+// The posting trigger picks the bucket a deposit goes to.
+var increaseBucketQuery = `
+WITH target AS (
+    SELECT id
+    FROM wallets
+    WHERE id = $1 AND status = 'ACTIVE' AND balance_buckets > 0
+    FOR KEY SHARE
+), source AS (
+    SELECT id FROM target
+)` + shardedWalletSelect
+
+// A withdrawal locks a bucket covering it, so the posting trigger finds one
+// to draw from. A bucket being written by another transaction is skipped
+// rather than waited for.
+var decreaseBucketQuery = `
+WITH target AS (
+    SELECT id
+    FROM wallets
+    WHERE id = $1 AND status = 'ACTIVE' AND balance_buckets > 0
+    FOR KEY SHARE
+), source AS (
+    SELECT bucket
+    FROM wallet_balance_buckets
+    WHERE wallet_id = (SELECT id FROM target) AND balance >= $2
+    ORDER BY random()
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)` + shardedWalletSelect
+
+// The wallet row is locked before the buckets, in the order LockWallets
+// takes them. Its lock mode lets bucket writers go on with other buckets.
+// Draining a bucket folds its funds into the wallets row (see
+// fold_wallet_balance_bucket), which is read back by a later statement.
+const drainBucketsQuery = `
+WITH target AS (
+    SELECT id
+    FROM wallets
+    WHERE id = $1
+    FOR NO KEY UPDATE
+)
+UPDATE wallet_balance_buckets
+SET balance = 0
+WHERE wallet_id = (SELECT id FROM target) AND balance > 0`
+
+// IncreaseBucketBalance prepares a credit of an active sharded wallet, which
+// its posting makes in a random bucket. A wallet that is no longer sharded
+// is credited on its row.
+func (wr *WalletRepository) IncreaseBucketBalance(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+	amount int64,
+) (*models.Wallet, error) {
+
+	const op = "storage.postgres.IncreaseBucketBalance"
+
+	wallet, err := scanWallet(tx.QueryRow(ctx, increaseBucketQuery, walletID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return wr.IncreaseBalance(ctx, tx, walletID, amount)
+		}
+
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return projectBalance(wallet, amount), nil
+}
+
+// DecreaseBucketBalance prepares a debit of an active sharded wallet from a
+// random bucket that covers the whole amount. It fails with
+// ErrInsufficientFunds when no single free bucket does, even if their sum
+// would.
+func (wr *WalletRepository) DecreaseBucketBalance(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+	amount int64,
+) (*models.Wallet, error) {
+
+	const op = "storage.postgres.DecreaseBucketBalance"
+
+	wallet, err := scanWallet(tx.QueryRow(ctx, decreaseBucketQuery, walletID, amount))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = wr.rejectedUpdateError(ctx, tx, op, walletID)
+			if errors.Is(err, storage.ErrWalletSharded) {
+				return nil, storage.ErrInsufficientFunds
+			}
+			return nil, err
+		}
+
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return projectBalance(wallet, -amount), nil
+}
+
+// ConsolidateBalance moves the funds of the wallet's buckets onto its row
+// and keeps the row locked until the transaction ends. Deposits into the
+// buckets may go on meanwhile.
+func (wr *WalletRepository) ConsolidateBalance(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+) (*models.Wallet, error) {
+
+	const op = "storage.postgres.ConsolidateBalance"
+
+	if _, err := tx.Exec(ctx, drainBucketsQuery, walletID); err != nil {
+		return nil, transaction.HandleError(op, "drain", err)
+	}
+
+	query, args, err := wr.postgres.
+		Select(walletColumns...).
+		From("wallets").
+		Where("id = ?", walletID).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	wallet, err := scanWallet(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrWalletNotFound
+		}
+
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return wallet, nil
+}
+
+// SetBalanceBuckets shards the balance of the wallet into the given number
+// of buckets; zero turns sharding off. The caller locks the wallet first,
+// which consolidates it.
+func (wr *WalletRepository) SetBalanceBuckets(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+	buckets int,
+) (*models.Wallet, error) {
+
+	const op = "storage.postgres.SetBalanceBuckets"
+
+	query, args, err := wr.postgres.
+		Delete("wallet_balance_buckets").
+		Where("wallet_id = ? AND balance = 0", walletID).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_delete", err)
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return nil, transaction.HandleError(op, "delete", err)
+	}
+
+	query, args, err = wr.postgres.
+		Update("wallets").
+		Set("balance_buckets", buckets).
+		Where("id = ?", walletID).
+		Suffix(walletReturning()).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_update", err)
+	}
+
+	wallet, err := scanWallet(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrWalletNotFound
+		}
+		return nil, transaction.HandleError(op, "update", err)
+	}
+
+	return wallet, nil
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+	"wallet-service/pkg/pgx-driver/transaction"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var quoteColumns = []string{
+	"id", "from_currency", "to_currency", "trim_scale(rate)::text", "expires_at", "used_at", "created_at",
+}
+
+func scanQuote(row pgx.Row) (*models.FXQuote, error) {
+	quote := &models.FXQuote{}
+	err := row.Scan(
+		&quote.ID,
+		&quote.FromCurrency,
+		&quote.ToCurrency,
+		&quote.Rate,
+		&quote.ExpiresAt,
+		&quote.UsedAt,
+		&quote.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return quote, nil
+}
+
+type QuoteRepository struct {
+	postgres *pgxdriver.Postgres
+	log      *slog.Logger
+}
+
+func NewQuoteRepository(log *slog.Logger, postgres *pgxdriver.Postgres) *QuoteRepository {
+	return &QuoteRepository{
+		postgres: postgres,
+		log:      log,
+	}
+}
+
+func (qr *QuoteRepository) CreateQuote(ctx context.Context, quote *models.FXQuote) (*models.FXQuote, error) {
+	const op = "storage.postgres.CreateQuote"
+
+	query, args, err := qr.postgres.
+		Insert("fx_quotes").
+		Columns("id", "from_currency", "to_currency", "rate", "expires_at").
+		Values(
+			quote.ID,
+			quote.FromCurrency,
+			quote.ToCurrency,
+			squirrel.Expr("?::text::numeric", quote.Rate),
+			quote.ExpiresAt,
+		).
+		Suffix("RETURNING " + strings.Join(quoteColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_insert", err)
+	}
+
+	created, err := scanQuote(qr.postgres.Pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, transaction.HandleError(op, "insert", err)
+	}
+
+	return created, nil
+}
+
+// GetQuoteForUpdate loads a quote and locks it, so two exchanges cannot
+// consume the same quote concurrently.
+func (qr *QuoteRepository) GetQuoteForUpdate(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	id uuid.UUID,
+) (*models.FXQuote, error) {
+
+	const op = "storage.postgres.GetQuoteForUpdate"
+
+	query, args, err := qr.postgres.
+		Select(quoteColumns...).
+		From("fx_quotes").
+		Where("id = ?", id).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	quote, err := scanQuote(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrQuoteNotFound
+		}
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return quote, nil
+}
+
+func (qr *QuoteRepository) MarkQuoteUsed(ctx context.Context, tx pgxdriver.QueryExecuter, id uuid.UUID) error {
+	const op = "storage.postgres.MarkQuoteUsed"
+
+	query, args, err := qr.postgres.
+		Update("fx_quotes").
+		Set("used_at", squirrel.Expr("now()")).
+		Where("id = ?", id).
+		ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_update", err)
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return transaction.HandleError(op, "update", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return storage.ErrQuoteNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+	"wallet-service/pkg/pgx-driver/transaction"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var holdColumns = []string{
+	"id", "wallet_id", "amount", "captured_amount", "status", "expires_at", "created_at", "updated_at",
+}
+
+func scanHold(row pgx.Row) (*models.Hold, error) {
+	hold := &models.Hold{}
+	err := row.Scan(
+		&hold.ID,
+		&hold.WalletID,
+		&hold.Amount,
+		&hold.CapturedAmount,
+		&hold.Status,
+		&hold.ExpiresAt,
+		&hold.CreatedAt,
+		&hold.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+type HoldRepository struct {
+	postgres *pgxdriver.Postgres
+	log      *slog.Logger
+}
+
+func NewHoldRepository(log *slog.Logger, postgres *pgxdriver.Postgres) *HoldRepository {
+	return &HoldRepository{
+		postgres: postgres,
+		log:      log,
+	}
+}
+
+func (hr *HoldRepository) CreateHold(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	hold *models.Hold,
+) (*models.Hold, error) {
+
+	const op = "storage.postgres.CreateHold"
+
+	query, args, err := hr.postgres.
+		Insert("holds").
+		Columns("id", "wallet_id", "amount", "status", "expires_at").
+		Values(hold.ID, hold.WalletID, hold.Amount, hold.Status, hold.ExpiresAt).
+		Suffix("RETURNING " + strings.Join(holdColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_insert", err)
+	}
+
+	created, err := scanHold(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, transaction.HandleError(op, "insert", err)
+	}
+
+	return created, nil
+}
+
+// GetHoldForUpdate loads a hold and locks its row until the transaction ends.
+func (hr *HoldRepository) GetHoldForUpdate(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	id uuid.UUID,
+) (*models.Hold, error) {
+
+	const op = "storage.postgres.GetHoldForUpdate"
+
+	query, args, err := hr.postgres.
+		Select(holdColumns...).
+		From("holds").
+		Where("id = ?", id).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	hold, err := scanHold(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrHoldNotFound
+		}
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return hold, nil
+}
+
+func (hr *HoldRepository) UpdateHoldStatus(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	id uuid.UUID,
+	status models.HoldStatus,
+	capturedAmount int64,
+) (*models.Hold, error) {
+
+	const op = "storage.postgres.UpdateHoldStatus"
+
+	query, args, err := hr.postgres.
+		Update("holds").
+		Set("status", status).
+		Set("captured_amount", capturedAmount).
+		Where("id = ?", id).
+		Suffix("RETURNING " + strings.Join(holdColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_update", err)
+	}
+
+	hold, err := scanHold(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrHoldNotFound
+		}
+		return nil, transaction.HandleError(op, "update", err)
+	}
+
+	return hold, nil
+}
+
+// GetExpiredHoldsForUpdate locks up to limit active holds that expired
+// before now, skipping rows already locked by a concurrent capture or void.
+func (hr *HoldRepository) GetExpiredHoldsForUpdate(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	now time.Time,
+	limit int,
+) ([]*models.Hold, error) {
+
+	const op = "storage.postgres.GetExpiredHoldsForUpdate"
+
+	query, args, err := hr.postgres.
+		Select(holdColumns...).
+		From("holds").
+		Where(squirrel.And{
+			squirrel.Eq{"status": models.HoldActive},
+			squirrel.LtOrEq{"expires_at": now},
+		}).
+		OrderBy("expires_at").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+	defer rows.Close()
+
+	var holds []*models.Hold
+	for rows.Next() {
+		hold, err := scanHold(rows)
+		if err != nil {
+			return nil, transaction.HandleError(op, "scan", err)
+		}
+		holds = append(holds, hold)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return holds, nil
+}
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+	"wallet-service/pkg/pgx-driver/transaction"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type IdempotencyRepository struct {
+	postgres *pgxdriver.Postgres
+	log      *slog.Logger
+}
+
+func NewIdempotencyRepository(log *slog.Logger, postgres *pgxdriver.Postgres) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		postgres: postgres,
+		log:      log,
+	}
+}
+
+func (ir *IdempotencyRepository) GetIdempotencyRecord(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	key string,
+) (*models.IdempotencyRecord, error) {
+
+	const op = "storage.postgres.GetIdempotencyRecord"
+
+	query, args, err := ir.postgres.
+		Select(
+			"key",
+			"wallet_id",
+			"request_hash",
+			"operation_id",
+			"balance",
+			"held_balance",
+			"currency",
+			"wallet_updated_at",
+			"created_at",
+		).
+		From("idempotency_keys").
+		Where("key = ?", key).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	record := &models.IdempotencyRecord{}
+	err = tx.QueryRow(ctx, query, args...).Scan(
+		&record.Key,
+		&record.WalletID,
+		&record.RequestHash,
+		&record.OperationID,
+		&record.Balance,
+		&record.HeldBalance,
+		&record.Currency,
+		&record.WalletUpdatedAt,
+		&record.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrIdempotencyKeyNotFound
+		}
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return record, nil
+}
+
+func (ir *IdempotencyRepository) SaveIdempotencyRecord(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	record *models.IdempotencyRecord,
+) error {
+
+	const op = "storage.postgres.SaveIdempotencyRecord"
+
+	query, args, err := ir.postgres.
+		Insert("idempotency_keys").
+		Columns("key", "wallet_id", "request_hash", "operation_id", "balance", "held_balance", "currency", "wallet_updated_at").
+		Values(
+			record.Key,
+			record.WalletID,
+			record.RequestHash,
+			record.OperationID,
+			record.Balance,
+			record.HeldBalance,
+			record.Currency,
+			record.WalletUpdatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_insert", err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return transaction.HandleError(op, "insert", err)
+	}
+
+	return nil
+}
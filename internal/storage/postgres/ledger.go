@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"wallet-service/internal/domain/models"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+	"wallet-service/pkg/pgx-driver/transaction"
+
+	"github.com/google/uuid"
+)
+
+type LedgerRepository struct {
+	postgres *pgxdriver.Postgres
+	log      *slog.Logger
+}
+
+func NewLedgerRepository(log *slog.Logger, postgres *pgxdriver.Postgres) *LedgerRepository {
+	return &LedgerRepository{
+		postgres: postgres,
+		log:      log,
+	}
+}
+
+// CreateWalletAccount opens the ledger account of a wallet; it shares the wallet id.
+func (lr *LedgerRepository) CreateWalletAccount(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+	currency string,
+) error {
+
+	const op = "storage.postgres.CreateWalletAccount"
+
+	query, args, err := lr.postgres.
+		Insert("ledger_accounts").
+		Columns("id", "wallet_id", "kind", "currency").
+		Values(walletID, walletID, models.AccountWallet, currency).
+		ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_insert", err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return transaction.HandleError(op, "insert", err)
+	}
+
+	return nil
+}
+
+// EnsureSystemAccount opens a system (EXTERNAL or FX) account unless it
+// already exists.
+func (lr *LedgerRepository) EnsureSystemAccount(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	accountID uuid.UUID,
+	kind models.LedgerAccountKind,
+	currency string,
+) error {
+
+	const op = "storage.postgres.EnsureSystemAccount"
+
+	query, args, err := lr.postgres.
+		Insert("ledger_accounts").
+		Columns("id", "kind", "currency").
+		Values(accountID, kind, currency).
+		Suffix("ON CONFLICT (id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_insert", err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return transaction.HandleError(op, "insert", err)
+	}
+
+	return nil
+}
+
+// PostEntry writes a journal entry and its postings. Balancing is enforced
+// by a deferred constraint trigger when the transaction commits.
+func (lr *LedgerRepository) PostEntry(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	entry *models.JournalEntry,
+) error {
+
+	const op = "storage.postgres.PostEntry"
+
+	query, args, err := lr.postgres.
+		Insert("journal_entries").
+		Columns("id", "type", "reference_id").
+		Values(entry.ID, entry.Type, entry.ReferenceID).
+		ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_insert_entry", err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return transaction.HandleError(op, "insert_entry", err)
+	}
+
+	postings := lr.postgres.
+		Insert("postings").
+		Columns("entry_id", "account_id", "amount")
+	for _, posting := range entry.Postings {
+		postings = postings.Values(entry.ID, posting.AccountID, posting.Amount)
+	}
+
+	query, args, err = postings.ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_insert_postings", err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return transaction.HandleError(op, "insert_postings", err)
+	}
+
+	return nil
+}
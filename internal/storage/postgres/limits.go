@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+	"wallet-service/internal/domain/models"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+	"wallet-service/pkg/pgx-driver/transaction"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var limitColumns = []string{
+	"max_single_withdrawal",
+	"daily_withdrawal",
+	"monthly_withdrawal",
+	"max_balance",
+	"max_operations",
+	"operation_window_seconds",
+}
+
+// outflowOperationTypes are the operations counted against withdrawal limits.
+var outflowOperationTypes = []models.OperationType{
+	models.Withdraw,
+	models.TransferOut,
+	models.Capture,
+	models.ExchangeOut,
+}
+
+func scanLimitOverrides(row pgx.Row) (*models.LimitOverrides, error) {
+	overrides := &models.LimitOverrides{}
+	err := row.Scan(
+		&overrides.MaxSingleWithdrawal,
+		&overrides.DailyWithdrawal,
+		&overrides.MonthlyWithdrawal,
+		&overrides.MaxBalance,
+		&overrides.MaxOperations,
+		&overrides.OperationWindowSeconds,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return overrides, nil
+}
+
+type LimitRepository struct {
+	postgres *pgxdriver.Postgres
+	log      *slog.Logger
+}
+
+func NewLimitRepository(log *slog.Logger, postgres *pgxdriver.Postgres) *LimitRepository {
+	return &LimitRepository{
+		postgres: postgres,
+		log:      log,
+	}
+}
+
+// GetWalletLimits returns the overrides of a wallet, or nil when it has none.
+func (lr *LimitRepository) GetWalletLimits(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+) (*models.LimitOverrides, error) {
+
+	const op = "storage.postgres.GetWalletLimits"
+
+	query, args, err := lr.postgres.
+		Select(limitColumns...).
+		From("wallet_limits").
+		Where("wallet_id = ?", walletID).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	overrides, err := scanLimitOverrides(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return overrides, nil
+}
+
+// SetWalletLimits replaces the overrides of a wallet.
+func (lr *LimitRepository) SetWalletLimits(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+	overrides *models.LimitOverrides,
+) error {
+
+	const op = "storage.postgres.SetWalletLimits"
+
+	query, args, err := lr.postgres.
+		Insert("wallet_limits").
+		Columns(append([]string{"wallet_id"}, limitColumns...)...).
+		Values(
+			walletID,
+			overrides.MaxSingleWithdrawal,
+			overrides.DailyWithdrawal,
+			overrides.MonthlyWithdrawal,
+			overrides.MaxBalance,
+			overrides.MaxOperations,
+			overrides.OperationWindowSeconds,
+		).
+		Suffix(`ON CONFLICT (wallet_id) DO UPDATE SET
+			max_single_withdrawal = EXCLUDED.max_single_withdrawal,
+			daily_withdrawal = EXCLUDED.daily_withdrawal,
+			monthly_withdrawal = EXCLUDED.monthly_withdrawal,
+			max_balance = EXCLUDED.max_balance,
+			max_operations = EXCLUDED.max_operations,
+			operation_window_seconds = EXCLUDED.operation_window_seconds,
+			updated_at = now()`).
+		ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_upsert", err)
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return transaction.HandleError(op, "upsert", err)
+	}
+
+	return nil
+}
+
+// GetLimitUsage sums what a wallet has withdrawn since dayStart and
+// monthStart, and counts its operations since windowStart.
+func (lr *LimitRepository) GetLimitUsage(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+	dayStart time.Time,
+	monthStart time.Time,
+	windowStart time.Time,
+) (*models.LimitUsage, error) {
+
+	const op = "storage.postgres.GetLimitUsage"
+
+	outflow, outflowArgs, err := squirrel.Eq{"type": outflowOperationTypes}.ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_filter", err)
+	}
+
+	since := monthStart
+	if windowStart.Before(since) {
+		since = windowStart
+	}
+
+	query, args, err := lr.postgres.
+		Select().
+		Column(squirrel.Expr(
+			"COALESCE(SUM(amount) FILTER (WHERE "+outflow+" AND created_at >= ?), 0)::bigint",
+			append(slices.Clone(outflowArgs), dayStart)...,
+		)).
+		Column(squirrel.Expr(
+			"COALESCE(SUM(amount) FILTER (WHERE "+outflow+" AND created_at >= ?), 0)::bigint",
+			append(slices.Clone(outflowArgs), monthStart)...,
+		)).
+		Column(squirrel.Expr("COUNT(*) FILTER (WHERE created_at >= ?)", windowStart)).
+		From("operations").
+		Where(squirrel.Eq{"wallet_id": walletID}).
+		Where(squirrel.GtOrEq{"created_at": since}).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	usage := &models.LimitUsage{}
+	err = tx.QueryRow(ctx, query, args...).Scan(
+		&usage.WithdrawnToday,
+		&usage.WithdrawnThisMonth,
+		&usage.OperationsInWindow,
+	)
+	if err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return usage, nil
+}
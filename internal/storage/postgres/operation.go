@@ -2,13 +2,53 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"time"
 	"wallet-service/internal/domain/models"
+	"wallet-service/internal/storage"
 	pgxdriver "wallet-service/pkg/pgx-driver"
 	"wallet-service/pkg/pgx-driver/transaction"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
-// TODO - methods: GetOperationsByWallet(ctx, walletID uuid.UUID, limit, offset int) ([]*Operation, error)(optional)
+// operationColumns is the column list every operation query selects, in the
+// order expected by scanOperation.
+var operationColumns = []string{
+	"id", "wallet_id", "type", "amount", "balance_after", "transfer_id", "hold_id", "trim_scale(fx_rate)::text",
+	"reversed_operation_id", "reversed_amount", "reason", "sequence", "created_at",
+}
+
+func scanOperation(row pgx.Row) (*models.Operation, error) {
+	operation := &models.Operation{}
+	err := row.Scan(
+		&operation.ID,
+		&operation.WalletID,
+		&operation.Type,
+		&operation.Amount,
+		&operation.BalanceAfter,
+		&operation.TransferID,
+		&operation.HoldID,
+		&operation.FXRate,
+		&operation.ReversedOperationID,
+		&operation.ReversedAmount,
+		&operation.Reason,
+		&operation.Sequence,
+		&operation.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return operation, nil
+}
+
+// OperationsChannel is the NOTIFY channel every committed operation is
+// announced on; the payload is the id of the wallet it belongs to.
+const OperationsChannel = "wallet_operations"
 
 type OperationRepository struct {
 	postgres *pgxdriver.Postgres
@@ -31,8 +71,18 @@ func (or *OperationRepository) CreateOperation(
 	const op = "storage.postgres.CreateOperation"
 
 	query, args, err := or.postgres.Insert("operations").
-		Columns("id", "wallet_id", "type", "amount").
-		Values(operation.ID, operation.WalletID, operation.Type, operation.Amount).
+		Columns("id", "wallet_id", "type", "amount", "transfer_id", "hold_id", "fx_rate", "reversed_operation_id", "reason").
+		Values(
+			operation.ID,
+			operation.WalletID,
+			operation.Type,
+			operation.Amount,
+			operation.TransferID,
+			operation.HoldID,
+			squirrel.Expr("?::text::numeric", operation.FXRate),
+			operation.ReversedOperationID,
+			operation.Reason,
+		).
 		ToSql()
 
 	if err != nil {
@@ -44,5 +94,300 @@ func (or *OperationRepository) CreateOperation(
 		return transaction.HandleError(op, "insert", err)
 	}
 
+	err = pgxdriver.Notify(ctx, tx, OperationsChannel, operation.WalletID.String())
+	if err != nil {
+		return transaction.HandleError(op, "notify", err)
+	}
+
+	return nil
+}
+
+// CreateOperations records several plain operations (no transfer, hold, FX
+// rate or reversal) with one multi-row insert.
+func (or *OperationRepository) CreateOperations(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	operations []*models.Operation,
+) error {
+
+	const op = "storage.postgres.CreateOperations"
+
+	if len(operations) == 0 {
+		return nil
+	}
+
+	insert := or.postgres.Insert("operations").
+		Columns("id", "wallet_id", "type", "amount")
+	for _, operation := range operations {
+		insert = insert.Values(operation.ID, operation.WalletID, operation.Type, operation.Amount)
+	}
+
+	query, args, err := insert.ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "insert", err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return transaction.HandleError(op, "insert", err)
+	}
+
+	notified := make(map[uuid.UUID]bool, 1)
+	for _, operation := range operations {
+		if notified[operation.WalletID] {
+			continue
+		}
+		notified[operation.WalletID] = true
+
+		err = pgxdriver.Notify(ctx, tx, OperationsChannel, operation.WalletID.String())
+		if err != nil {
+			return transaction.HandleError(op, "notify", err)
+		}
+	}
+
+	return nil
+}
+
+// balancesAtQuery returns the opening balance plus the deltas of the
+// operations created at or before each moment. Unlike the balance_after of
+// the last of them, the sum does not depend on the order concurrent
+// operations were sequenced in.
+const balancesAtQuery = `
+SELECT t.at,
+       (w.opening_balance + COALESCE((
+           SELECT SUM(o.delta)
+           FROM operations o
+           WHERE o.wallet_id = w.id AND o.created_at <= t.at
+       ), 0))::BIGINT
+FROM wallets w
+CROSS JOIN unnest($2::timestamptz[]) AS t(at)
+WHERE w.id = $1
+ORDER BY t.at`
+
+// GetBalancesAt returns the balance of the wallet at each of the given
+// moments, in chronological order. Moments before the wallet was opened
+// report its opening balance.
+func (or *OperationRepository) GetBalancesAt(
+	ctx context.Context,
+	walletID uuid.UUID,
+	at []time.Time,
+) ([]models.BalancePoint, error) {
+
+	const op = "storage.postgres.GetBalancesAt"
+
+	rows, err := or.postgres.Pool.Query(ctx, balancesAtQuery, walletID, at)
+	if err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+	defer rows.Close()
+
+	points := make([]models.BalancePoint, 0, len(at))
+	for rows.Next() {
+		var point models.BalancePoint
+		if err := rows.Scan(&point.At, &point.Balance); err != nil {
+			return nil, transaction.HandleError(op, "scan", err)
+		}
+		points = append(points, point)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return points, nil
+}
+
+// GetOperationsByWallet returns up to limit operations of the wallet, newest
+// first, strictly after the given keyset cursor (nil for the first page).
+func (or *OperationRepository) GetOperationsByWallet(
+	ctx context.Context,
+	walletID uuid.UUID,
+	filter models.OperationFilter,
+	after *models.OperationCursor,
+	limit int,
+) ([]*models.Operation, error) {
+
+	const op = "storage.postgres.GetOperationsByWallet"
+
+	where := squirrel.And{
+		squirrel.Eq{"wallet_id": walletID},
+	}
+
+	if len(filter.Types) > 0 {
+		where = append(where, squirrel.Eq{"type": filter.Types})
+	}
+	if filter.MinAmount != nil {
+		where = append(where, squirrel.GtOrEq{"amount": *filter.MinAmount})
+	}
+	if filter.MaxAmount != nil {
+		where = append(where, squirrel.LtOrEq{"amount": *filter.MaxAmount})
+	}
+	if filter.From != nil {
+		where = append(where, squirrel.GtOrEq{"created_at": *filter.From})
+	}
+	if filter.To != nil {
+		where = append(where, squirrel.Lt{"created_at": *filter.To})
+	}
+	if after != nil {
+		where = append(where, squirrel.Expr("(created_at, id) < (?, ?)", after.CreatedAt, after.ID))
+	}
+
+	query, args, err := or.postgres.
+		Select(operationColumns...).
+		From("operations").
+		Where(where).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	rows, err := or.postgres.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+	defer rows.Close()
+
+	operations := make([]*models.Operation, 0, limit)
+	for rows.Next() {
+		operation, err := scanOperation(rows)
+		if err != nil {
+			return nil, transaction.HandleError(op, "scan", err)
+		}
+
+		operations = append(operations, operation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return operations, nil
+}
+
+// SequenceOperations numbers the committed operations of the wallet that
+// have no sequence yet (see sequence_wallet_operations) and returns the last
+// sequence of the wallet.
+func (or *OperationRepository) SequenceOperations(ctx context.Context, walletID uuid.UUID) (int64, error) {
+	const op = "storage.postgres.SequenceOperations"
+
+	var last *int64
+	err := or.postgres.Pool.QueryRow(ctx, "SELECT sequence_wallet_operations($1)", walletID).Scan(&last)
+	if err != nil {
+		return 0, transaction.HandleError(op, "sequence", err)
+	}
+
+	if last == nil {
+		return 0, storage.ErrWalletNotFound
+	}
+
+	return *last, nil
+}
+
+// GetOperationsSince returns up to limit operations of the wallet with a
+// sequence greater than after, in sequence order.
+func (or *OperationRepository) GetOperationsSince(
+	ctx context.Context,
+	walletID uuid.UUID,
+	after int64,
+	limit int,
+) ([]*models.Operation, error) {
+
+	const op = "storage.postgres.GetOperationsSince"
+
+	query, args, err := or.postgres.
+		Select(operationColumns...).
+		From("operations").
+		Where("wallet_id = ? AND sequence > ?", walletID, after).
+		OrderBy("sequence").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	rows, err := or.postgres.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+	defer rows.Close()
+
+	operations := make([]*models.Operation, 0, limit)
+	for rows.Next() {
+		operation, err := scanOperation(rows)
+		if err != nil {
+			return nil, transaction.HandleError(op, "scan", err)
+		}
+
+		operations = append(operations, operation)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return operations, nil
+}
+
+// GetOperationForUpdate loads an operation and locks its row, so concurrent
+// reversals of the same operation are serialized.
+func (or *OperationRepository) GetOperationForUpdate(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	id uuid.UUID,
+) (*models.Operation, error) {
+
+	const op = "storage.postgres.GetOperationForUpdate"
+
+	query, args, err := or.postgres.
+		Select(operationColumns...).
+		From("operations").
+		Where("id = ?", id).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	operation, err := scanOperation(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrOperationNotFound
+		}
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return operation, nil
+}
+
+// AddReversedAmount records that amount more of the operation has been reversed.
+func (or *OperationRepository) AddReversedAmount(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	id uuid.UUID,
+	amount int64,
+) error {
+
+	const op = "storage.postgres.AddReversedAmount"
+
+	query, args, err := or.postgres.
+		Update("operations").
+		Set("reversed_amount", squirrel.Expr("reversed_amount + ?", amount)).
+		Where("id = ?", id).
+		ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_update", err)
+	}
+
+	tag, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return transaction.HandleError(op, "update", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return storage.ErrOperationNotFound
+	}
+
 	return nil
 }
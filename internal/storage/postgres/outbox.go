@@ -0,0 +1,213 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"time"
+	"wallet-service/internal/domain/models"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+	"wallet-service/pkg/pgx-driver/transaction"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var outboxColumns = []string{
+	"id",
+	"aggregate_id",
+	"event_type",
+	"payload",
+	"status",
+	"attempts",
+	"next_attempt_at",
+	"last_error",
+	"created_at",
+	"published_at",
+}
+
+func scanOutboxEvent(row pgx.Row) (*models.OutboxEvent, error) {
+	event := &models.OutboxEvent{}
+	err := row.Scan(
+		&event.ID,
+		&event.AggregateID,
+		&event.EventType,
+		&event.Payload,
+		&event.Status,
+		&event.Attempts,
+		&event.NextAttemptAt,
+		&event.LastError,
+		&event.CreatedAt,
+		&event.PublishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+type OutboxRepository struct {
+	postgres *pgxdriver.Postgres
+	log      *slog.Logger
+}
+
+func NewOutboxRepository(log *slog.Logger, postgres *pgxdriver.Postgres) *OutboxRepository {
+	return &OutboxRepository{
+		postgres: postgres,
+		log:      log,
+	}
+}
+
+func (ob *OutboxRepository) EnqueueEvent(ctx context.Context, tx pgxdriver.QueryExecuter, event *models.OutboxEvent) error {
+	const op = "storage.postgres.EnqueueEvent"
+
+	query, args, err := ob.postgres.
+		Insert("outbox").
+		Columns("id", "aggregate_id", "event_type", "payload").
+		Values(event.ID, event.AggregateID, event.EventType, string(event.Payload)).
+		ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_insert", err)
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return transaction.HandleError(op, "insert", err)
+	}
+
+	return nil
+}
+
+// GetPendingEventsForUpdate locks up to limit pending events that are due,
+// oldest first, skipping rows already claimed by another relay.
+func (ob *OutboxRepository) GetPendingEventsForUpdate(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	now time.Time,
+	limit int,
+) ([]*models.OutboxEvent, error) {
+
+	const op = "storage.postgres.GetPendingEventsForUpdate"
+
+	query, args, err := ob.postgres.
+		Select(outboxColumns...).
+		From("outbox").
+		Where(squirrel.And{
+			squirrel.Eq{"status": models.OutboxPending},
+			squirrel.LtOrEq{"next_attempt_at": now},
+		}).
+		OrderBy("next_attempt_at", "created_at").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+	defer rows.Close()
+
+	var events []*models.OutboxEvent
+	for rows.Next() {
+		event, err := scanOutboxEvent(rows)
+		if err != nil {
+			return nil, transaction.HandleError(op, "scan", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return events, nil
+}
+
+func (ob *OutboxRepository) MarkEventPublished(ctx context.Context, tx pgxdriver.QueryExecuter, id uuid.UUID) error {
+	const op = "storage.postgres.MarkEventPublished"
+
+	query, args, err := ob.postgres.
+		Update("outbox").
+		Set("status", models.OutboxPublished).
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("last_error", "").
+		Set("published_at", squirrel.Expr("now()")).
+		Where("id = ?", id).
+		ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_update", err)
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return transaction.HandleError(op, "update", err)
+	}
+
+	return nil
+}
+
+// MarkEventFailed records a failed delivery attempt. The event is retried at
+// nextAttemptAt while status stays PENDING, or given up on with OutboxDead.
+func (ob *OutboxRepository) MarkEventFailed(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	id uuid.UUID,
+	status models.OutboxStatus,
+	nextAttemptAt time.Time,
+	lastError string,
+) error {
+
+	const op = "storage.postgres.MarkEventFailed"
+
+	query, args, err := ob.postgres.
+		Update("outbox").
+		Set("status", status).
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("next_attempt_at", nextAttemptAt).
+		Set("last_error", lastError).
+		Where("id = ?", id).
+		ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_update", err)
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return transaction.HandleError(op, "update", err)
+	}
+
+	return nil
+}
+
+// deleteEventsQuery removes a bounded batch, so a large backlog is purged in
+// short transactions.
+const deleteEventsQuery = `
+DELETE FROM outbox
+WHERE id IN (
+    SELECT id
+    FROM outbox
+    WHERE status = $1
+      AND created_at < $2
+    LIMIT $3
+)`
+
+// DeleteEvents deletes up to limit events in status created before before and
+// returns how many it deleted.
+func (ob *OutboxRepository) DeleteEvents(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	status models.OutboxStatus,
+	before time.Time,
+	limit int,
+) (int64, error) {
+
+	const op = "storage.postgres.DeleteEvents"
+
+	tag, err := tx.Exec(ctx, deleteEventsQuery, status, before, limit)
+	if err != nil {
+		return 0, transaction.HandleError(op, "delete", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
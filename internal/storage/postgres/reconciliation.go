@@ -0,0 +1,346 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+	"wallet-service/pkg/pgx-driver/transaction"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var reconciliationRunColumns = []string{
+	"id",
+	"mode",
+	"since",
+	"status",
+	"checkpoint",
+	"wallets_checked",
+	"mismatches",
+	"last_error",
+	"started_at",
+	"finished_at",
+}
+
+func scanReconciliationRun(row pgx.Row) (*models.ReconciliationRun, error) {
+	run := &models.ReconciliationRun{}
+	err := row.Scan(
+		&run.ID,
+		&run.Mode,
+		&run.Since,
+		&run.Status,
+		&run.Checkpoint,
+		&run.WalletsChecked,
+		&run.Mismatches,
+		&run.LastError,
+		&run.StartedAt,
+		&run.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+// Wallets of a full run are paged by id. An incremental run pages through
+// the wallets with an operation created since $3 instead.
+const (
+	allWalletsPage = `
+    SELECT w.id
+    FROM wallets w
+    WHERE w.id > $1
+    ORDER BY w.id
+    LIMIT $2`
+
+	changedWalletsPage = `
+    SELECT DISTINCT o.wallet_id AS id
+    FROM operations o
+    WHERE o.created_at >= $3 AND o.wallet_id > $1
+    ORDER BY o.wallet_id
+    LIMIT $2`
+)
+
+// reconcileQuery recomputes the balance of each wallet of a page as its
+// opening balance plus the deltas of its operations, carries the
+// balance_after chain forward over the operations not sequenced yet, and
+// sums the postings of its ledger account next to it. It is a single statement, so
+// all of them come from the same snapshot.
+const reconcileQuery = `
+WITH page AS (%s
+)
+SELECT w.id,
+       w.balance + COALESCE((
+           SELECT SUM(b.balance)
+           FROM wallet_balance_buckets b
+           WHERE b.wallet_id = w.id
+       ), 0)::BIGINT,
+       (w.opening_balance + h.delta)::BIGINT,
+       l.balance::BIGINT,
+       CASE
+           WHEN h.operations > 0
+               THEN (COALESCE(h.last_balance_after, w.opening_balance) + h.unsequenced_delta)::BIGINT
+       END,
+       h.operations
+FROM page p
+JOIN wallets w ON w.id = p.id
+CROSS JOIN LATERAL (
+    SELECT COALESCE(SUM(o.delta), 0) AS delta,
+           (array_agg(o.balance_after ORDER BY o.sequence DESC) FILTER (WHERE o.sequence IS NOT NULL))[1]
+               AS last_balance_after,
+           COALESCE(SUM(o.delta) FILTER (WHERE o.sequence IS NULL), 0) AS unsequenced_delta,
+           COUNT(*) AS operations
+    FROM operations o
+    WHERE o.wallet_id = w.id
+) h
+LEFT JOIN LATERAL (
+    SELECT COALESCE(SUM(p.amount), 0) AS balance
+    FROM ledger_accounts a
+    LEFT JOIN postings p ON p.account_id = a.id
+    WHERE a.id = w.id
+    GROUP BY a.id
+) l ON true
+ORDER BY w.id`
+
+type ReconciliationRepository struct {
+	postgres *pgxdriver.Postgres
+	log      *slog.Logger
+}
+
+func NewReconciliationRepository(log *slog.Logger, postgres *pgxdriver.Postgres) *ReconciliationRepository {
+	return &ReconciliationRepository{
+		postgres: postgres,
+		log:      log,
+	}
+}
+
+func (rr *ReconciliationRepository) CreateRun(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	run *models.ReconciliationRun,
+) (*models.ReconciliationRun, error) {
+
+	const op = "storage.postgres.CreateRun"
+
+	query, args, err := rr.postgres.
+		Insert("reconciliation_runs").
+		Columns("id", "mode", "since").
+		Values(run.ID, run.Mode, run.Since).
+		Suffix("RETURNING " + strings.Join(reconciliationRunColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_insert", err)
+	}
+
+	created, err := scanReconciliationRun(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, transaction.HandleError(op, "insert", err)
+	}
+
+	return created, nil
+}
+
+// GetRunningRun returns the run in progress, if any.
+func (rr *ReconciliationRepository) GetRunningRun(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+) (*models.ReconciliationRun, error) {
+
+	const op = "storage.postgres.GetRunningRun"
+
+	query, args, err := rr.postgres.
+		Select(reconciliationRunColumns...).
+		From("reconciliation_runs").
+		Where("status = ?", models.ReconciliationRunning).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	return rr.getRun(ctx, tx, op, query, args)
+}
+
+// GetLastCompletedRun returns the most recently started completed run.
+func (rr *ReconciliationRepository) GetLastCompletedRun(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+) (*models.ReconciliationRun, error) {
+
+	const op = "storage.postgres.GetLastCompletedRun"
+
+	query, args, err := rr.postgres.
+		Select(reconciliationRunColumns...).
+		From("reconciliation_runs").
+		Where("status = ?", models.ReconciliationCompleted).
+		OrderBy("started_at DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	return rr.getRun(ctx, tx, op, query, args)
+}
+
+// GetRunForUpdate locks a run, so processes resuming the same run take its
+// pages one after another.
+func (rr *ReconciliationRepository) GetRunForUpdate(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	id uuid.UUID,
+) (*models.ReconciliationRun, error) {
+
+	const op = "storage.postgres.GetRunForUpdate"
+
+	query, args, err := rr.postgres.
+		Select(reconciliationRunColumns...).
+		From("reconciliation_runs").
+		Where("id = ?", id).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	return rr.getRun(ctx, tx, op, query, args)
+}
+
+func (rr *ReconciliationRepository) getRun(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	op string,
+	query string,
+	args []any,
+) (*models.ReconciliationRun, error) {
+
+	run, err := scanReconciliationRun(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrReconciliationRunNotFound
+		}
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return run, nil
+}
+
+func (rr *ReconciliationRepository) UpdateRun(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	run *models.ReconciliationRun,
+) error {
+
+	const op = "storage.postgres.UpdateRun"
+
+	query, args, err := rr.postgres.
+		Update("reconciliation_runs").
+		Set("status", run.Status).
+		Set("checkpoint", run.Checkpoint).
+		Set("wallets_checked", run.WalletsChecked).
+		Set("mismatches", run.Mismatches).
+		Set("last_error", run.LastError).
+		Set("finished_at", run.FinishedAt).
+		Where("id = ?", run.ID).
+		ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_update", err)
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return transaction.HandleError(op, "update", err)
+	}
+
+	return nil
+}
+
+// ReconcileWallets reconciles up to limit wallets with ids after after, in
+// id order. With a non-nil since only wallets with operations created since
+// then are taken.
+func (rr *ReconciliationRepository) ReconcileWallets(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	after uuid.UUID,
+	since *time.Time,
+	limit int,
+) ([]*models.WalletReconciliation, error) {
+
+	const op = "storage.postgres.ReconcileWallets"
+
+	query := fmt.Sprintf(reconcileQuery, allWalletsPage)
+	args := []any{after, limit}
+	if since != nil {
+		query = fmt.Sprintf(reconcileQuery, changedWalletsPage)
+		args = append(args, *since)
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+	defer rows.Close()
+
+	var results []*models.WalletReconciliation
+	for rows.Next() {
+		result := &models.WalletReconciliation{}
+		err := rows.Scan(
+			&result.WalletID,
+			&result.Balance,
+			&result.ComputedBalance,
+			&result.LedgerBalance,
+			&result.LastBalanceAfter,
+			&result.Operations,
+		)
+		if err != nil {
+			return nil, transaction.HandleError(op, "scan", err)
+		}
+		results = append(results, result)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return results, nil
+}
+
+// SaveMismatches records the wallets of a run that failed reconciliation.
+func (rr *ReconciliationRepository) SaveMismatches(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	runID uuid.UUID,
+	mismatches []*models.WalletReconciliation,
+) error {
+
+	const op = "storage.postgres.SaveMismatches"
+
+	if len(mismatches) == 0 {
+		return nil
+	}
+
+	insert := rr.postgres.
+		Insert("reconciliation_mismatches").
+		Columns("run_id", "wallet_id", "balance", "computed_balance", "ledger_balance", "last_balance_after", "operations")
+	for _, m := range mismatches {
+		insert = insert.Values(runID, m.WalletID, m.Balance, m.ComputedBalance, m.LedgerBalance, m.LastBalanceAfter, m.Operations)
+	}
+
+	query, args, err := insert.
+		Suffix("ON CONFLICT (run_id, wallet_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_insert", err)
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return transaction.HandleError(op, "insert", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+	"wallet-service/pkg/pgx-driver/transaction"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// undefinedTable is raised when schema_migrations has not been created yet.
+const undefinedTable = "42P01"
+
+type SchemaRepository struct {
+	postgres *pgxdriver.Postgres
+	log      *slog.Logger
+}
+
+func NewSchemaRepository(log *slog.Logger, postgres *pgxdriver.Postgres) *SchemaRepository {
+	return &SchemaRepository{
+		postgres: postgres,
+		log:      log,
+	}
+}
+
+// SchemaVersion returns the last migration applied to the database, as
+// recorded by golang-migrate, and whether it failed halfway.
+func (sr *SchemaRepository) SchemaVersion(ctx context.Context) (uint, bool, error) {
+	const op = "storage.postgres.SchemaVersion"
+
+	query, args, err := sr.postgres.
+		Select("version", "dirty").
+		From("schema_migrations").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return 0, false, transaction.HandleError(op, "build_select", err)
+	}
+
+	var (
+		version int64
+		dirty   bool
+	)
+	err = sr.postgres.Pool.QueryRow(ctx, query, args...).Scan(&version, &dirty)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == undefinedTable) {
+			return 0, false, storage.ErrSchemaNotMigrated
+		}
+		return 0, false, transaction.HandleError(op, "select", err)
+	}
+
+	return uint(version), dirty, nil
+}
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+	"wallet-service/pkg/pgx-driver/transaction"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	statementCursor    = "statement_operations"
+	statementFetchSize = 500
+)
+
+// A statement is read from a single snapshot, so its opening balance, lines
+// and closing balance agree even while operations keep committing.
+const statementSnapshot = "SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY"
+
+const openingBalanceQuery = `
+SELECT (w.opening_balance + COALESCE((
+           SELECT SUM(o.delta)
+           FROM operations o
+           WHERE o.wallet_id = w.id AND o.created_at < $2
+       ), 0))::BIGINT
+FROM wallets w
+WHERE w.id = $1`
+
+// statementColumns are the operation columns of a statement line, with the
+// running balance of the statement in place of balance_after: it follows
+// the order of the lines, which the sequence may not.
+var statementColumns = func() []string {
+	columns := slices.Clone(operationColumns)
+	columns[slices.Index(columns, "balance_after")] = "(? + SUM(delta) OVER (ORDER BY created_at, id))::BIGINT"
+	return columns
+}()
+
+// OpenStatement pins tx to a read-only snapshot and returns the balance of
+// the wallet right before from. It must be the first statement of tx.
+func (or *OperationRepository) OpenStatement(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+	from time.Time,
+) (int64, error) {
+
+	const op = "storage.postgres.OpenStatement"
+
+	if _, err := tx.Exec(ctx, statementSnapshot); err != nil {
+		return 0, transaction.HandleError(op, "snapshot", err)
+	}
+
+	var opening int64
+	err := tx.QueryRow(ctx, openingBalanceQuery, walletID, from).Scan(&opening)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, storage.ErrWalletNotFound
+		}
+		return 0, transaction.HandleError(op, "select", err)
+	}
+
+	return opening, nil
+}
+
+// WalkStatement calls visit with every operation of the wallet created in
+// [from, to), oldest first, each carrying the running balance from opening
+// in BalanceAfter. The operations are fetched through a server-side cursor
+// in chunks, so a long statement is never held in memory at once.
+func (or *OperationRepository) WalkStatement(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+	from time.Time,
+	to time.Time,
+	opening int64,
+	visit func(operation *models.Operation) error,
+) error {
+
+	const op = "storage.postgres.WalkStatement"
+
+	query, args, err := or.postgres.
+		Select().
+		Column(strings.Join(statementColumns, ", "), opening).
+		From("operations").
+		Where("wallet_id = ? AND created_at >= ? AND created_at < ?", walletID, from, to).
+		OrderBy("created_at", "id").
+		ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_select", err)
+	}
+
+	_, err = tx.Exec(ctx, "DECLARE "+statementCursor+" NO SCROLL CURSOR FOR "+query, args...)
+	if err != nil {
+		return transaction.HandleError(op, "declare", err)
+	}
+
+	fetch := fmt.Sprintf("FETCH %d FROM %s", statementFetchSize, statementCursor)
+	for {
+		fetched, err := fetchStatement(ctx, tx, fetch, visit)
+		if err != nil {
+			return transaction.HandleError(op, "fetch", err)
+		}
+		if fetched < statementFetchSize {
+			break
+		}
+	}
+
+	if _, err := tx.Exec(ctx, "CLOSE "+statementCursor); err != nil {
+		return transaction.HandleError(op, "close", err)
+	}
+
+	return nil
+}
+
+func fetchStatement(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	fetch string,
+	visit func(operation *models.Operation) error,
+) (int, error) {
+
+	rows, err := tx.Query(ctx, fetch)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	fetched := 0
+	for rows.Next() {
+		operation, err := scanOperation(rows)
+		if err != nil {
+			return fetched, err
+		}
+		fetched++
+
+		if err := visit(operation); err != nil {
+			return fetched, err
+		}
+	}
+
+	return fetched, rows.Err()
+}
@@ -1,10 +1,12 @@
 package postgres
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"log/slog"
-	"time"
+	"slices"
+	"strings"
 	"wallet-service/internal/domain/models"
 	"wallet-service/internal/storage"
 	pgxdriver "wallet-service/pkg/pgx-driver"
@@ -27,35 +29,78 @@ func NewWalletRepository(log *slog.Logger, postgres *pgxdriver.Postgres) *Wallet
 	}
 }
 
-func (wr *WalletRepository) CreateWallet(ctx context.Context, id uuid.UUID, balance int64) (*models.Wallet, error) {
+// walletBalance is the balance of a wallet: a sharded wallet adds the sum of
+// its buckets to the wallets row.
+const walletBalance = `CASE WHEN balance_buckets = 0 THEN balance ELSE balance + (
+	SELECT COALESCE(SUM(b.balance), 0) FROM wallet_balance_buckets b WHERE b.wallet_id = wallets.id
+) END`
+
+// walletColumns is the column list every wallet query selects or returns,
+// in the order expected by scanWallet.
+var walletColumns = []string{
+	"id", walletBalance, "held_balance", "currency", "status", "balance_buckets", "created_at", "updated_at",
+}
+
+func walletReturning() string {
+	return "RETURNING " + strings.Join(walletColumns, ", ")
+}
+
+func scanWallet(row pgx.Row) (*models.Wallet, error) {
+	wallet := &models.Wallet{}
+	err := row.Scan(
+		&wallet.ID,
+		&wallet.Balance,
+		&wallet.HeldBalance,
+		&wallet.Currency,
+		&wallet.Status,
+		&wallet.BalanceBuckets,
+		&wallet.CreatedAt,
+		&wallet.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet.AvailableBalance = wallet.Balance - wallet.HeldBalance
+
+	return wallet, nil
+}
+
+// CreateWallet inserts a wallet with a zero balance and returns it with
+// the opening balance, which the opening journal entry brings onto it. The
+// entry records no operation, so the sequenced balance starts there too.
+func (wr *WalletRepository) CreateWallet(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	id uuid.UUID,
+	balance int64,
+	currency string,
+) (*models.Wallet, error) {
 	const op = "storage.postgres.CreateWallet"
 
 	query, args, err := wr.postgres.
 		Insert("wallets").
-		Columns("id", "balance").
-		Values(id, balance).
-		Suffix("RETURNING id, balance, created_at, updated_at").
+		Columns("id", "opening_balance", "sequenced_balance", "currency").
+		Values(id, balance, balance, currency).
+		Suffix(walletReturning()).
 		ToSql()
 	if err != nil {
 		return nil, transaction.HandleError(op, "insert", err)
 	}
 
-	wallet := &models.Wallet{}
-	err = wr.postgres.Pool.QueryRow(ctx, query, args...).Scan(
-		&wallet.ID, &wallet.Balance, &wallet.CreatedAt, &wallet.UpdatedAt,
-	)
+	wallet, err := scanWallet(tx.QueryRow(ctx, query, args...))
 	if err != nil {
 		return nil, transaction.HandleError(op, "insert", err)
 	}
 
-	return wallet, nil
+	return projectBalance(wallet, balance), nil
 }
 
 func (wr *WalletRepository) GetWallet(ctx context.Context, id uuid.UUID) (*models.Wallet, error) {
 	const op = "storage.postgres.GetWallet"
 
 	query, args, err := wr.postgres.
-		Select("id", "balance", "created_at", "updated_at").
+		Select(walletColumns...).
 		From("wallets").
 		Where("id = ?", id).
 		ToSql()
@@ -63,10 +108,7 @@ func (wr *WalletRepository) GetWallet(ctx context.Context, id uuid.UUID) (*model
 		return nil, transaction.HandleError(op, "select", err)
 	}
 
-	wallet := &models.Wallet{}
-	err = wr.postgres.Pool.QueryRow(ctx, query, args...).Scan(
-		&wallet.ID, &wallet.Balance, &wallet.CreatedAt, &wallet.UpdatedAt,
-	)
+	wallet, err := scanWallet(wr.postgres.Pool.QueryRow(ctx, query, args...))
 	if err != nil {
 		wr.log.Debug(err.Error())
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -78,112 +120,404 @@ func (wr *WalletRepository) GetWallet(ctx context.Context, id uuid.UUID) (*model
 	return wallet, nil
 }
 
+// projectBalance returns wallet as the posting of an operation moving its
+// balance by delta leaves it. wallets.balance is a projection of the ledger,
+// moved only by the posting trigger, so balance updates lock and check the
+// row and leave the balance to the journal entry posted next.
+func projectBalance(wallet *models.Wallet, delta int64) *models.Wallet {
+	wallet.Balance += delta
+	wallet.AvailableBalance += delta
+
+	return wallet
+}
+
+// lockForPosting locks the row of an active wallet that also satisfies
+// cond, and returns it projected by delta. A wallet that does not qualify
+// is reported by rejectedUpdateError.
+func (wr *WalletRepository) lockForPosting(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	op string,
+	walletID uuid.UUID,
+	delta int64,
+	cond squirrel.Sqlizer,
+) (*models.Wallet, error) {
+
+	query, args, err := wr.postgres.
+		Select(walletColumns...).
+		From("wallets").
+		Where(squirrel.And{
+			squirrel.Expr("id = ?", walletID),
+			squirrel.Eq{"status": models.WalletActive},
+			cond,
+		}).
+		Suffix("FOR NO KEY UPDATE").
+		ToSql()
+
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	wallet, err := scanWallet(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		wr.log.Debug(op, slog.String("error", err.Error()))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, wr.rejectedUpdateError(ctx, tx, op, walletID)
+		}
+
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return projectBalance(wallet, delta), nil
+}
+
+// IncreaseBalance prepares a credit of the wallet if it is active; frozen
+// and closed wallets are refused with ErrWalletFrozen and ErrWalletClosed.
+// Sharded wallets are refused with ErrWalletSharded: their deposits go to a
+// bucket through IncreaseBucketBalance.
 func (wr *WalletRepository) IncreaseBalance(
 	ctx context.Context,
 	tx pgxdriver.QueryExecuter,
 	walletID uuid.UUID,
 	amount int64,
-) (int64, time.Time, error) {
+) (*models.Wallet, error) {
 
 	const op = "storage.postgres.IncreaseBalance"
 
+	return wr.lockForPosting(ctx, tx, op, walletID, amount, squirrel.Eq{"balance_buckets": 0})
+}
+
+// DecreaseBalance prepares a debit of an active wallet only if its available
+// balance (balance minus funds reserved by active holds) covers the amount.
+// For a sharded wallet only the wallets row counts: when it falls short the
+// debit is refused with ErrWalletSharded, as the buckets may still cover it.
+func (wr *WalletRepository) DecreaseBalance(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+	amount int64,
+) (*models.Wallet, error) {
+
+	const op = "storage.postgres.DecreaseBalance"
+
+	return wr.lockForPosting(ctx, tx, op, walletID, -amount, squirrel.Expr("balance - held_balance >= ?", amount))
+}
+
+// HoldBalance reserves amount of the wallet's available balance. Like
+// DecreaseBalance it only counts the wallets row of a sharded wallet.
+func (wr *WalletRepository) HoldBalance(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+	amount int64,
+) (*models.Wallet, error) {
+
+	const op = "storage.postgres.HoldBalance"
+
 	query, args, err := wr.postgres.
 		Update("wallets").
-		Set("balance", squirrel.Expr("balance + ?", amount)).
+		Set("held_balance", squirrel.Expr("held_balance + ?", amount)).
+		Where(squirrel.And{
+			squirrel.Expr("id = ?", walletID),
+			squirrel.Eq{"status": models.WalletActive},
+			squirrel.Expr("balance - held_balance >= ?", amount),
+		}).
+		Suffix(walletReturning()).
+		ToSql()
+
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_update", err)
+	}
+
+	wallet, err := scanWallet(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, wr.rejectedUpdateError(ctx, tx, op, walletID)
+		}
+
+		return nil, transaction.HandleError(op, "update", err)
+	}
+
+	return wallet, nil
+}
+
+// SettleHeldBalance releases held from the reserved funds without
+// debiting the balance. It serves voids and expiries, which must release
+// the funds whatever the wallet status.
+func (wr *WalletRepository) SettleHeldBalance(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+	held int64,
+) (*models.Wallet, error) {
+
+	const op = "storage.postgres.SettleHeldBalance"
+
+	query, args, err := wr.postgres.
+		Update("wallets").
+		Set("held_balance", squirrel.Expr("held_balance - ?", held)).
 		Where(squirrel.Expr("id = ?", walletID)).
-		Suffix("RETURNING balance, updated_at").
+		Suffix(walletReturning()).
 		ToSql()
 
 	if err != nil {
-		return 0, time.Time{}, transaction.HandleError(op, "build_update", err)
+		return nil, transaction.HandleError(op, "build_update", err)
 	}
 
-	var newBalance int64
-	var updatedAt time.Time
-	err = tx.QueryRow(ctx, query, args...).Scan(&newBalance, &updatedAt)
+	wallet, err := scanWallet(tx.QueryRow(ctx, query, args...))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			wr.log.Error("wallet not found")
-			return 0, time.Time{}, storage.ErrWalletNotFound
+			return nil, storage.ErrWalletNotFound
 		}
 
-		return 0, time.Time{}, transaction.HandleError(op, "update", err)
+		return nil, transaction.HandleError(op, "update", err)
 	}
 
-	return newBalance, updatedAt, nil
+	return wallet, nil
 }
 
-func (wr *WalletRepository) DecreaseBalance(
+// CaptureHeldBalance releases held from the reserved funds and prepares the
+// debit of captured (at most held), which the posting of the capture makes.
+// Like the other debits it is refused on frozen and closed wallets with
+// ErrWalletFrozen and ErrWalletClosed.
+func (wr *WalletRepository) CaptureHeldBalance(
 	ctx context.Context,
 	tx pgxdriver.QueryExecuter,
 	walletID uuid.UUID,
-	amount int64,
-) (int64, time.Time, error) {
+	held int64,
+	captured int64,
+) (*models.Wallet, error) {
 
-	const op = "storage.postgres.DecreaseBalance"
+	const op = "storage.postgres.CaptureHeldBalance"
 
 	query, args, err := wr.postgres.
 		Update("wallets").
-		Set("balance", squirrel.Expr("balance - ?", amount)).
+		Set("held_balance", squirrel.Expr("held_balance - ?", held)).
 		Where(squirrel.And{
 			squirrel.Expr("id = ?", walletID),
-			squirrel.Expr("balance >= ?", amount),
+			squirrel.Eq{"status": models.WalletActive},
 		}).
-		Suffix("RETURNING balance, updated_at").
+		Suffix(walletReturning()).
 		ToSql()
 
 	if err != nil {
-		return 0, time.Time{}, transaction.HandleError(op, "build_update", err)
+		return nil, transaction.HandleError(op, "build_update", err)
 	}
 
-	var newBalance int64
-	var updatedAt time.Time
-	err = tx.QueryRow(ctx, query, args...).Scan(&newBalance, &updatedAt)
-
+	wallet, err := scanWallet(tx.QueryRow(ctx, query, args...))
 	if err != nil {
-		wr.log.Debug(op, err.Error())
 		if errors.Is(err, pgx.ErrNoRows) {
-			notFound, checkErr := wr.isWalletNotFound(ctx, tx, walletID)
-			if checkErr != nil {
-				return 0, time.Time{}, transaction.HandleError(op, "check_wallet", checkErr)
-			}
+			return nil, wr.rejectedUpdateError(ctx, tx, op, walletID)
+		}
+
+		return nil, transaction.HandleError(op, "update", err)
+	}
+
+	return projectBalance(wallet, -captured), nil
+}
+
+// rejectedUpdateError explains why a guarded balance update matched no row:
+// the wallet is missing, not active, sharded, or short of available funds.
+func (wr *WalletRepository) rejectedUpdateError(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	op string,
+	walletID uuid.UUID,
+) error {
+
+	status, buckets, err := wr.walletState(ctx, tx, walletID)
+	if err != nil {
+		if errors.Is(err, storage.ErrWalletNotFound) {
+			return storage.ErrWalletNotFound
+		}
+		return transaction.HandleError(op, "check_wallet", err)
+	}
+
+	switch {
+	case status == models.WalletFrozen:
+		return storage.ErrWalletFrozen
+	case status == models.WalletClosed:
+		return storage.ErrWalletClosed
+	case buckets > 0:
+		return storage.ErrWalletSharded
+	default:
+		return storage.ErrInsufficientFunds
+	}
+}
+
+// LockWallets takes row locks on the given wallets in ascending id order,
+// so concurrent callers locking the same set never deadlock each other.
+// The locked wallets are returned in that order. Sharded wallets are
+// consolidated: the balance updates that follow see all of their funds on
+// the wallets row.
+func (wr *WalletRepository) LockWallets(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletIDs ...uuid.UUID,
+) ([]*models.Wallet, error) {
+
+	wallets, err := wr.LockExistingWallets(ctx, tx, walletIDs...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(wallets) != len(slices.Compact(sortedIDs(walletIDs))) {
+		return nil, storage.ErrWalletNotFound
+	}
+
+	return wallets, nil
+}
+
+// LockExistingWallets is LockWallets without the existence check: ids that
+// name no wallet are skipped.
+func (wr *WalletRepository) LockExistingWallets(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletIDs ...uuid.UUID,
+) ([]*models.Wallet, error) {
+
+	const op = "storage.postgres.LockWallets"
+
+	ids := slices.Compact(sortedIDs(walletIDs))
+
+	query, args, err := wr.postgres.
+		Select(walletColumns...).
+		From("wallets").
+		Where(squirrel.Eq{"id": ids}).
+		OrderBy("id").
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+	defer rows.Close()
 
-			if notFound {
-				return 0, time.Time{}, storage.ErrWalletNotFound
-			}
+	wallets := make([]*models.Wallet, 0, len(ids))
+	for rows.Next() {
+		wallet, err := scanWallet(rows)
+		if err != nil {
+			return nil, transaction.HandleError(op, "scan", err)
+		}
+		wallets = append(wallets, wallet)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+	rows.Close()
 
-			return 0, time.Time{}, storage.ErrInsufficientFunds
+	// The row lock keeps bucket writers out from now on, but the bucket sums
+	// above were read before it was granted.
+	for i, wallet := range wallets {
+		if wallet.BalanceBuckets == 0 {
+			continue
 		}
 
-		return 0, time.Time{}, transaction.HandleError(op, "update", err)
+		wallets[i], err = wr.ConsolidateBalance(ctx, tx, wallet.ID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return newBalance, updatedAt, nil
+	return wallets, nil
 }
 
-func (wr *WalletRepository) isWalletNotFound(
+func sortedIDs(ids []uuid.UUID) []uuid.UUID {
+	sorted := slices.Clone(ids)
+	slices.SortFunc(sorted, func(a, b uuid.UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
+	return sorted
+}
+
+func (wr *WalletRepository) walletState(
 	ctx context.Context,
 	tx pgxdriver.QueryExecuter,
 	walletID uuid.UUID,
-) (bool, error) {
+) (models.WalletStatus, int, error) {
 
 	query, args, err := wr.postgres.
-		Select("1").
+		Select("status", "balance_buckets").
 		From("wallets").
 		Where("id = ?", walletID).
 		ToSql()
 	if err != nil {
-		return false, err
+		return "", 0, err
 	}
 
-	var exists int
-	err = tx.QueryRow(ctx, query, args...).Scan(&exists)
+	var (
+		status  models.WalletStatus
+		buckets int
+	)
+	err = tx.QueryRow(ctx, query, args...).Scan(&status, &buckets)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return true, nil
+			return "", 0, storage.ErrWalletNotFound
 		}
-		return false, err
+		return "", 0, err
+	}
+
+	return status, buckets, nil
+}
+
+// UpdateWalletStatus sets the lifecycle status of a wallet. Callers lock the
+// wallet first and validate the transition.
+func (wr *WalletRepository) UpdateWalletStatus(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	walletID uuid.UUID,
+	status models.WalletStatus,
+) (*models.Wallet, error) {
+
+	const op = "storage.postgres.UpdateWalletStatus"
+
+	query, args, err := wr.postgres.
+		Update("wallets").
+		Set("status", status).
+		Where("id = ?", walletID).
+		Suffix(walletReturning()).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_update", err)
+	}
+
+	wallet, err := scanWallet(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrWalletNotFound
+		}
+		return nil, transaction.HandleError(op, "update", err)
+	}
+
+	return wallet, nil
+}
+
+func (wr *WalletRepository) RecordStatusChange(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	change *models.WalletStatusChange,
+) error {
+
+	const op = "storage.postgres.RecordStatusChange"
+
+	query, args, err := wr.postgres.
+		Insert("wallet_status_changes").
+		Columns("id", "wallet_id", "from_status", "to_status", "actor", "reason").
+		Values(change.ID, change.WalletID, change.FromStatus, change.ToStatus, change.Actor, change.Reason).
+		ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_insert", err)
+	}
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return transaction.HandleError(op, "insert", err)
 	}
 
-	return false, nil
+	return nil
 }
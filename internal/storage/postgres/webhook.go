@@ -0,0 +1,443 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+	"wallet-service/internal/domain/models"
+	"wallet-service/internal/storage"
+	pgxdriver "wallet-service/pkg/pgx-driver"
+	"wallet-service/pkg/pgx-driver/transaction"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+var subscriptionColumns = []string{
+	"id", "url", "event_types", "wallet_ids", "secret", "active", "created_at", "updated_at",
+}
+
+var deliveryColumns = []string{
+	"d.id",
+	"d.subscription_id",
+	"d.event_id",
+	"d.event_type",
+	"d.payload",
+	"d.status",
+	"d.attempts",
+	"d.next_attempt_at",
+	"d.last_status_code",
+	"d.last_error",
+	"d.created_at",
+	"d.delivered_at",
+}
+
+func scanSubscription(row pgx.Row) (*models.WebhookSubscription, error) {
+	sub := &models.WebhookSubscription{}
+	err := row.Scan(
+		&sub.ID,
+		&sub.URL,
+		&sub.EventTypes,
+		&sub.WalletIDs,
+		&sub.Secret,
+		&sub.Active,
+		&sub.CreatedAt,
+		&sub.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+func deliveryDest(delivery *models.WebhookDelivery) []any {
+	return []any{
+		&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastStatusCode,
+		&delivery.LastError,
+		&delivery.CreatedAt,
+		&delivery.DeliveredAt,
+	}
+}
+
+func scanDelivery(row pgx.Row) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{}
+	if err := row.Scan(deliveryDest(delivery)...); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+type WebhookRepository struct {
+	postgres *pgxdriver.Postgres
+	log      *slog.Logger
+}
+
+func NewWebhookRepository(log *slog.Logger, postgres *pgxdriver.Postgres) *WebhookRepository {
+	return &WebhookRepository{
+		postgres: postgres,
+		log:      log,
+	}
+}
+
+func (wr *WebhookRepository) CreateSubscription(
+	ctx context.Context,
+	sub *models.WebhookSubscription,
+) (*models.WebhookSubscription, error) {
+
+	const op = "storage.postgres.CreateSubscription"
+
+	query, args, err := wr.postgres.
+		Insert("webhook_subscriptions").
+		Columns("id", "url", "event_types", "wallet_ids", "secret", "active").
+		Values(sub.ID, sub.URL, sub.EventTypes, sub.WalletIDs, sub.Secret, sub.Active).
+		Suffix("RETURNING " + strings.Join(subscriptionColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_insert", err)
+	}
+
+	created, err := scanSubscription(wr.postgres.Pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		return nil, transaction.HandleError(op, "insert", err)
+	}
+
+	return created, nil
+}
+
+func (wr *WebhookRepository) ListSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	const op = "storage.postgres.ListSubscriptions"
+
+	query, args, err := wr.postgres.
+		Select(subscriptionColumns...).
+		From("webhook_subscriptions").
+		OrderBy("created_at", "id").
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	rows, err := wr.postgres.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+	defer rows.Close()
+
+	subs := make([]*models.WebhookSubscription, 0)
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, transaction.HandleError(op, "scan", err)
+		}
+		subs = append(subs, sub)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return subs, nil
+}
+
+func (wr *WebhookRepository) GetSubscription(ctx context.Context, id uuid.UUID) (*models.WebhookSubscription, error) {
+	const op = "storage.postgres.GetSubscription"
+
+	query, args, err := wr.postgres.
+		Select(subscriptionColumns...).
+		From("webhook_subscriptions").
+		Where("id = ?", id).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	sub, err := scanSubscription(wr.postgres.Pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrWebhookNotFound
+		}
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return sub, nil
+}
+
+func (wr *WebhookRepository) UpdateSubscription(
+	ctx context.Context,
+	id uuid.UUID,
+	update *models.WebhookSubscriptionUpdate,
+) (*models.WebhookSubscription, error) {
+
+	const op = "storage.postgres.UpdateSubscription"
+
+	builder := wr.postgres.
+		Update("webhook_subscriptions").
+		Set("updated_at", squirrel.Expr("now()")).
+		Where("id = ?", id).
+		Suffix("RETURNING " + strings.Join(subscriptionColumns, ", "))
+
+	if update.URL != nil {
+		builder = builder.Set("url", *update.URL)
+	}
+	if update.EventTypes != nil {
+		builder = builder.Set("event_types", *update.EventTypes)
+	}
+	if update.WalletIDs != nil {
+		builder = builder.Set("wallet_ids", *update.WalletIDs)
+	}
+	if update.Active != nil {
+		builder = builder.Set("active", *update.Active)
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_update", err)
+	}
+
+	sub, err := scanSubscription(wr.postgres.Pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrWebhookNotFound
+		}
+		return nil, transaction.HandleError(op, "update", err)
+	}
+
+	return sub, nil
+}
+
+func (wr *WebhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	const op = "storage.postgres.DeleteSubscription"
+
+	query, args, err := wr.postgres.
+		Delete("webhook_subscriptions").
+		Where("id = ?", id).
+		ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_delete", err)
+	}
+
+	tag, err := wr.postgres.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return transaction.HandleError(op, "delete", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return storage.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// EnqueueDeliveries queues event for every active subscription whose filters
+// match it. Queuing the same event again is a no-op per subscription.
+func (wr *WebhookRepository) EnqueueDeliveries(ctx context.Context, event *models.OutboxEvent) (int64, error) {
+	const op = "storage.postgres.EnqueueDeliveries"
+
+	// The nested select keeps "?" placeholders; the insert numbers them.
+	matching := squirrel.
+		Select("gen_random_uuid()", "id").
+		Column("?::uuid", event.ID).
+		Column("?", event.EventType).
+		Column("?::jsonb", string(event.Payload)).
+		From("webhook_subscriptions").
+		Where(squirrel.And{
+			squirrel.Eq{"active": true},
+			squirrel.Expr("(cardinality(event_types) = 0 OR ? = ANY(event_types))", event.EventType),
+			squirrel.Expr("(cardinality(wallet_ids) = 0 OR ?::uuid = ANY(wallet_ids))", event.AggregateID),
+		})
+
+	query, args, err := wr.postgres.
+		Insert("webhook_deliveries").
+		Columns("id", "subscription_id", "event_id", "event_type", "payload").
+		Select(matching).
+		Suffix("ON CONFLICT (subscription_id, event_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return 0, transaction.HandleError(op, "build_insert", err)
+	}
+
+	tag, err := wr.postgres.Pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, transaction.HandleError(op, "insert", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// ListDeliveries returns the latest deliveries of a subscription, newest first.
+func (wr *WebhookRepository) ListDeliveries(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	limit int,
+) ([]*models.WebhookDelivery, error) {
+
+	const op = "storage.postgres.ListDeliveries"
+
+	query, args, err := wr.postgres.
+		Select(deliveryColumns...).
+		From("webhook_deliveries d").
+		Where("d.subscription_id = ?", subscriptionID).
+		OrderBy("d.created_at DESC", "d.id DESC").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	rows, err := wr.postgres.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]*models.WebhookDelivery, 0)
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, transaction.HandleError(op, "scan", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return deliveries, nil
+}
+
+// GetPendingDeliveriesForUpdate locks up to limit due deliveries of active
+// subscriptions and loads the URL and secret to send them with.
+func (wr *WebhookRepository) GetPendingDeliveriesForUpdate(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	now time.Time,
+	limit int,
+) ([]*models.WebhookDelivery, error) {
+
+	const op = "storage.postgres.GetPendingDeliveriesForUpdate"
+
+	query, args, err := wr.postgres.
+		Select(append(slices.Clone(deliveryColumns), "s.url", "s.secret")...).
+		From("webhook_deliveries d").
+		Join("webhook_subscriptions s ON s.id = d.subscription_id").
+		Where(squirrel.And{
+			squirrel.Eq{"d.status": models.DeliveryPending},
+			squirrel.LtOrEq{"d.next_attempt_at": now},
+			squirrel.Eq{"s.active": true},
+		}).
+		OrderBy("d.next_attempt_at").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE OF d SKIP LOCKED").
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_select", err)
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*models.WebhookDelivery
+	for rows.Next() {
+		delivery := &models.WebhookDelivery{}
+		if err := rows.Scan(append(deliveryDest(delivery), &delivery.URL, &delivery.Secret)...); err != nil {
+			return nil, transaction.HandleError(op, "scan", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, transaction.HandleError(op, "select", err)
+	}
+
+	return deliveries, nil
+}
+
+// RecordDeliveryAttempt stores the outcome of one attempt. A delivery stays
+// PENDING until nextAttemptAt, or ends as DELIVERED or FAILED.
+func (wr *WebhookRepository) RecordDeliveryAttempt(
+	ctx context.Context,
+	tx pgxdriver.QueryExecuter,
+	id uuid.UUID,
+	status models.WebhookDeliveryStatus,
+	nextAttemptAt time.Time,
+	statusCode *int,
+	lastError string,
+) error {
+
+	const op = "storage.postgres.RecordDeliveryAttempt"
+
+	builder := wr.postgres.
+		Update("webhook_deliveries").
+		Set("status", status).
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("next_attempt_at", nextAttemptAt).
+		Set("last_status_code", statusCode).
+		Set("last_error", lastError).
+		Where("id = ?", id)
+
+	if status == models.DeliveryDelivered {
+		builder = builder.Set("delivered_at", squirrel.Expr("now()"))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return transaction.HandleError(op, "build_update", err)
+	}
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		return transaction.HandleError(op, "update", err)
+	}
+
+	return nil
+}
+
+// ResetDelivery queues a delivery of the subscription again with a fresh
+// retry budget, whatever its current status.
+func (wr *WebhookRepository) ResetDelivery(
+	ctx context.Context,
+	subscriptionID uuid.UUID,
+	deliveryID uuid.UUID,
+) (*models.WebhookDelivery, error) {
+
+	const op = "storage.postgres.ResetDelivery"
+
+	query, args, err := wr.postgres.
+		Update("webhook_deliveries d").
+		Set("status", models.DeliveryPending).
+		Set("attempts", 0).
+		Set("next_attempt_at", squirrel.Expr("now()")).
+		Set("delivered_at", nil).
+		Where(squirrel.Eq{"d.id": deliveryID, "d.subscription_id": subscriptionID}).
+		Suffix("RETURNING " + strings.Join(deliveryColumns, ", ")).
+		ToSql()
+	if err != nil {
+		return nil, transaction.HandleError(op, "build_update", err)
+	}
+
+	delivery, err := scanDelivery(wr.postgres.Pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, storage.ErrDeliveryNotFound
+		}
+		return nil, transaction.HandleError(op, "update", err)
+	}
+
+	return delivery, nil
+}
@@ -5,9 +5,30 @@ import "errors"
 var (
 	ErrWalletExists   = errors.New("wallet already exists")
 	ErrWalletNotFound = errors.New("wallet not found")
+	ErrWalletFrozen   = errors.New("wallet is frozen")
+	ErrWalletClosed   = errors.New("wallet is closed")
 
 	ErrOperationExists   = errors.New("operation already exists")
 	ErrOperationNotFound = errors.New("operation not found")
 
 	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrWalletSharded reports that a balance update on the wallets row was
+	// refused because part of the wallet balance may sit in its buckets.
+	ErrWalletSharded = errors.New("wallet balance is sharded")
+
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+
+	ErrHoldNotFound = errors.New("hold not found")
+
+	ErrQuoteNotFound = errors.New("fx quote not found")
+
+	ErrWebhookNotFound  = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+
+	ErrReconciliationRunNotFound = errors.New("reconciliation run not found")
+
+	ErrAPIKeyNotFound = errors.New("api key not found")
+
+	// ErrSchemaNotMigrated reports a database without a migration applied.
+	ErrSchemaNotMigrated = errors.New("database schema is not migrated")
 )
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_operations_transfer_id;
+
+DELETE FROM operations WHERE type IN ('TRANSFER_OUT', 'TRANSFER_IN');
+
+ALTER TABLE operations
+    DROP CONSTRAINT IF EXISTS operation_type_check;
+
+ALTER TABLE operations
+    ADD CONSTRAINT operation_type_check
+        CHECK (type IN ('DEPOSIT', 'WITHDRAW'));
+
+ALTER TABLE operations
+    DROP COLUMN IF EXISTS transfer_id;
@@ -0,0 +1,12 @@
+ALTER TABLE operations
+    ADD COLUMN IF NOT EXISTS transfer_id UUID;
+
+ALTER TABLE operations
+    DROP CONSTRAINT IF EXISTS operation_type_check;
+
+ALTER TABLE operations
+    ADD CONSTRAINT operation_type_check
+        CHECK (type IN ('DEPOSIT', 'WITHDRAW', 'TRANSFER_OUT', 'TRANSFER_IN'));
+
+CREATE INDEX IF NOT EXISTS idx_operations_transfer_id
+    ON operations(transfer_id);
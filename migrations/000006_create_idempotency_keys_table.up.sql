@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key VARCHAR(255) PRIMARY KEY,
+    wallet_id UUID NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    operation_id UUID NOT NULL,
+    balance BIGINT NOT NULL,
+    wallet_updated_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_idempotency_operation
+        FOREIGN KEY (operation_id)
+            REFERENCES operations(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at
+    ON idempotency_keys(created_at);
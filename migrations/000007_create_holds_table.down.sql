@@ -0,0 +1,22 @@
+ALTER TABLE idempotency_keys
+    DROP COLUMN IF EXISTS held_balance;
+
+DELETE FROM operations WHERE type = 'CAPTURE';
+
+ALTER TABLE operations
+    DROP CONSTRAINT IF EXISTS operation_type_check;
+
+ALTER TABLE operations
+    ADD CONSTRAINT operation_type_check
+        CHECK (type IN ('DEPOSIT', 'WITHDRAW', 'TRANSFER_OUT', 'TRANSFER_IN'));
+
+ALTER TABLE operations
+    DROP COLUMN IF EXISTS hold_id;
+
+DROP TABLE IF EXISTS holds;
+
+ALTER TABLE wallets
+    DROP CONSTRAINT IF EXISTS wallet_held_balance_check;
+
+ALTER TABLE wallets
+    DROP COLUMN IF EXISTS held_balance;
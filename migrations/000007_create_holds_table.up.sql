@@ -0,0 +1,50 @@
+ALTER TABLE wallets
+    ADD COLUMN IF NOT EXISTS held_balance BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE wallets
+    ADD CONSTRAINT wallet_held_balance_check
+        CHECK (held_balance >= 0 AND held_balance <= balance);
+
+CREATE TABLE IF NOT EXISTS holds (
+    id UUID PRIMARY KEY,
+    wallet_id UUID NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    captured_amount BIGINT NOT NULL DEFAULT 0 CHECK (captured_amount >= 0 AND captured_amount <= amount),
+    status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_hold_wallet
+        FOREIGN KEY (wallet_id)
+            REFERENCES wallets(id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT hold_status_check
+        CHECK (status IN ('ACTIVE', 'CAPTURED', 'VOIDED', 'EXPIRED'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_holds_wallet_id
+    ON holds(wallet_id);
+
+CREATE INDEX IF NOT EXISTS idx_holds_active_expires_at
+    ON holds(expires_at)
+    WHERE status = 'ACTIVE';
+
+CREATE TRIGGER trg_holds_set_updated_at
+    BEFORE UPDATE ON holds
+    FOR EACH ROW
+EXECUTE FUNCTION set_updated_at();
+
+ALTER TABLE operations
+    ADD COLUMN IF NOT EXISTS hold_id UUID REFERENCES holds(id) ON DELETE SET NULL;
+
+ALTER TABLE operations
+    DROP CONSTRAINT IF EXISTS operation_type_check;
+
+ALTER TABLE operations
+    ADD CONSTRAINT operation_type_check
+        CHECK (type IN ('DEPOSIT', 'WITHDRAW', 'TRANSFER_OUT', 'TRANSFER_IN', 'CAPTURE'));
+
+ALTER TABLE idempotency_keys
+    ADD COLUMN IF NOT EXISTS held_balance BIGINT NOT NULL DEFAULT 0;
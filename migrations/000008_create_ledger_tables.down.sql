@@ -0,0 +1,15 @@
+DROP TRIGGER IF EXISTS trg_guard_wallet_balance ON wallets;
+
+DROP VIEW IF EXISTS ledger_account_balances;
+
+DROP TABLE IF EXISTS postings;
+
+DROP TABLE IF EXISTS journal_entries;
+
+DROP TABLE IF EXISTS ledger_accounts;
+
+DROP FUNCTION IF EXISTS guard_wallet_balance();
+
+DROP FUNCTION IF EXISTS check_entry_balanced();
+
+DROP FUNCTION IF EXISTS apply_posting();
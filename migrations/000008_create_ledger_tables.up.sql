@@ -0,0 +1,150 @@
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    id UUID PRIMARY KEY,
+    wallet_id UUID UNIQUE,
+    kind VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_ledger_account_wallet
+        FOREIGN KEY (wallet_id)
+            REFERENCES wallets(id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT ledger_account_kind_check
+        CHECK (kind IN ('WALLET', 'EXTERNAL')),
+
+    CONSTRAINT ledger_account_wallet_check
+        CHECK ((kind = 'WALLET') = (wallet_id IS NOT NULL))
+);
+
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id UUID PRIMARY KEY,
+    type VARCHAR(20) NOT NULL,
+    reference_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS postings (
+    id BIGSERIAL PRIMARY KEY,
+    entry_id UUID NOT NULL,
+    account_id UUID NOT NULL,
+    amount BIGINT NOT NULL CHECK (amount <> 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_posting_entry
+        FOREIGN KEY (entry_id)
+            REFERENCES journal_entries(id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_posting_account
+        FOREIGN KEY (account_id)
+            REFERENCES ledger_accounts(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_journal_entries_reference_id
+    ON journal_entries(reference_id);
+
+CREATE INDEX IF NOT EXISTS idx_postings_entry_id
+    ON postings(entry_id);
+
+-- Account balances are summed from the postings rather than kept on the
+-- account rows: the external account takes a posting from every deposit and
+-- withdrawal, and a running balance on it would serialize them all.
+CREATE INDEX IF NOT EXISTS idx_postings_account_id
+    ON postings(account_id) INCLUDE (amount);
+
+CREATE OR REPLACE VIEW ledger_account_balances AS
+SELECT a.id, a.wallet_id, a.kind, COALESCE(SUM(p.amount), 0) AS balance
+FROM ledger_accounts a
+LEFT JOIN postings p ON p.account_id = a.id
+GROUP BY a.id;
+
+-- At commit every journal entry must be balanced: its postings sum to zero.
+CREATE OR REPLACE FUNCTION check_entry_balanced()
+    RETURNS TRIGGER AS $$
+DECLARE
+    total BIGINT;
+BEGIN
+    SELECT COALESCE(SUM(amount), 0) INTO total
+    FROM postings
+    WHERE entry_id = NEW.entry_id;
+
+    IF total <> 0 THEN
+        RAISE EXCEPTION 'journal entry % is unbalanced: postings sum to %', NEW.entry_id, total
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER trg_check_entry_balanced
+    AFTER INSERT ON postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW
+EXECUTE FUNCTION check_entry_balanced();
+
+-- Backfill: the external account, one account per existing wallet
+-- and an opening entry carrying each wallet's current balance. The balances
+-- are already on the wallets, so this runs before the projection trigger.
+INSERT INTO ledger_accounts (id, wallet_id, kind)
+VALUES ('00000000-0000-0000-0000-000000000001', NULL, 'EXTERNAL')
+ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO ledger_accounts (id, wallet_id, kind)
+SELECT id, id, 'WALLET'
+FROM wallets
+ON CONFLICT (id) DO NOTHING;
+
+WITH entries AS (
+    INSERT INTO journal_entries (id, type, reference_id)
+    SELECT gen_random_uuid(), 'OPENING_BALANCE', id
+    FROM wallets
+    WHERE balance <> 0
+    RETURNING id, reference_id
+)
+INSERT INTO postings (entry_id, account_id, amount)
+SELECT e.id, w.id, w.balance
+FROM entries e
+JOIN wallets w ON w.id = e.reference_id
+UNION ALL
+SELECT e.id, '00000000-0000-0000-0000-000000000001', -w.balance
+FROM entries e
+JOIN wallets w ON w.id = e.reference_id;
+
+-- wallets.balance is the projection of the postings to the wallet account,
+-- which shares the wallet id: it only moves here.
+CREATE OR REPLACE FUNCTION apply_posting()
+    RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE wallets
+    SET balance = balance + NEW.amount
+    WHERE id = NEW.account_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_apply_posting
+    AFTER INSERT ON postings
+    FOR EACH ROW
+EXECUTE FUNCTION apply_posting();
+
+-- Writes to wallets.balance from anywhere but a trigger are refused, so the
+-- projection cannot drift from the journal.
+CREATE OR REPLACE FUNCTION guard_wallet_balance()
+    RETURNS TRIGGER AS $$
+BEGIN
+    IF pg_trigger_depth() = 1 AND (
+        (TG_OP = 'INSERT' AND NEW.balance <> 0) OR
+        (TG_OP = 'UPDATE' AND NEW.balance <> OLD.balance)
+    ) THEN
+        RAISE EXCEPTION 'wallet % balance is a projection of the ledger: post a journal entry instead', NEW.id
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_guard_wallet_balance
+    BEFORE INSERT OR UPDATE OF balance ON wallets
+    FOR EACH ROW
+EXECUTE FUNCTION guard_wallet_balance();
@@ -0,0 +1,34 @@
+CREATE OR REPLACE FUNCTION check_entry_balanced()
+    RETURNS TRIGGER AS $$
+DECLARE
+    total BIGINT;
+BEGIN
+    SELECT COALESCE(SUM(amount), 0) INTO total
+    FROM postings
+    WHERE entry_id = NEW.entry_id;
+
+    IF total <> 0 THEN
+        RAISE EXCEPTION 'journal entry % is unbalanced: postings sum to %', NEW.entry_id, total
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DELETE FROM ledger_accounts WHERE kind = 'FX';
+
+ALTER TABLE ledger_accounts
+    DROP CONSTRAINT IF EXISTS ledger_account_kind_check;
+
+ALTER TABLE ledger_accounts
+    ADD CONSTRAINT ledger_account_kind_check
+        CHECK (kind IN ('WALLET', 'EXTERNAL'));
+
+ALTER TABLE ledger_accounts
+    DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE idempotency_keys
+    DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE wallets
+    DROP COLUMN IF EXISTS currency;
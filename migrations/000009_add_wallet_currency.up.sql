@@ -0,0 +1,46 @@
+ALTER TABLE wallets
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE wallets
+    ALTER COLUMN currency DROP DEFAULT;
+
+ALTER TABLE ledger_accounts
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE ledger_accounts
+    ALTER COLUMN currency DROP DEFAULT;
+
+ALTER TABLE ledger_accounts
+    DROP CONSTRAINT IF EXISTS ledger_account_kind_check;
+
+ALTER TABLE ledger_accounts
+    ADD CONSTRAINT ledger_account_kind_check
+        CHECK (kind IN ('WALLET', 'EXTERNAL', 'FX'));
+
+-- Journal entries must now balance within every currency they touch.
+CREATE OR REPLACE FUNCTION check_entry_balanced()
+    RETURNS TRIGGER AS $$
+DECLARE
+    unbalanced CHAR(3);
+BEGIN
+    SELECT a.currency INTO unbalanced
+    FROM postings p
+    JOIN ledger_accounts a ON a.id = p.account_id
+    WHERE p.entry_id = NEW.entry_id
+    GROUP BY a.currency
+    HAVING SUM(p.amount) <> 0
+    LIMIT 1;
+
+    IF unbalanced IS NOT NULL THEN
+        RAISE EXCEPTION 'journal entry % is unbalanced in %', NEW.entry_id, unbalanced
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE idempotency_keys
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE idempotency_keys
+    ALTER COLUMN currency DROP DEFAULT;
@@ -0,0 +1,13 @@
+DELETE FROM operations WHERE type IN ('EXCHANGE_OUT', 'EXCHANGE_IN');
+
+ALTER TABLE operations
+    DROP CONSTRAINT IF EXISTS operation_type_check;
+
+ALTER TABLE operations
+    ADD CONSTRAINT operation_type_check
+        CHECK (type IN ('DEPOSIT', 'WITHDRAW', 'TRANSFER_OUT', 'TRANSFER_IN', 'CAPTURE'));
+
+ALTER TABLE operations
+    DROP COLUMN IF EXISTS fx_rate;
+
+DROP TABLE IF EXISTS fx_quotes;
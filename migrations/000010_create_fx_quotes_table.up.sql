@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS fx_quotes (
+    id UUID PRIMARY KEY,
+    from_currency CHAR(3) NOT NULL,
+    to_currency CHAR(3) NOT NULL,
+    rate NUMERIC(24, 12) NOT NULL CHECK (rate > 0),
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT fx_quote_currencies_check
+        CHECK (from_currency <> to_currency)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fx_quotes_expires_at
+    ON fx_quotes(expires_at);
+
+ALTER TABLE operations
+    ADD COLUMN IF NOT EXISTS fx_rate NUMERIC(24, 12);
+
+ALTER TABLE operations
+    DROP CONSTRAINT IF EXISTS operation_type_check;
+
+ALTER TABLE operations
+    ADD CONSTRAINT operation_type_check
+        CHECK (type IN ('DEPOSIT', 'WITHDRAW', 'TRANSFER_OUT', 'TRANSFER_IN', 'CAPTURE', 'EXCHANGE_OUT', 'EXCHANGE_IN'));
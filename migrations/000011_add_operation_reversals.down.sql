@@ -0,0 +1,24 @@
+DELETE FROM operations WHERE type = 'REVERSAL';
+
+DROP INDEX IF EXISTS idx_operations_reversed_operation_id;
+
+ALTER TABLE operations
+    DROP CONSTRAINT IF EXISTS operation_reversal_reference_check;
+
+ALTER TABLE operations
+    DROP CONSTRAINT IF EXISTS operation_type_check;
+
+ALTER TABLE operations
+    ADD CONSTRAINT operation_type_check
+        CHECK (type IN ('DEPOSIT', 'WITHDRAW', 'TRANSFER_OUT', 'TRANSFER_IN', 'CAPTURE', 'EXCHANGE_OUT', 'EXCHANGE_IN'));
+
+ALTER TABLE operations
+    DROP CONSTRAINT IF EXISTS operation_reversed_amount_check;
+
+ALTER TABLE operations
+    DROP CONSTRAINT IF EXISTS fk_operation_reversed_operation;
+
+ALTER TABLE operations
+    DROP COLUMN IF EXISTS reason,
+    DROP COLUMN IF EXISTS reversed_amount,
+    DROP COLUMN IF EXISTS reversed_operation_id;
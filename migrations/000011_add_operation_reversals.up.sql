@@ -0,0 +1,28 @@
+ALTER TABLE operations
+    ADD COLUMN IF NOT EXISTS reversed_operation_id UUID,
+    ADD COLUMN IF NOT EXISTS reversed_amount BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS reason TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE operations
+    ADD CONSTRAINT fk_operation_reversed_operation
+        FOREIGN KEY (reversed_operation_id)
+            REFERENCES operations(id);
+
+ALTER TABLE operations
+    ADD CONSTRAINT operation_reversed_amount_check
+        CHECK (reversed_amount >= 0 AND reversed_amount <= amount);
+
+ALTER TABLE operations
+    DROP CONSTRAINT IF EXISTS operation_type_check;
+
+ALTER TABLE operations
+    ADD CONSTRAINT operation_type_check
+        CHECK (type IN ('DEPOSIT', 'WITHDRAW', 'TRANSFER_OUT', 'TRANSFER_IN', 'CAPTURE', 'EXCHANGE_OUT', 'EXCHANGE_IN', 'REVERSAL'));
+
+ALTER TABLE operations
+    ADD CONSTRAINT operation_reversal_reference_check
+        CHECK ((type = 'REVERSAL') = (reversed_operation_id IS NOT NULL));
+
+CREATE INDEX IF NOT EXISTS idx_operations_reversed_operation_id
+    ON operations(reversed_operation_id)
+    WHERE reversed_operation_id IS NOT NULL;
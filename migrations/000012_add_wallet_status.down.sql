@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS wallet_status_changes;
+
+ALTER TABLE wallets
+    DROP CONSTRAINT IF EXISTS wallet_closed_empty_check;
+
+ALTER TABLE wallets
+    DROP CONSTRAINT IF EXISTS wallet_status_check;
+
+ALTER TABLE wallets
+    DROP COLUMN IF EXISTS status;
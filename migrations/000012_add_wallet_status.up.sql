@@ -0,0 +1,28 @@
+ALTER TABLE wallets
+    ADD COLUMN IF NOT EXISTS status VARCHAR(10) NOT NULL DEFAULT 'ACTIVE';
+
+ALTER TABLE wallets
+    ADD CONSTRAINT wallet_status_check
+        CHECK (status IN ('ACTIVE', 'FROZEN', 'CLOSED'));
+
+ALTER TABLE wallets
+    ADD CONSTRAINT wallet_closed_empty_check
+        CHECK (status <> 'CLOSED' OR (balance = 0 AND held_balance = 0));
+
+CREATE TABLE IF NOT EXISTS wallet_status_changes (
+    id UUID PRIMARY KEY,
+    wallet_id UUID NOT NULL,
+    from_status VARCHAR(10) NOT NULL,
+    to_status VARCHAR(10) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_wallet_status_change_wallet
+        FOREIGN KEY (wallet_id)
+            REFERENCES wallets(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_wallet_status_changes_wallet_id_created_at
+    ON wallet_status_changes(wallet_id, created_at DESC);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_operations_wallet_id_created_at;
+
+DROP TABLE IF EXISTS wallet_limits;
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS wallet_limits (
+    wallet_id UUID PRIMARY KEY,
+    max_single_withdrawal BIGINT CHECK (max_single_withdrawal >= 0),
+    daily_withdrawal BIGINT CHECK (daily_withdrawal >= 0),
+    monthly_withdrawal BIGINT CHECK (monthly_withdrawal >= 0),
+    max_balance BIGINT CHECK (max_balance >= 0),
+    max_operations BIGINT CHECK (max_operations >= 0),
+    operation_window_seconds BIGINT CHECK (operation_window_seconds > 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    CONSTRAINT fk_wallet_limits_wallet
+        FOREIGN KEY (wallet_id)
+            REFERENCES wallets(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_operations_wallet_id_created_at
+    ON operations(wallet_id, created_at);
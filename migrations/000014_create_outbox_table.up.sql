@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS outbox (
+    id UUID PRIMARY KEY,
+    aggregate_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'PENDING',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    published_at TIMESTAMPTZ,
+
+    CONSTRAINT outbox_status_check
+        CHECK (status IN ('PENDING', 'PUBLISHED', 'DEAD'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending_next_attempt_at
+    ON outbox(next_attempt_at, created_at)
+    WHERE status = 'PENDING';
+
+-- lets the relay find published and dead events past their retention
+CREATE INDEX IF NOT EXISTS idx_outbox_status_created_at
+    ON outbox(status, created_at)
+    WHERE status <> 'PENDING';
@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id UUID PRIMARY KEY,
+    url TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    wallet_ids UUID[] NOT NULL DEFAULT '{}',
+    secret TEXT NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY,
+    subscription_id UUID NOT NULL,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'PENDING',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_status_code INT,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    delivered_at TIMESTAMPTZ,
+
+    CONSTRAINT fk_webhook_delivery_subscription
+        FOREIGN KEY (subscription_id)
+            REFERENCES webhook_subscriptions(id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT webhook_delivery_status_check
+        CHECK (status IN ('PENDING', 'DELIVERED', 'FAILED')),
+
+    -- the outbox relay is at-least-once; an event is queued once per subscription
+    CONSTRAINT webhook_delivery_event_unique
+        UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending_next_attempt_at
+    ON webhook_deliveries(next_attempt_at)
+    WHERE status = 'PENDING';
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id_created_at
+    ON webhook_deliveries(subscription_id, created_at DESC);
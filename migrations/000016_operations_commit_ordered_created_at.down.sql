@@ -0,0 +1,2 @@
+ALTER TABLE operations
+    ALTER COLUMN created_at SET DEFAULT now();
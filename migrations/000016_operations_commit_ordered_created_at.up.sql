@@ -0,0 +1,6 @@
+-- now() is the transaction start time, so an operation could become visible
+-- after a later-stamped one and be skipped by a stream resuming from a
+-- (created_at, id) cursor. Operations are inserted while the wallet row is
+-- locked, so the wall-clock insert time follows commit order per wallet.
+ALTER TABLE operations
+    ALTER COLUMN created_at SET DEFAULT clock_timestamp();
@@ -0,0 +1,46 @@
+-- Drain the buckets into the rows before dropping them.
+UPDATE wallet_balance_buckets
+SET balance = 0
+WHERE balance <> 0;
+
+DROP TRIGGER IF EXISTS trg_fold_wallet_balance_bucket ON wallet_balance_buckets;
+
+DROP TRIGGER IF EXISTS trg_guard_wallet_balance_bucket ON wallet_balance_buckets;
+
+DROP FUNCTION IF EXISTS fold_wallet_balance_bucket();
+
+DROP FUNCTION IF EXISTS guard_wallet_balance_bucket();
+
+CREATE OR REPLACE FUNCTION apply_posting()
+    RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE wallets
+    SET balance = balance + NEW.amount
+    WHERE id = NEW.account_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_assign_operation_sequence ON operations;
+
+DROP FUNCTION IF EXISTS assign_operation_sequence();
+
+DROP FUNCTION IF EXISTS sequence_wallet_operations(UUID);
+
+DROP INDEX IF EXISTS idx_operations_unsequenced;
+
+DROP INDEX IF EXISTS idx_operations_wallet_sequence;
+
+ALTER TABLE operations
+    DROP COLUMN IF EXISTS sequence;
+
+ALTER TABLE wallets
+    DROP COLUMN IF EXISTS last_sequence;
+
+DROP TABLE IF EXISTS wallet_balance_buckets;
+
+ALTER TABLE wallets
+    DROP CONSTRAINT IF EXISTS wallet_balance_buckets_check;
+
+ALTER TABLE wallets
+    DROP COLUMN IF EXISTS balance_buckets;
@@ -0,0 +1,193 @@
+ALTER TABLE wallets
+    ADD COLUMN IF NOT EXISTS balance_buckets SMALLINT NOT NULL DEFAULT 0;
+
+ALTER TABLE wallets
+    ADD CONSTRAINT wallet_balance_buckets_check
+        CHECK (balance_buckets BETWEEN 0 AND 64);
+
+-- A sharded wallet (balance_buckets > 0) takes deposits into one of its
+-- buckets instead of the wallets row, so concurrent deposits do not queue on
+-- one row lock. Its balance is wallets.balance plus the sum of its buckets;
+-- funds reserved by holds always stay in wallets.balance.
+CREATE TABLE IF NOT EXISTS wallet_balance_buckets (
+    wallet_id UUID NOT NULL,
+    bucket SMALLINT NOT NULL,
+    balance BIGINT NOT NULL DEFAULT 0 CHECK (balance >= 0),
+
+    PRIMARY KEY (wallet_id, bucket),
+
+    CONSTRAINT fk_wallet_balance_bucket_wallet
+        FOREIGN KEY (wallet_id)
+            REFERENCES wallets(id)
+            ON DELETE CASCADE
+);
+
+-- Operations of a wallet are numbered by a per-wallet sequence, assigned
+-- under the wallet row lock, which is held until commit: a stream resuming
+-- after a sequence number never skips an operation. created_at no longer
+-- gives that order once deposits into buckets commit without the row lock.
+ALTER TABLE wallets
+    ADD COLUMN IF NOT EXISTS last_sequence BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE operations
+    ADD COLUMN IF NOT EXISTS sequence BIGINT;
+
+WITH numbered AS (
+    SELECT id, row_number() OVER (PARTITION BY wallet_id ORDER BY created_at, id) AS sequence
+    FROM operations
+)
+UPDATE operations o
+SET sequence = n.sequence
+FROM numbered n
+WHERE o.id = n.id;
+
+UPDATE wallets w
+SET last_sequence = c.total
+FROM (
+    SELECT wallet_id, COUNT(*) AS total
+    FROM operations
+    GROUP BY wallet_id
+) c
+WHERE c.wallet_id = w.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_operations_wallet_sequence
+    ON operations(wallet_id, sequence);
+
+CREATE INDEX IF NOT EXISTS idx_operations_unsequenced
+    ON operations(wallet_id)
+    WHERE sequence IS NULL;
+
+-- An operation of a plain wallet is inserted under its row lock and takes
+-- the next sequence right away. One of a sharded wallet is left without, to
+-- be sequenced by sequence_wallet_operations.
+CREATE OR REPLACE FUNCTION assign_operation_sequence()
+    RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE wallets
+    SET last_sequence = last_sequence + 1
+    WHERE id = NEW.wallet_id AND balance_buckets = 0
+    RETURNING last_sequence INTO NEW.sequence;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_assign_operation_sequence
+    BEFORE INSERT ON operations
+    FOR EACH ROW
+EXECUTE FUNCTION assign_operation_sequence();
+
+-- Sequences the committed operations of the wallet still without one, in
+-- creation order, and returns the last sequence of the wallet (NULL for an
+-- unknown wallet). Each statement sees what committed before the row lock
+-- was granted.
+CREATE OR REPLACE FUNCTION sequence_wallet_operations(target UUID)
+    RETURNS BIGINT AS $$
+DECLARE
+    last BIGINT;
+BEGIN
+    IF EXISTS (SELECT 1 FROM operations WHERE wallet_id = target AND sequence IS NULL) THEN
+        PERFORM 1 FROM wallets WHERE id = target FOR NO KEY UPDATE;
+
+        WITH pending AS (
+            SELECT id, row_number() OVER (ORDER BY created_at, id) AS n
+            FROM operations
+            WHERE wallet_id = target AND sequence IS NULL
+        ), counted AS (
+            SELECT COUNT(*) AS total FROM pending
+        ), base AS (
+            UPDATE wallets w
+            SET last_sequence = w.last_sequence + c.total
+            FROM counted c
+            WHERE w.id = target
+            RETURNING w.last_sequence - c.total AS last_sequence
+        )
+        UPDATE operations o
+        SET sequence = b.last_sequence + p.n
+        FROM pending p, base b
+        WHERE o.id = p.id;
+    END IF;
+
+    SELECT last_sequence INTO last FROM wallets WHERE id = target;
+    RETURN last;
+END;
+$$ LANGUAGE plpgsql;
+
+-- The projection of a sharded wallet takes its deposits into a random bucket.
+-- A withdrawal draws from a bucket that covers it and is free, or else from
+-- the wallets row, where a consolidating caller has gathered the funds.
+CREATE OR REPLACE FUNCTION apply_posting()
+    RETURNS TRIGGER AS $$
+DECLARE
+    buckets SMALLINT;
+    drawn SMALLINT;
+BEGIN
+    SELECT balance_buckets INTO buckets
+    FROM wallets
+    WHERE id = NEW.account_id;
+
+    IF buckets > 0 AND NEW.amount > 0 THEN
+        INSERT INTO wallet_balance_buckets (wallet_id, bucket, balance)
+        VALUES (NEW.account_id, floor(random() * buckets), NEW.amount)
+        ON CONFLICT (wallet_id, bucket) DO UPDATE
+            SET balance = wallet_balance_buckets.balance + EXCLUDED.balance;
+        RETURN NEW;
+    END IF;
+
+    IF buckets > 0 THEN
+        SELECT bucket INTO drawn
+        FROM wallet_balance_buckets
+        WHERE wallet_id = NEW.account_id AND balance >= -NEW.amount
+        ORDER BY random()
+        LIMIT 1
+        FOR UPDATE SKIP LOCKED;
+
+        IF FOUND THEN
+            UPDATE wallet_balance_buckets
+            SET balance = balance + NEW.amount
+            WHERE wallet_id = NEW.account_id AND bucket = drawn;
+            RETURN NEW;
+        END IF;
+    END IF;
+
+    UPDATE wallets
+    SET balance = balance + NEW.amount
+    WHERE id = NEW.account_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Outside the ledger a bucket may only be drained, which folds its funds
+-- into the wallets row: consolidation moves the projection without changing
+-- its total.
+CREATE OR REPLACE FUNCTION guard_wallet_balance_bucket()
+    RETURNS TRIGGER AS $$
+BEGIN
+    IF pg_trigger_depth() = 1 AND NEW.balance <> 0 AND (TG_OP = 'INSERT' OR NEW.balance <> OLD.balance) THEN
+        RAISE EXCEPTION 'wallet % balance is a projection of the ledger: post a journal entry instead', NEW.wallet_id
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_guard_wallet_balance_bucket
+    BEFORE INSERT OR UPDATE OF balance ON wallet_balance_buckets
+    FOR EACH ROW
+EXECUTE FUNCTION guard_wallet_balance_bucket();
+
+CREATE OR REPLACE FUNCTION fold_wallet_balance_bucket()
+    RETURNS TRIGGER AS $$
+BEGIN
+    IF pg_trigger_depth() = 1 AND OLD.balance <> NEW.balance THEN
+        UPDATE wallets
+        SET balance = balance + OLD.balance - NEW.balance
+        WHERE id = NEW.wallet_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_fold_wallet_balance_bucket
+    AFTER UPDATE OF balance ON wallet_balance_buckets
+    FOR EACH ROW
+EXECUTE FUNCTION fold_wallet_balance_bucket();
@@ -0,0 +1,58 @@
+CREATE OR REPLACE FUNCTION assign_operation_sequence()
+    RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE wallets
+    SET last_sequence = last_sequence + 1
+    WHERE id = NEW.wallet_id AND balance_buckets = 0
+    RETURNING last_sequence INTO NEW.sequence;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION sequence_wallet_operations(target UUID)
+    RETURNS BIGINT AS $$
+DECLARE
+    last BIGINT;
+BEGIN
+    IF EXISTS (SELECT 1 FROM operations WHERE wallet_id = target AND sequence IS NULL) THEN
+        PERFORM 1 FROM wallets WHERE id = target FOR NO KEY UPDATE;
+
+        WITH pending AS (
+            SELECT id, row_number() OVER (ORDER BY created_at, id) AS n
+            FROM operations
+            WHERE wallet_id = target AND sequence IS NULL
+        ), counted AS (
+            SELECT COUNT(*) AS total FROM pending
+        ), base AS (
+            UPDATE wallets w
+            SET last_sequence = w.last_sequence + c.total
+            FROM counted c
+            WHERE w.id = target
+            RETURNING w.last_sequence - c.total AS last_sequence
+        )
+        UPDATE operations o
+        SET sequence = b.last_sequence + p.n
+        FROM pending p, base b
+        WHERE o.id = p.id;
+    END IF;
+
+    SELECT last_sequence INTO last FROM wallets WHERE id = target;
+    RETURN last;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP INDEX IF EXISTS idx_operations_wallet_created_at;
+
+DROP FUNCTION IF EXISTS operation_delta(VARCHAR, BIGINT, UUID);
+
+ALTER TABLE wallets
+    DROP COLUMN IF EXISTS sequenced_balance;
+
+ALTER TABLE wallets
+    DROP COLUMN IF EXISTS opening_balance;
+
+ALTER TABLE operations
+    DROP COLUMN IF EXISTS balance_after;
+
+ALTER TABLE operations
+    DROP COLUMN IF EXISTS delta;
@@ -0,0 +1,146 @@
+-- Every operation records the signed amount it moved the wallet balance by,
+-- so the balance at any moment is the opening balance plus the deltas of the
+-- operations created up to it. That sum does not depend on the order
+-- concurrent operations commit in.
+ALTER TABLE operations
+    ADD COLUMN IF NOT EXISTS delta BIGINT;
+
+-- The balance the operation left the wallet with, in sequence order. It is
+-- assigned together with the sequence, under the wallet row lock, and is
+-- NULL as long as the sequence is.
+ALTER TABLE operations
+    ADD COLUMN IF NOT EXISTS balance_after BIGINT;
+
+-- The balance a wallet was opened with, for moments before its first
+-- operation.
+ALTER TABLE wallets
+    ADD COLUMN IF NOT EXISTS opening_balance BIGINT;
+
+-- The balance after the last sequenced operation of the wallet. A new
+-- wallet starts it at its opening balance: the opening entry is not an
+-- operation.
+ALTER TABLE wallets
+    ADD COLUMN IF NOT EXISTS sequenced_balance BIGINT;
+
+CREATE OR REPLACE FUNCTION operation_delta(op_type VARCHAR, amount BIGINT, reversed_operation UUID)
+    RETURNS BIGINT AS $$
+    SELECT CASE
+               WHEN op_type IN ('DEPOSIT', 'TRANSFER_IN', 'EXCHANGE_IN') THEN amount
+               WHEN op_type = 'REVERSAL'
+                   AND (SELECT r.type FROM operations r WHERE r.id = reversed_operation) = 'WITHDRAW' THEN amount
+               ELSE -amount
+           END
+$$ LANGUAGE sql STABLE;
+
+-- Backfill: the balance only changes through operations, so walking each
+-- wallet's operations back from its current balance recovers the balance
+-- after each of them and the opening balance.
+UPDATE operations
+SET delta = operation_delta(type, amount, reversed_operation_id);
+
+CREATE TEMPORARY TABLE current_balances AS
+SELECT w.id,
+       w.balance + COALESCE((
+           SELECT SUM(b.balance)
+           FROM wallet_balance_buckets b
+           WHERE b.wallet_id = w.id
+       ), 0) AS balance
+FROM wallets w;
+
+WITH backfilled AS (
+    SELECT o.id,
+           c.balance - COALESCE(SUM(o.delta) OVER (
+               PARTITION BY o.wallet_id
+               ORDER BY o.sequence DESC
+               ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING
+           ), 0) AS balance_after
+    FROM operations o
+    JOIN current_balances c ON c.id = o.wallet_id
+)
+UPDATE operations o
+SET balance_after = b.balance_after
+FROM backfilled b
+WHERE o.id = b.id;
+
+UPDATE wallets w
+SET opening_balance = c.balance - COALESCE((
+        SELECT SUM(o.delta)
+        FROM operations o
+        WHERE o.wallet_id = w.id
+    ), 0),
+    sequenced_balance = c.balance
+FROM current_balances c
+WHERE c.id = w.id;
+
+DROP TABLE current_balances;
+
+ALTER TABLE operations
+    ALTER COLUMN delta SET NOT NULL;
+
+ALTER TABLE wallets
+    ALTER COLUMN opening_balance SET NOT NULL,
+    ALTER COLUMN opening_balance SET DEFAULT 0;
+
+ALTER TABLE wallets
+    ALTER COLUMN sequenced_balance SET NOT NULL,
+    ALTER COLUMN sequenced_balance SET DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_operations_wallet_created_at
+    ON operations(wallet_id, created_at) INCLUDE (delta);
+
+-- The sequence functions of the buckets migration now also assign
+-- balance_after from the running sequenced balance of the wallet.
+CREATE OR REPLACE FUNCTION assign_operation_sequence()
+    RETURNS TRIGGER AS $$
+BEGIN
+    NEW.delta := operation_delta(NEW.type, NEW.amount, NEW.reversed_operation_id);
+    NEW.balance_after := NULL;
+
+    UPDATE wallets
+    SET last_sequence = last_sequence + 1,
+        sequenced_balance = sequenced_balance + NEW.delta
+    WHERE id = NEW.wallet_id AND balance_buckets = 0
+    RETURNING last_sequence, sequenced_balance INTO NEW.sequence, NEW.balance_after;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION sequence_wallet_operations(target UUID)
+    RETURNS BIGINT AS $$
+DECLARE
+    last BIGINT;
+BEGIN
+    IF EXISTS (SELECT 1 FROM operations WHERE wallet_id = target AND sequence IS NULL) THEN
+        PERFORM 1 FROM wallets WHERE id = target FOR NO KEY UPDATE;
+
+        WITH pending AS (
+            SELECT id,
+                   delta,
+                   row_number() OVER w AS n,
+                   SUM(delta) OVER w AS running
+            FROM operations
+            WHERE wallet_id = target AND sequence IS NULL
+            WINDOW w AS (ORDER BY created_at, id ROWS UNBOUNDED PRECEDING)
+        ), counted AS (
+            SELECT COUNT(*) AS total, COALESCE(SUM(delta), 0) AS delta
+            FROM pending
+        ), base AS (
+            UPDATE wallets w
+            SET last_sequence = w.last_sequence + c.total,
+                sequenced_balance = w.sequenced_balance + c.delta
+            FROM counted c
+            WHERE w.id = target
+            RETURNING w.last_sequence - c.total AS last_sequence,
+                      w.sequenced_balance - c.delta AS balance
+        )
+        UPDATE operations o
+        SET sequence = b.last_sequence + p.n,
+            balance_after = b.balance + p.running
+        FROM pending p, base b
+        WHERE o.id = p.id;
+    END IF;
+
+    SELECT last_sequence INTO last FROM wallets WHERE id = target;
+    RETURN last;
+END;
+$$ LANGUAGE plpgsql;
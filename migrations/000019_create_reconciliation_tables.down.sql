@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS reconciliation_mismatches;
+
+DROP TABLE IF EXISTS reconciliation_runs;
@@ -0,0 +1,51 @@
+-- A reconciliation run recomputes wallet balances from their operations in
+-- pages of wallets ordered by id. checkpoint is the last wallet reconciled,
+-- so an interrupted run resumes after it.
+CREATE TABLE IF NOT EXISTS reconciliation_runs (
+    id UUID PRIMARY KEY,
+    mode VARCHAR(20) NOT NULL,
+    since TIMESTAMPTZ,
+    status VARCHAR(20) NOT NULL DEFAULT 'RUNNING',
+    checkpoint UUID,
+    wallets_checked BIGINT NOT NULL DEFAULT 0,
+    mismatches BIGINT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at TIMESTAMPTZ,
+
+    CONSTRAINT reconciliation_run_mode_check
+        CHECK (mode IN ('FULL', 'INCREMENTAL')),
+
+    CONSTRAINT reconciliation_run_status_check
+        CHECK (status IN ('RUNNING', 'COMPLETED'))
+);
+
+-- At most one run is in progress; it has to be resumed before a new one
+-- starts.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reconciliation_runs_running
+    ON reconciliation_runs((true))
+    WHERE status = 'RUNNING';
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_runs_started_at
+    ON reconciliation_runs(started_at DESC);
+
+CREATE TABLE IF NOT EXISTS reconciliation_mismatches (
+    run_id UUID NOT NULL,
+    wallet_id UUID NOT NULL,
+    balance BIGINT NOT NULL,
+    computed_balance BIGINT NOT NULL,
+    ledger_balance BIGINT,
+    last_balance_after BIGINT,
+    operations BIGINT NOT NULL,
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (run_id, wallet_id),
+
+    CONSTRAINT fk_reconciliation_mismatch_run
+        FOREIGN KEY (run_id)
+            REFERENCES reconciliation_runs(id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_mismatches_wallet_id
+    ON reconciliation_mismatches(wallet_id);
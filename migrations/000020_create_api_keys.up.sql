@@ -0,0 +1,17 @@
+-- API keys authenticate clients of the HTTP API. Only the SHA-256 of a key is
+-- stored; prefix is its first characters, kept to tell keys apart.
+CREATE TABLE IF NOT EXISTS api_keys (
+    id UUID PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(20) NOT NULL,
+    key_hash BYTEA NOT NULL,
+    scopes TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+
+    CONSTRAINT api_key_scopes_check
+        CHECK (scopes <@ ARRAY['wallets:read', 'wallets:write', 'admin']::TEXT[] AND cardinality(scopes) > 0)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS api_keys_key_hash_idx ON api_keys (key_hash);
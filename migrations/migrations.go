@@ -0,0 +1,8 @@
+// Package migrations embeds the SQL migrations of the service, so the binary
+// can migrate the database it is deployed against.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,5 @@
+// Package api holds the Go code generated from the protobuf definitions in
+// the top-level api directory.
+package api
+
+//go:generate protoc -I ../../api --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative wallet/v1/wallet.proto
@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -28,6 +31,83 @@ func ReadUUIDParam(r *http.Request, key string) (uuid.UUID, error) {
 	return id, nil
 }
 
+func ReadString(qs url.Values, key string, defaultValue string) string {
+	s := qs.Get(key)
+	if s == "" {
+		return defaultValue
+	}
+
+	return s
+}
+
+func ReadCSV(qs url.Values, key string, defaultValue []string) []string {
+	csv := qs.Get(key)
+	if csv == "" {
+		return defaultValue
+	}
+
+	return strings.Split(csv, ",")
+}
+
+func ReadInt(qs url.Values, key string, defaultValue int) (int, error) {
+	s := qs.Get(key)
+	if s == "" {
+		return defaultValue, nil
+	}
+
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return defaultValue, fmt.Errorf("%s must be an integer value", key)
+	}
+
+	return i, nil
+}
+
+// ReadOptionalInt64 returns nil when the query parameter is absent.
+func ReadOptionalInt64(qs url.Values, key string) (*int64, error) {
+	s := qs.Get(key)
+	if s == "" {
+		return nil, nil
+	}
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an integer value", key)
+	}
+
+	return &i, nil
+}
+
+// ReadOptionalTime parses an RFC 3339 query parameter and returns nil when it is absent.
+func ReadOptionalTime(qs url.Values, key string) (*time.Time, error) {
+	s := qs.Get(key)
+	if s == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 timestamp", key)
+	}
+
+	return &t, nil
+}
+
+// ReadOptionalDate parses a YYYY-MM-DD date; the result is midnight UTC.
+func ReadOptionalDate(qs url.Values, key string) (*time.Time, error) {
+	s := qs.Get(key)
+	if s == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a YYYY-MM-DD date", key)
+	}
+
+	return &t, nil
+}
+
 func WriteJSON(w http.ResponseWriter, status int, data Envelope, headers http.Header) error {
 	js, err := json.Marshal(data)
 
@@ -0,0 +1,78 @@
+package pgx_driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Notify queues a notification on channel. When q is a transaction the
+// notification is delivered to listeners only if, and when, it commits.
+func Notify(ctx context.Context, q QueryExecuter, channel, payload string) error {
+	_, err := q.Exec(ctx, "SELECT pg_notify($1, $2)", channel, payload)
+	return err
+}
+
+// Listen holds a dedicated pool connection subscribed to channel and calls
+// handle with the payload of every notification received, until ctx is
+// canceled. A lost connection is re-established with backoff; notifications
+// sent while no connection was listening are not redelivered, so handle is
+// also called with an empty payload after every (re)subscription to let the
+// caller catch up from its own source of truth.
+func (p *Postgres) Listen(ctx context.Context, channel string, handle func(payload string)) error {
+	const op = "storage.postgres.Listen"
+
+	backoff := p.baseRetryDelay
+	for {
+		err := p.listen(ctx, channel, handle)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		p.logger.Warn("postgresql listener disconnected",
+			slog.String("operation", op),
+			slog.String("channel", channel),
+			slog.String("retry_after", backoff.String()),
+			slog.Any("error", err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*_backoffMultiplier, p.maxRetryDelay)
+	}
+}
+
+func (p *Postgres) listen(ctx context.Context, channel string, handle func(payload string)) error {
+	conn, err := p.Pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire: %w", err)
+	}
+	// The session stays subscribed, so it must not go back to the pool.
+	defer conn.Hijack().Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	handle("")
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			return fmt.Errorf("wait: %w", err)
+		}
+
+		handle(notification.Payload)
+	}
+}
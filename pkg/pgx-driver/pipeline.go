@@ -0,0 +1,108 @@
+package pgx_driver
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Pipeline is a QueryExecuter that defers Exec statements into a pgx.Batch
+// and sends them in a single round trip together, right before the next
+// statement that returns rows or on Flush. Reads therefore always observe the
+// deferred writes, while a sequence of writes costs one round trip.
+//
+// Exec returns an empty command tag and no error: failures surface from the
+// next flush. Only statements whose command tag is not inspected may be sent
+// through a Pipeline.
+type Pipeline struct {
+	q     QueryExecuter
+	batch *pgx.Batch
+}
+
+func NewPipeline(q QueryExecuter) *Pipeline {
+	return &Pipeline{q: q, batch: &pgx.Batch{}}
+}
+
+// Flush sends the deferred statements and returns the first error among them.
+func (p *Pipeline) Flush(ctx context.Context) error {
+	if p.batch.Len() == 0 {
+		return nil
+	}
+
+	batch := p.batch
+	p.batch = &pgx.Batch{}
+
+	return p.q.SendBatch(ctx, batch).Close()
+}
+
+func (p *Pipeline) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if err := p.Flush(ctx); err != nil {
+		return nil, err
+	}
+
+	return p.q.Query(ctx, sql, args...)
+}
+
+func (p *Pipeline) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if err := p.Flush(ctx); err != nil {
+		return errRow{err: err}
+	}
+
+	return p.q.QueryRow(ctx, sql, args...)
+}
+
+func (p *Pipeline) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	p.batch.Queue(sql, args...)
+
+	return pgconn.CommandTag{}, nil
+}
+
+func (p *Pipeline) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	if err := p.Flush(ctx); err != nil {
+		return errBatchResults{err: err}
+	}
+
+	return p.q.SendBatch(ctx, b)
+}
+
+func (p *Pipeline) CopyFrom(
+	ctx context.Context,
+	tableName pgx.Identifier,
+	columnNames []string,
+	rowSrc pgx.CopyFromSource,
+) (int64, error) {
+	if err := p.Flush(ctx); err != nil {
+		return 0, err
+	}
+
+	return p.q.CopyFrom(ctx, tableName, columnNames, rowSrc)
+}
+
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(...any) error {
+	return r.err
+}
+
+type errBatchResults struct {
+	err error
+}
+
+func (r errBatchResults) Exec() (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, r.err
+}
+
+func (r errBatchResults) Query() (pgx.Rows, error) {
+	return nil, r.err
+}
+
+func (r errBatchResults) QueryRow() pgx.Row {
+	return errRow{err: r.err}
+}
+
+func (r errBatchResults) Close() error {
+	return r.err
+}
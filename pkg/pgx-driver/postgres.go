@@ -65,6 +65,7 @@ func New(dsn string, logger *slog.Logger, opts ...Option) (*Postgres, error) {
 	poolConfig.MaxConns = pg.maxPoolSize
 	poolConfig.MinConns = pg.maxIdleConns
 	poolConfig.MaxConnIdleTime = pg.maxIdleTime
+	poolConfig.ConnConfig.Tracer = queryTracer{}
 
 	currentBackoff := pg.baseRetryDelay
 	for attemptCount := 1; attemptCount <= pg.connAttempts; attemptCount++ {
@@ -102,6 +103,11 @@ func (p *Postgres) Ping(ctx context.Context) error {
 	return p.Pool.Ping(ctx)
 }
 
+// Stat returns a snapshot of the connection pool statistics.
+func (p *Postgres) Stat() *pgxpool.Stat {
+	return p.Pool.Stat()
+}
+
 func (p *Postgres) Close() {
 	if p.Pool != nil {
 		p.logger.Info("closing postgresql connection pool...")
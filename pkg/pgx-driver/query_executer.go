@@ -5,6 +5,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // QueryExecuter defines a unified interface for executing SQL queries and commands.
@@ -49,22 +50,35 @@ func (p *Postgres) CopyFrom(
 
 type TxQueryExecuter struct {
 	Tx pgx.Tx
+
+	// Span is the span of the transaction attempt. Statements are traced as
+	// its children even though callers pass the context they had before the
+	// transaction started.
+	Span trace.Span
+}
+
+func (t *TxQueryExecuter) withSpan(ctx context.Context) context.Context {
+	if t.Span == nil {
+		return ctx
+	}
+
+	return trace.ContextWithSpan(ctx, t.Span)
 }
 
 func (t *TxQueryExecuter) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
-	return t.Tx.Query(ctx, sql, args...)
+	return t.Tx.Query(t.withSpan(ctx), sql, args...)
 }
 
 func (t *TxQueryExecuter) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
-	return t.Tx.QueryRow(ctx, sql, args...)
+	return t.Tx.QueryRow(t.withSpan(ctx), sql, args...)
 }
 
 func (t *TxQueryExecuter) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
-	return t.Tx.Exec(ctx, sql, args...)
+	return t.Tx.Exec(t.withSpan(ctx), sql, args...)
 }
 
 func (t *TxQueryExecuter) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
-	return t.Tx.SendBatch(ctx, b)
+	return t.Tx.SendBatch(t.withSpan(ctx), b)
 }
 
 func (t *TxQueryExecuter) CopyFrom(
@@ -73,5 +87,5 @@ func (t *TxQueryExecuter) CopyFrom(
 	columnNames []string,
 	rowSrc pgx.CopyFromSource,
 ) (int64, error) {
-	return t.Tx.CopyFrom(ctx, tableName, columnNames, rowSrc)
+	return t.Tx.CopyFrom(t.withSpan(ctx), tableName, columnNames, rowSrc)
 }
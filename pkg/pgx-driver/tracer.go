@@ -0,0 +1,116 @@
+package pgx_driver
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "wallet-service/pkg/pgx-driver"
+
+// queryTracer starts a client span for every statement and batch sent over
+// a pool connection and for every wait on the pool for a connection, as a
+// child of the span in the context of the call. The tracer is looked up on
+// every call, so spans go to whichever provider is installed globally at the
+// time. Arguments are never recorded.
+type queryTracer struct{}
+
+var (
+	_ pgx.QueryTracer    = queryTracer{}
+	_ pgx.BatchTracer    = queryTracer{}
+	_ pgx.CopyFromTracer = queryTracer{}
+
+	_ pgxpool.AcquireTracer = queryTracer{}
+)
+
+func (queryTracer) start(ctx context.Context, name string, attrs ...attribute.KeyValue) context.Context {
+	attrs = append(attrs, semconv.DBSystemNamePostgreSQL)
+	ctx, _ = otel.Tracer(tracerName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+
+	return ctx
+}
+
+func (queryTracer) end(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (t queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	operation := operationName(data.SQL)
+
+	return t.start(ctx, operation,
+		semconv.DBOperationName(operation),
+		semconv.DBQueryText(data.SQL),
+	)
+}
+
+func (t queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	if data.Err == nil {
+		trace.SpanFromContext(ctx).SetAttributes(attribute.Int64("db.response.rows_affected", data.CommandTag.RowsAffected()))
+	}
+	t.end(ctx, data.Err)
+}
+
+func (t queryTracer) TraceBatchStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	return t.start(ctx, "BATCH",
+		semconv.DBOperationName("BATCH"),
+		semconv.DBOperationBatchSize(data.Batch.Len()),
+	)
+}
+
+// TraceBatchQuery records each statement of a batch as an event of the batch
+// span: they share a single round trip.
+func (queryTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	attrs := []attribute.KeyValue{semconv.DBQueryText(data.SQL)}
+	if data.Err != nil {
+		attrs = append(attrs, attribute.String("error.message", data.Err.Error()))
+	}
+	trace.SpanFromContext(ctx).AddEvent(operationName(data.SQL), trace.WithAttributes(attrs...))
+}
+
+func (t queryTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	t.end(ctx, data.Err)
+}
+
+func (t queryTracer) TraceCopyFromStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromStartData) context.Context {
+	return t.start(ctx, "COPY "+data.TableName.Sanitize(),
+		semconv.DBOperationName("COPY"),
+		semconv.DBCollectionName(data.TableName.Sanitize()),
+	)
+}
+
+func (t queryTracer) TraceCopyFromEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	t.end(ctx, data.Err)
+}
+
+func (t queryTracer) TraceAcquireStart(ctx context.Context, _ *pgxpool.Pool, _ pgxpool.TraceAcquireStartData) context.Context {
+	return t.start(ctx, "pool.acquire")
+}
+
+func (t queryTracer) TraceAcquireEnd(ctx context.Context, _ *pgxpool.Pool, data pgxpool.TraceAcquireEndData) {
+	t.end(ctx, data.Err)
+}
+
+// operationName is the leading keyword of a statement, e.g. SELECT or WITH.
+func operationName(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "QUERY"
+	}
+
+	return strings.ToUpper(fields[0])
+}
@@ -12,8 +12,14 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+const tracerName = "wallet-service/pkg/pgx-driver/transaction"
+
 const (
 	_defaultMaxAttempts    = 3
 	_defaultBaseRetryDelay = 10 * time.Millisecond
@@ -37,6 +43,8 @@ type manager struct {
 	maxAttempts    int
 	baseRetryDelay time.Duration
 	maxRetryDelay  time.Duration
+
+	onRetry func(tsName string)
 }
 
 func NewManager(pool *pgxdriver.Postgres, logger *slog.Logger, opts ...Option) (Manager, error) {
@@ -69,14 +77,29 @@ func (tm *manager) ExecuteInTransaction(
 	currentBackoff := tm.baseRetryDelay
 
 	for attempt := 1; attempt <= tm.maxAttempts; attempt++ {
-		err := tm.doTransaction(ctx, tsName, fn)
+		// Each attempt gets a span of its own, parenting the pool wait and
+		// the statements run in it, so retries show up side by side.
+		attemptCtx, span := otel.Tracer(tracerName).Start(ctx, "transaction "+tsName, trace.WithAttributes(
+			attribute.String("db.transaction.name", tsName),
+			attribute.Int("db.transaction.attempt", attempt),
+			attribute.Int("db.transaction.max_attempts", tm.maxAttempts),
+		))
+
+		err := tm.doTransaction(attemptCtx, tsName, fn)
 		if err == nil {
+			span.End()
 			return nil
 		}
 
 		lastErr = err
 
-		if !isRetryableError(err) || attempt == tm.maxAttempts {
+		retryable := isRetryableError(err)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(attribute.Bool("db.transaction.retryable", retryable))
+
+		if !retryable || attempt == tm.maxAttempts {
+			span.End()
 			return err
 		}
 		//nolint:gosec
@@ -84,6 +107,9 @@ func (tm *manager) ExecuteInTransaction(
 			rand.Int64N(int64(currentBackoff*_backoffMultiplier)),
 		), tm.maxRetryDelay)
 
+		span.SetAttributes(attribute.Int64("db.transaction.retry_after_ms", jitter.Milliseconds()))
+		span.End()
+
 		tm.logger.LogAttrs(ctx, slog.LevelWarn, "retrying transaction",
 			slog.String("op", op),
 			slog.String("transaction", tsName),
@@ -93,6 +119,10 @@ func (tm *manager) ExecuteInTransaction(
 			slog.Any("error", lastErr),
 		)
 
+		if tm.onRetry != nil {
+			tm.onRetry(tsName)
+		}
+
 		select {
 		case <-time.After(jitter):
 			currentBackoff = min(currentBackoff*_backoffMultiplier, tm.maxRetryDelay)
@@ -110,7 +140,7 @@ func (tm *manager) doTransaction(ctx context.Context, tsName string, fn func(tx
 	}
 	defer tm.safelyRollback(ctx, tx, tsName)
 
-	if err := fn(&pgxdriver.TxQueryExecuter{Tx: tx}); err != nil {
+	if err := fn(&pgxdriver.TxQueryExecuter{Tx: tx, Span: trace.SpanFromContext(ctx)}); err != nil {
 		return HandleError(tsName, "execute", err)
 	}
 
@@ -32,6 +32,14 @@ func MaxRetryDelay(delay time.Duration) Option {
 	}
 }
 
+// OnRetry sets a function called with the transaction name each time a
+// transaction is retried.
+func OnRetry(fn func(tsName string)) Option {
+	return func(m *manager) {
+		m.onRetry = fn
+	}
+}
+
 func (tm *manager) validate() error {
 	if tm.maxAttempts <= 0 {
 		return ErrInvalidMaxAttempts
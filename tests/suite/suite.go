@@ -8,8 +8,6 @@ import (
 	"testing"
 	"time"
 	"wallet-service/internal/config"
-
-	"github.com/stretchr/testify/require"
 )
 
 type Suite struct {
@@ -18,6 +16,7 @@ type Suite struct {
 
 	Client  *http.Client
 	BaseURL string
+	APIKey  string
 }
 
 func New(t *testing.T) (context.Context, *Suite) {
@@ -38,6 +37,8 @@ func New(t *testing.T) (context.Context, *Suite) {
 		Cfg:     cfg,
 		Client:  client,
 		BaseURL: "http://" + cfg.HTTPServer.Address + "/api/v1",
+		// An admin key, e.g. from "wallet-service apikey create tests admin".
+		APIKey: os.Getenv("WALLET_API_KEY"),
 	}
 
 	return ctx, suite
@@ -65,14 +66,16 @@ func (s *Suite) Request(
 ) (*http.Response, error) {
 
 	req, err := http.NewRequestWithContext(ctx, method, s.BaseURL+path, body)
-	require.NoError(s.T, err)
+	if err != nil {
+		return nil, err
+	}
 
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	if err != nil {
-		return nil, err
+	if s.APIKey != "" {
+		req.Header.Set("X-API-Key", s.APIKey)
 	}
 
 	return s.Client.Do(req)
@@ -74,3 +74,55 @@ func TestDepositFlow(t *testing.T) {
 
 	assert.Equal(t, deposit.Amount, walletUpdate.Balance)
 }
+
+func TestOperationBalanceAfter(t *testing.T) {
+	ctx, st := suite.New(t)
+
+	payload, err := json.Marshal(struct {
+		Amount int64 `json:"amount"`
+	}{Amount: 50})
+	require.NoError(t, err)
+
+	resp, err := st.Request(ctx, http.MethodPost, "/wallets", bytes.NewReader(payload))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var created struct {
+		Data struct {
+			Wallet models.Wallet `json:"wallet"`
+		} `json:"data"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&created)
+	require.NoError(t, err)
+
+	walletID := created.Data.Wallet.ID
+
+	payload, err = json.Marshal(struct {
+		WalletID      uuid.UUID `json:"wallet_id"`
+		Amount        int64     `json:"amount"`
+		OperationType string    `json:"operation_type"`
+	}{WalletID: walletID, Amount: 100, OperationType: "DEPOSIT"})
+	require.NoError(t, err)
+
+	resp, err = st.Request(ctx, http.MethodPost, "/wallets/operation", bytes.NewReader(payload))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = st.Request(ctx, http.MethodGet, "/wallets/"+walletID.String()+"/operations", nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var history struct {
+		Data struct {
+			Operations []*models.Operation `json:"operations"`
+		} `json:"data"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&history)
+	require.NoError(t, err)
+
+	require.Len(t, history.Data.Operations, 1)
+	require.NotNil(t, history.Data.Operations[0].BalanceAfter)
+	assert.Equal(t, int64(150), *history.Data.Operations[0].BalanceAfter)
+}